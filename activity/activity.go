@@ -0,0 +1,103 @@
+// Package activity renders one repo's recent commits as Markdown notes,
+// grouped by day or by conventional type. Unlike the AI context export it's
+// meant to be read by people, e.g. pasted into a standup.
+package activity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dylan/gitdash/commit"
+	"github.com/dylan/gitdash/git"
+)
+
+// headings names the type groups, in commit.Types order; commits without
+// a known type go under Other.
+var headings = map[string]string{
+	"feat":     "Features",
+	"fix":      "Fixes",
+	"refactor": "Refactoring",
+	"docs":     "Docs",
+	"test":     "Tests",
+	"chore":    "Chores",
+	"perf":     "Performance",
+	"style":    "Style",
+	"ci":       "CI",
+	"build":    "Build",
+	"revert":   "Reverts",
+}
+
+// Build renders commits (newest first, as git.GetRecentCommits returns
+// them) under a title naming the repo, branch and range. byType groups
+// them by conventional type instead of by day.
+func Build(repo, branch string, commits []git.RecentCommitInfo, days int, byType bool) string {
+	var b strings.Builder
+	span := fmt.Sprintf("last %d days", days)
+	if days == 1 {
+		span = "last day"
+	}
+	fmt.Fprintf(&b, "# %s activity (%s)\n\n", repo, span)
+	noun := "commits"
+	if len(commits) == 1 {
+		noun = "commit"
+	}
+	fmt.Fprintf(&b, "%d %s on `%s`.\n\n", len(commits), noun, branch)
+	if byType {
+		writeByType(&b, commits)
+	} else {
+		writeByDay(&b, commits)
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+func writeByDay(b *strings.Builder, commits []git.RecentCommitInfo) {
+	var day string
+	for _, c := range commits {
+		if d := dayOf(c.Date); d != day {
+			if day != "" {
+				b.WriteString("\n")
+			}
+			day = d
+			fmt.Fprintf(b, "## %s\n\n", day)
+		}
+		fmt.Fprintf(b, "- %s (%s)\n", c.Message, c.Hash)
+	}
+}
+
+func writeByType(b *strings.Builder, commits []git.RecentCommitInfo) {
+	grouped := map[string][]string{}
+	for _, c := range commits {
+		p := commit.Parse(c.Message)
+		desc := p.Description
+		if p.Scope != "" {
+			desc = fmt.Sprintf("**%s:** %s", p.Scope, desc)
+		}
+		grouped[p.Type] = append(grouped[p.Type], fmt.Sprintf("- %s (%s)", desc, c.Hash))
+	}
+	types := commit.Types[:len(commit.Types):len(commit.Types)]
+	for _, t := range append(types, "") {
+		lines := grouped[t]
+		if len(lines) == 0 {
+			continue
+		}
+		heading := headings[t]
+		if heading == "" {
+			heading = "Other"
+		}
+		fmt.Fprintf(b, "## %s\n\n%s\n\n", heading, strings.Join(lines, "\n"))
+	}
+}
+
+// dayOf turns an ISO-ish author date ("2024-05-01 14:03:00 +0200") into
+// a heading like "Wed 2024-05-01".
+func dayOf(date string) string {
+	if len(date) < 10 {
+		return date
+	}
+	t, err := time.Parse("2006-01-02", date[:10])
+	if err != nil {
+		return date[:10]
+	}
+	return t.Format("Mon 2006-01-02")
+}
@@ -14,13 +14,36 @@ func stripCodeFences(s string) string {
 	return s
 }
 
+const commitMessagePrompt = "Generate a short commit message for this diff. Format:\n" +
+	"type(scope): subject\n\n" +
+	"- point 1\n" +
+	"- point 2\n\n" +
+	"Keep it to 1-2 bullet points max. No prose. Return only the message."
+
 func GenerateCommitMessage(diff string) (string, error) {
-	cmd := exec.Command("claude", "--print", "-p",
-		"Generate a short commit message for this diff. Format:\n"+
-			"type(scope): subject\n\n"+
-			"- point 1\n"+
-			"- point 2\n\n"+
-			"Keep it to 1-2 bullet points max. No prose. Return only the message.")
+	return commitMessage(diff, commitPrompt(""))
+}
+
+// RegenerateCommitMessage asks for a different take on diff than previous,
+// the suggestion that was turned down. The CLI has no temperature setting,
+// so the prompt does the varying.
+func RegenerateCommitMessage(diff, previous string) (string, error) {
+	return commitMessage(diff, commitPrompt(previous))
+}
+
+// commitPrompt is the commit message prompt, steering away from previous
+// when it's set.
+func commitPrompt(previous string) string {
+	if strings.TrimSpace(previous) == "" {
+		return commitMessagePrompt
+	}
+	return commitMessagePrompt + "\n\n" +
+		"This suggestion was rejected, so write a noticeably different one " +
+		"(another angle, scope or wording):\n\n" + previous
+}
+
+func commitMessage(diff, prompt string) (string, error) {
+	cmd := exec.Command("claude", "--print", "-p", prompt)
 	cmd.Stdin = strings.NewReader(diff)
 
 	out, err := cmd.CombinedOutput()
@@ -39,3 +62,75 @@ func GenerateCommitMessage(diff string) (string, error) {
 	msg = stripCodeFences(msg)
 	return msg, nil
 }
+
+// SuggestBranchName asks for a short kebab-case branch name describing
+// diff, without a type prefix (the picker adds that).
+func SuggestBranchName(diff string) (string, error) {
+	if _, err := exec.LookPath("claude"); err != nil {
+		return "", fmt.Errorf("claude CLI not found — install it to use AI features")
+	}
+	cmd := exec.Command("claude", "--print", "-p",
+		"Suggest a git branch name for this diff: 2-5 lowercase words in kebab-case, "+
+			"no type prefix like feat/ or fix/. Return only the name.")
+	cmd.Stdin = strings.NewReader(diff)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("claude: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	name := kebabCase(stripCodeFences(strings.TrimSpace(string(out))))
+	if name == "" {
+		return "", fmt.Errorf("claude returned no usable branch name")
+	}
+	return name, nil
+}
+
+// ExplainCommit asks for a short plain-language summary of what a commit
+// does, given its `git show` output.
+func ExplainCommit(show string) (string, error) {
+	if _, err := exec.LookPath("claude"); err != nil {
+		return "", fmt.Errorf("claude CLI not found — install it to use AI features")
+	}
+	cmd := exec.Command("claude", "--print", "-p",
+		"Explain in plain language what this commit does and why, in 2-3 short sentences. "+
+			"No headings, lists or markdown. Return only the explanation.")
+	cmd.Stdin = strings.NewReader(show)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("claude: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	summary := stripCodeFences(strings.TrimSpace(string(out)))
+	if summary == "" {
+		return "", fmt.Errorf("claude returned empty response")
+	}
+	return summary, nil
+}
+
+// kebabCase turns a model's answer into a branch name: the first line,
+// any type prefix dropped, lowercased, with runs of other characters
+// collapsed to single dashes.
+func kebabCase(s string) string {
+	s, _, _ = strings.Cut(s, "\n")
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		s = s[i+1:]
+	}
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	name := b.String()
+	if len(name) > 50 {
+		name = strings.TrimRight(name[:50], "-")
+	}
+	return name
+}
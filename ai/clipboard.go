@@ -1,12 +1,84 @@
 package ai
 
 import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"strings"
 )
 
+// forceOSC52 skips native clipboard tools and always writes an OSC 52 escape.
+var forceOSC52 bool
+
+// SetOSC52 forces OSC 52 clipboard writes (useful over SSH).
+func SetOSC52(enabled bool) { forceOSC52 = enabled }
+
+// clipboardTools are tried in order; the first one found on PATH is used.
+var clipboardTools = [][]string{
+	{"pbcopy"},
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+}
+
+// CopyToClipboard copies text using a native clipboard tool, falling back to
+// an OSC 52 terminal escape when none is available or OSC 52 is forced.
 func CopyToClipboard(text string) error {
-	cmd := exec.Command("pbcopy")
-	cmd.Stdin = strings.NewReader(text)
-	return cmd.Run()
+	if !forceOSC52 {
+		for _, tool := range clipboardTools {
+			if _, err := exec.LookPath(tool[0]); err != nil {
+				continue
+			}
+			cmd := exec.Command(tool[0], tool[1:]...)
+			cmd.Stdin = strings.NewReader(text)
+			if err := cmd.Run(); err == nil {
+				return nil
+			}
+		}
+	}
+	return copyOSC52(text)
+}
+
+// copyOSC52 writes the base64-encoded payload as an OSC 52 escape sequence so
+// the local terminal emulator sets its clipboard, even over SSH.
+func copyOSC52(text string) error {
+	seq := fmt.Sprintf("\x1b]52;c;%s\x07", base64.StdEncoding.EncodeToString([]byte(text)))
+	// tmux swallows unknown escapes unless wrapped in a DCS passthrough
+	if os.Getenv("TMUX") != "" {
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+
+	var out io.Writer = os.Stdout
+	if tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0); err == nil {
+		defer tty.Close()
+		out = tty
+	}
+	_, err := io.WriteString(out, seq)
+	return err
+}
+
+// pasteTools read the clipboard, tried in order like clipboardTools.
+var pasteTools = [][]string{
+	{"pbpaste"},
+	{"wl-paste", "--no-newline"},
+	{"xclip", "-selection", "clipboard", "-o"},
+	{"xsel", "--clipboard", "--output"},
+}
+
+// ReadClipboard returns the clipboard's text using a native clipboard
+// tool. OSC 52 can't be read back, so without one it's an error.
+func ReadClipboard() (string, error) {
+	for _, tool := range pasteTools {
+		if _, err := exec.LookPath(tool[0]); err != nil {
+			continue
+		}
+		out, err := exec.Command(tool[0], tool[1:]...).Output()
+		if err == nil {
+			return string(out), nil
+		}
+	}
+	return "", errors.New("no clipboard tool to read from (pbpaste, wl-paste, xclip or xsel)")
 }
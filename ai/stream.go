@@ -0,0 +1,85 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// streamLine is the part of a line of `claude --output-format stream-json`
+// that matters here: text deltas while the answer is written, then the
+// final result.
+type streamLine struct {
+	Type  string `json:"type"`
+	Event struct {
+		Type  string `json:"type"`
+		Delta struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"delta"`
+	} `json:"event"`
+	Result  string `json:"result"`
+	IsError bool   `json:"is_error"`
+}
+
+// StreamCommitMessage is RegenerateCommitMessage, but calls onChunk with
+// each piece of the message as the CLI writes it. The returned message is
+// the complete, cleaned-up one. A CLI too old to stream gets the blocking
+// call instead.
+func StreamCommitMessage(diff, previous string, onChunk func(string)) (string, error) {
+	if _, err := exec.LookPath("claude"); err != nil {
+		return "", fmt.Errorf("claude CLI not found — install it to use AI features")
+	}
+	cmd := exec.Command("claude", "--print", "--verbose",
+		"--output-format", "stream-json", "--include-partial-messages",
+		"-p", commitPrompt(previous))
+	cmd.Stdin = strings.NewReader(diff)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", err
+	}
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("claude: %w", err)
+	}
+
+	var result string
+	var gotResult, failed, streamed bool
+	sc := bufio.NewScanner(stdout)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		var l streamLine
+		if json.Unmarshal(sc.Bytes(), &l) != nil {
+			continue
+		}
+		switch {
+		case l.Type == "stream_event" && l.Event.Type == "content_block_delta" && l.Event.Delta.Type == "text_delta":
+			streamed = true
+			onChunk(l.Event.Delta.Text)
+		case l.Type == "result":
+			gotResult, result, failed = true, l.Result, l.IsError
+		}
+	}
+	waitErr := cmd.Wait()
+
+	if !gotResult && !streamed {
+		// Nothing came back in the stream format, most likely because the
+		// CLI doesn't know the flags
+		return RegenerateCommitMessage(diff, previous)
+	}
+	if failed {
+		return "", fmt.Errorf("claude: %s", strings.TrimSpace(result))
+	}
+	if waitErr != nil {
+		return "", fmt.Errorf("claude: %s: %w", strings.TrimSpace(stderr.String()), waitErr)
+	}
+	msg := stripCodeFences(strings.TrimSpace(result))
+	if msg == "" {
+		return "", fmt.Errorf("claude returned empty response")
+	}
+	return msg, nil
+}
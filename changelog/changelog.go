@@ -0,0 +1,107 @@
+// Package changelog turns a range of conventional commits into Markdown
+// release notes.
+package changelog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dylan/gitdash/commit"
+	"github.com/dylan/gitdash/git"
+)
+
+// sections lists the headings commits are grouped under, in order. Types
+// not listed here go under Other.
+var sections = []struct {
+	heading string
+	types   []string
+}{
+	{"Features", []string{"feat"}},
+	{"Bug Fixes", []string{"fix"}},
+	{"Performance", []string{"perf"}},
+	{"Refactoring", []string{"refactor"}},
+	{"Documentation", []string{"docs"}},
+	{"Tests", []string{"test"}},
+	{"Build & CI", []string{"build", "ci"}},
+	{"Reverts", []string{"revert"}},
+}
+
+// Build renders commits as Markdown sections grouped by conventional type,
+// with breaking changes first. Chores and style commits are left out, and
+// anything without a type goes under Other. Commits keep their given order
+// within a section.
+func Build(commits []git.RecentCommitInfo) string {
+	heading := map[string]string{}
+	for _, s := range sections {
+		for _, t := range s.types {
+			heading[t] = s.heading
+		}
+	}
+
+	var breaking []string
+	grouped := map[string][]string{}
+	for _, c := range commits {
+		p := commit.Parse(c.Message)
+		line := bullet(p, c.Hash)
+		if p.Breaking {
+			breaking = append(breaking, line)
+		}
+		switch {
+		case p.Type == "chore" || p.Type == "style":
+			continue
+		case heading[p.Type] != "":
+			grouped[heading[p.Type]] = append(grouped[heading[p.Type]], line)
+		default:
+			grouped["Other"] = append(grouped["Other"], line)
+		}
+	}
+
+	var b strings.Builder
+	writeSection(&b, "Breaking Changes", breaking)
+	for _, s := range sections {
+		writeSection(&b, s.heading, grouped[s.heading])
+	}
+	writeSection(&b, "Other", grouped["Other"])
+	if b.Len() == 0 {
+		return "No notable changes.\n"
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// Entry is a release heading followed by the Build output for it.
+func Entry(title string, commits []git.RecentCommitInfo) string {
+	return "## " + title + "\n\n" + Build(commits)
+}
+
+// Prepend adds entry to the top of an existing changelog, below its
+// "# ..." title if it has one.
+func Prepend(existing, entry string) string {
+	existing = strings.TrimLeft(existing, "\n")
+	if existing == "" {
+		return "# Changelog\n\n" + entry
+	}
+	if strings.HasPrefix(existing, "# ") {
+		title, rest, _ := strings.Cut(existing, "\n")
+		return title + "\n\n" + entry + "\n" + strings.TrimLeft(rest, "\n")
+	}
+	return entry + "\n" + existing
+}
+
+func bullet(p commit.Conventional, hash string) string {
+	desc := p.Description
+	if p.Scope != "" {
+		desc = fmt.Sprintf("**%s:** %s", p.Scope, desc)
+	}
+	return fmt.Sprintf("- %s (%s)", desc, hash)
+}
+
+func writeSection(b *strings.Builder, heading string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "### %s\n\n", heading)
+	for _, l := range lines {
+		b.WriteString(l + "\n")
+	}
+	b.WriteString("\n")
+}
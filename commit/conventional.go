@@ -0,0 +1,63 @@
+package commit
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Conventional is a subject line split into its conventional commit parts,
+// "type(scope)!: description".
+type Conventional struct {
+	Type        string // lowercased, "" when the subject has no known type
+	Scope       string
+	Breaking    bool   // "!" after the type or scope
+	Description string // the subject after the prefix, or all of it
+	Prefix      string // "type(scope)!:" as written, for highlighting
+}
+
+// ParsePrefix parses a subject that starts directly with its type. Only
+// the types in Types are recognized.
+func ParsePrefix(subject string) (Conventional, bool) {
+	lower := strings.ToLower(subject)
+	for _, t := range Types {
+		if !strings.HasPrefix(lower, t) {
+			continue
+		}
+		c := Conventional{Type: t}
+		rest := subject[len(t):]
+		if strings.HasPrefix(rest, "(") {
+			end := strings.Index(rest, ")")
+			if end == -1 {
+				continue
+			}
+			c.Scope = rest[1:end]
+			rest = rest[end+1:]
+		}
+		if strings.HasPrefix(rest, "!") {
+			c.Breaking = true
+			rest = rest[1:]
+		}
+		if !strings.HasPrefix(rest, ":") {
+			continue
+		}
+		c.Prefix = subject[:len(subject)-len(rest)+1]
+		c.Description = strings.TrimLeft(rest[1:], " ")
+		return c, true
+	}
+	return Conventional{Description: subject}, false
+}
+
+// Parse parses subject like ParsePrefix, first skipping leading gitmoji and
+// a "[TICKET]" reference. Without a type, Description is what's left.
+func Parse(subject string) Conventional {
+	s := strings.TrimLeftFunc(subject, func(r rune) bool {
+		return r == ' ' || (r > unicode.MaxASCII && !unicode.IsLetter(r))
+	})
+	if strings.HasPrefix(s, "[") {
+		if end := strings.Index(s, "]"); end != -1 {
+			s = strings.TrimLeft(s[end+1:], " ")
+		}
+	}
+	c, _ := ParsePrefix(s)
+	return c
+}
@@ -0,0 +1,16 @@
+package commit
+
+import "strings"
+
+// StripComments drops the lines starting with "#", as git does with a
+// template's instructions when it commits, and trims the blank lines left
+// at either end.
+func StripComments(message string) string {
+	var kept []string
+	for _, line := range strings.Split(message, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			kept = append(kept, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
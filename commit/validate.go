@@ -0,0 +1,127 @@
+package commit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Types are the conventional commit types accepted by RequireType.
+var Types = []string{
+	"feat", "fix", "refactor", "docs",
+	"test", "chore", "perf", "style",
+	"ci", "build", "revert",
+}
+
+// Rules controls which checks Validate runs. Zero values disable a check.
+type Rules struct {
+	MaxSubject          int  // max subject length in characters, 0 = unlimited
+	RequireType         bool // subject must start with "type: " or "type(scope): "
+	Imperative          bool // flag subjects like "added x" or "fixes y"
+	BlankLineBeforeBody bool // second line must be empty when there's a body
+}
+
+// Validate checks a commit message against rules and returns one
+// human-readable violation per failed check.
+func Validate(message string, rules Rules) []string {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	subject := strings.TrimRight(lines[0], " ")
+
+	var violations []string
+	if rules.MaxSubject > 0 {
+		if n := len([]rune(subject)); n > rules.MaxSubject {
+			violations = append(violations, fmt.Sprintf("subject is %d chars (max %d)", n, rules.MaxSubject))
+		}
+	}
+
+	desc, hasType := description(subject)
+	if rules.RequireType && !hasType {
+		violations = append(violations, "subject has no conventional type (e.g. \"feat: \")")
+	}
+	if rules.Imperative {
+		if word := firstWord(desc); word != "" && !isImperative(word) {
+			violations = append(violations, fmt.Sprintf("use the imperative mood (%q → %q)", word, imperativeOf(word)))
+		}
+	}
+	if rules.BlankLineBeforeBody && len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		violations = append(violations, "missing blank line between subject and body")
+	}
+	return violations
+}
+
+// description strips leading gitmoji, "[TICKET]" prefixes and the
+// conventional type from subject. hasType reports whether a type was found.
+func description(subject string) (desc string, hasType bool) {
+	c := Parse(subject)
+	return c.Description, c.Type != ""
+}
+
+func firstWord(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimRight(fields[0], ".,:;"))
+}
+
+// commonVerbs are checked for third-person forms ("adds", "fixes"); a bare
+// trailing "s" is too ambiguous to flag on its own ("docs", "tests").
+var commonVerbs = map[string]bool{
+	"add": true, "fix": true, "update": true, "remove": true, "change": true,
+	"improve": true, "refactor": true, "move": true, "rename": true,
+	"create": true, "implement": true, "use": true, "make": true,
+	"allow": true, "bump": true, "handle": true, "support": true,
+	"clean": true, "drop": true, "show": true, "set": true, "enable": true,
+	"disable": true, "replace": true, "delete": true, "extract": true,
+	"introduce": true, "simplify": true, "prevent": true, "ensure": true,
+}
+
+// nonVerbs end in "ed"/"ing" but aren't past or progressive forms.
+var nonVerbs = map[string]bool{
+	"embed": true, "feed": true, "need": true, "seed": true, "speed": true,
+	"shed": true, "bring": true, "ping": true, "ring": true, "sing": true,
+	"string": true, "swing": true, "thing": true, "wing": true,
+}
+
+// isImperative reports whether word looks like an imperative verb. It's a
+// heuristic: only obvious past ("added"), progressive ("adding") and
+// third-person ("adds") forms are rejected.
+func isImperative(word string) bool {
+	if nonVerbs[word] || commonVerbs[word] {
+		return true
+	}
+	if len(word) > 4 && (strings.HasSuffix(word, "ed") || strings.HasSuffix(word, "ing")) {
+		return false
+	}
+	if strings.HasSuffix(word, "es") && commonVerbs[strings.TrimSuffix(word, "es")] {
+		return false
+	}
+	if strings.HasSuffix(word, "s") && commonVerbs[strings.TrimSuffix(word, "s")] {
+		return false
+	}
+	return true
+}
+
+// imperativeOf guesses the imperative form of word for the violation hint.
+func imperativeOf(word string) string {
+	candidates := []string{
+		strings.TrimSuffix(word, "es"),
+		strings.TrimSuffix(word, "s"),
+		strings.TrimSuffix(word, "ed") + "e",
+		strings.TrimSuffix(word, "ed"),
+		strings.TrimSuffix(word, "d"),
+		strings.TrimSuffix(word, "ing") + "e",
+		strings.TrimSuffix(word, "ing"),
+	}
+	for _, c := range candidates {
+		if c != word && commonVerbs[c] {
+			return c
+		}
+	}
+	switch {
+	case strings.HasSuffix(word, "ing"):
+		return strings.TrimSuffix(word, "ing")
+	case strings.HasSuffix(word, "ed"):
+		return strings.TrimSuffix(word, "ed")
+	}
+	return strings.TrimSuffix(word, "s")
+}
@@ -224,6 +224,9 @@ func (d *DB) GetAllData() (*ConductorData, error) {
 		return nil, err
 	}
 
+	// Commit links are extra detail; don't lose the rest over them
+	commits, _ := d.GetFeatureCommits()
+
 	var passed, total int
 	for _, f := range features {
 		total++
@@ -238,6 +241,7 @@ func (d *DB) GetAllData() (*ConductorData, error) {
 		Handoff:  handoff,
 		Quality:  quality,
 		Memories: memories,
+		Commits:  commits,
 		Passed:   passed,
 		Total:    total,
 	}, nil
@@ -317,6 +321,28 @@ func (d *DB) GetCommitContext(hash string) (*CommitContext, error) {
 	return ctx, nil
 }
 
+// GetFeatureCommits returns the commits recorded for each feature, keyed by
+// feature ID, newest first.
+func (d *DB) GetFeatureCommits() (map[string][]FeatureCommit, error) {
+	rows, err := d.db.Query(`SELECT feature_id, commit_hash, COALESCE(message, ''), COALESCE(created_at, 0)
+		FROM commits WHERE feature_id IS NOT NULL ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make(map[string][]FeatureCommit)
+	for rows.Next() {
+		var featureID string
+		var c FeatureCommit
+		if err := rows.Scan(&featureID, &c.Hash, &c.Message, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		results[featureID] = append(results[featureID], c)
+	}
+	return results, rows.Err()
+}
+
 // GetCommitFiles returns files_changed from prior commits on a feature.
 func (d *DB) GetCommitFiles(featureID string) ([]string, error) {
 	rows, err := d.db.Query(`SELECT COALESCE(files_changed, '[]')
@@ -0,0 +1,158 @@
+package conductor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Sections picks which parts of the conductor data RenderMarkdownSections
+// includes.
+type Sections struct {
+	Session  bool
+	Features bool
+	Quality  bool
+	Memories bool
+}
+
+// AllSections includes everything.
+var AllSections = Sections{Session: true, Features: true, Quality: true, Memories: true}
+
+// statusOrder lists feature statuses in the order they're reported within
+// a phase: work in flight first, done last.
+var statusOrder = []string{"in_progress", "failed", "blocked", "pending", "passed"}
+
+// RenderMarkdown renders data as a Markdown status report, for pasting into
+// a status update or issue.
+func RenderMarkdown(data *ConductorData) string {
+	return RenderMarkdownSections(data, AllSections)
+}
+
+// RenderMarkdownSections renders only the chosen sections of data.
+func RenderMarkdownSections(data *ConductorData, s Sections) string {
+	var b strings.Builder
+	b.WriteString("# Conductor Status\n\n")
+	if data == nil {
+		b.WriteString("No conductor data.\n")
+		return b.String()
+	}
+
+	pct := 0
+	if data.Total > 0 {
+		pct = data.Passed * 100 / data.Total
+	}
+	fmt.Fprintf(&b, "**Progress:** %d/%d features passed (%d%%)\n\n", data.Passed, data.Total, pct)
+
+	if s.Session {
+		writeSession(&b, data)
+	}
+	if s.Features && len(data.Features) > 0 {
+		writeFeatures(&b, data.Features)
+	}
+	if s.Quality && len(data.Quality) > 0 {
+		writeQuality(&b, data.Quality)
+	}
+	if s.Memories && len(data.Memories) > 0 {
+		b.WriteString("## Recent Memories\n\n")
+		for _, m := range data.Memories {
+			fmt.Fprintf(&b, "- **%s**", m.Name)
+			if len(m.Tags) > 0 {
+				fmt.Fprintf(&b, " (%s)", strings.Join(m.Tags, ", "))
+			}
+			if first, _, _ := strings.Cut(strings.TrimSpace(m.Content), "\n"); first != "" {
+				b.WriteString(": " + first)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+func writeSession(b *strings.Builder, data *ConductorData) {
+	if data.Session == nil && data.Handoff == nil {
+		return
+	}
+	if s := data.Session; s != nil {
+		fmt.Fprintf(b, "## Session #%d (%s)\n\n", s.Number, s.Status)
+		if s.ProgressNotes != "" {
+			b.WriteString(s.ProgressNotes + "\n\n")
+		}
+	} else {
+		b.WriteString("## Session\n\n")
+	}
+	if h := data.Handoff; h != nil {
+		if h.CurrentTask != "" {
+			fmt.Fprintf(b, "**Current task:** %s\n\n", h.CurrentTask)
+		}
+		writeList(b, "Next steps", h.NextSteps)
+		writeList(b, "Blockers", h.Blockers)
+	}
+}
+
+func writeFeatures(b *strings.Builder, features []Feature) {
+	rank := make(map[string]int, len(statusOrder))
+	for i, st := range statusOrder {
+		rank[st] = i
+	}
+	sorted := make([]Feature, len(features))
+	copy(sorted, features)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Phase != sorted[j].Phase {
+			return sorted[i].Phase < sorted[j].Phase
+		}
+		return rank[sorted[i].Status] < rank[sorted[j].Status]
+	})
+
+	b.WriteString("## Features\n")
+	phase := -1
+	for _, f := range sorted {
+		if f.Phase != phase {
+			phase = f.Phase
+			fmt.Fprintf(b, "\n### Phase %d\n\n", phase)
+		}
+		check := " "
+		if f.Status == "passed" {
+			check = "x"
+		}
+		fmt.Fprintf(b, "- [%s] %s", check, f.Description)
+		if f.Category != "" {
+			fmt.Fprintf(b, " `%s`", f.Category)
+		}
+		if f.Status != "passed" && f.Status != "pending" {
+			fmt.Fprintf(b, " — %s", strings.ReplaceAll(f.Status, "_", " "))
+		}
+		if f.LastError != "" && f.Status != "passed" {
+			first, _, _ := strings.Cut(f.LastError, "\n")
+			fmt.Fprintf(b, " (%s)", first)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+}
+
+func writeQuality(b *strings.Builder, quality []QualityReflection) {
+	b.WriteString("## Open Quality Issues\n\n")
+	for _, q := range quality {
+		fmt.Fprintf(b, "### %s\n\n", strings.ReplaceAll(q.ReflectionType, "_", " "))
+		writeList(b, "Shortcuts taken", q.ShortcutsTaken)
+		writeList(b, "Tests skipped", q.TestsSkipped)
+		writeList(b, "Known limitations", q.KnownLimitations)
+		writeList(b, "Deferred work", q.DeferredWork)
+		writeList(b, "Technical debt", q.TechnicalDebt)
+	}
+}
+
+// writeList writes a bold label and a bullet per item, or nothing when
+// items is empty.
+func writeList(b *strings.Builder, label string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "**%s:**\n\n", label)
+	for _, it := range items {
+		b.WriteString("- " + it + "\n")
+	}
+	b.WriteString("\n")
+}
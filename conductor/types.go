@@ -58,6 +58,13 @@ type FeatureError struct {
 	AttemptNumber int
 }
 
+// FeatureCommit is a commit recorded against a feature.
+type FeatureCommit struct {
+	Hash      string
+	Message   string
+	CreatedAt int64
+}
+
 // CommitContext holds conductor context for a specific commit.
 type CommitContext struct {
 	Feature  *Feature
@@ -81,6 +88,7 @@ type ConductorData struct {
 	Handoff  *Handoff
 	Quality  []QualityReflection
 	Memories []Memory
+	Commits  map[string][]FeatureCommit // by feature ID, newest first
 	Passed   int
 	Total    int
 }
@@ -14,6 +14,8 @@ type Config struct {
 	Workspace  WorkspaceInfo     `toml:"workspace"`
 	Projects   []ProjectConfig   `toml:"project"`
 	Display    DisplayConfig     `toml:"display"`
+	AI         AIConfig          `toml:"ai"`
+	Commit     CommitConfig      `toml:"commit"`
 }
 
 type WorkspaceInfo struct {
@@ -28,6 +30,7 @@ type ProjectConfig struct {
 }
 
 type ThemeConfig struct {
+	Preset      string   `toml:"preset,omitempty"` // bundled base theme, see ThemePresets
 	BG          string   `toml:"bg,omitempty"`
 	FG          string   `toml:"fg,omitempty"`
 	Accent      string   `toml:"accent,omitempty"`
@@ -46,6 +49,7 @@ type ThemeConfig struct {
 	Error       string   `toml:"error,omitempty"`
 	CursorBG    string   `toml:"cursor_bg,omitempty"`
 	GraphColors []string `toml:"graph_colors,omitempty"`
+	GraphPalette string  `toml:"graph_palette,omitempty"` // "colorblind" for a deuteranopia/protanopia-safe palette
 
 	// Brutalist styling
 	PathDirFG          string            `toml:"path_dir_fg,omitempty"`
@@ -77,18 +81,63 @@ type PrefixColor struct {
 type RepoConfig struct {
 	Path           string   `toml:"path"`
 	IgnorePatterns []string `toml:"ignore_patterns"`
+	CompareRemote  string   `toml:"compare_remote"` // second remote (or remote/branch) to show ahead/behind against, e.g. "upstream"
 }
 
 type DisplayConfig struct {
-	Icons           bool           `toml:"icons,omitempty"`
-	NerdFonts       bool           `toml:"nerd_fonts,omitempty"`
-	GroupFolders    bool           `toml:"group_folders,omitempty"`
-	GroupDocs       bool           `toml:"group_docs,omitempty"`
-	Priority        []PriorityRule `toml:"priority,omitempty"`
-	GraphMaxCommits int            `toml:"graph_max_commits,omitempty"`
-	ShowGraph       *bool          `toml:"show_graph,omitempty"`
-	ShowConductor   *bool          `toml:"show_conductor,omitempty"`
-	DashboardWidth  int            `toml:"dashboard_width,omitempty"` // percentage, default 25 (with conductor) or 50 (without)
+	Icons                 bool           `toml:"icons,omitempty"`
+	NerdFonts             bool           `toml:"nerd_fonts,omitempty"`
+	GroupFolders          bool           `toml:"group_folders,omitempty"`
+	FolderTree            bool           `toml:"folder_tree,omitempty"` // with group_folders: nested headers per path level
+	GroupDocs             bool           `toml:"group_docs,omitempty"`
+	Priority              []PriorityRule `toml:"priority,omitempty"`
+	GraphMaxCommits       int            `toml:"graph_max_commits,omitempty"`
+	ShowGraph             *bool          `toml:"show_graph,omitempty"`
+	ShowConductor         *bool          `toml:"show_conductor,omitempty"`
+	DashboardWidth        int            `toml:"dashboard_width,omitempty"`         // percentage with graph and conductor, default 25
+	DashboardWidth2Col    int            `toml:"dashboard_width_2col,omitempty"`    // percentage with only the graph, default 50
+	OSC52Clipboard        bool           `toml:"osc52_clipboard,omitempty"`         // always copy via OSC 52 terminal escape (SSH)
+	CopyAbsolute          bool           `toml:"copy_absolute,omitempty"`           // Y copies absolute file paths instead of repo-relative
+	FileStats             bool           `toml:"file_stats,omitempty"`              // +added/-deleted badges on file rows (extra git calls per repo)
+	ShowRemote            bool           `toml:"show_remote,omitempty"`             // origin's owner/repo in repo headers
+	GraphAuthors          bool           `toml:"graph_authors,omitempty"`           // author initials on graph commits
+	TerminalCmd           string         `toml:"terminal_cmd,omitempty"`            // terminal launched in the repo, default tmux window / $TERMINAL / platform default
+	FileManagerCmd        string         `toml:"filemanager_cmd,omitempty"`         // file manager, given the repo path as its last argument
+	Scrollbars            *bool          `toml:"scrollbars,omitempty"`              // pane scrollbars, default true
+	ConfirmBulk           *bool          `toml:"confirm_bulk,omitempty"`            // confirm stage-all/unstage-all, default true
+	ConfirmBulkMin        int            `toml:"confirm_bulk_min,omitempty"`        // only confirm when at least this many files change, default 2
+	FileLimit             int            `toml:"file_limit,omitempty"`              // files listed per section before a "more" row, default 200, -1 for no cap
+	Watch                 bool           `toml:"watch,omitempty"`                   // refresh a repo's status when its files change, instead of only polling
+	ShowClock             bool           `toml:"show_clock,omitempty"`              // clock at the right of the status bar
+	Notify                bool           `toml:"notify,omitempty"`                  // bell and desktop notification when a push finishes while unfocused
+	RestoreView           *bool          `toml:"restore_view,omitempty"`            // reopen the last project and graph/conductor toggles, default true
+	CollapseUntrackedDirs bool           `toml:"collapse_untracked_dirs,omitempty"` // one "dir/ (N files)" row per wholly untracked directory
+	ShowSignatures        bool           `toml:"show_signatures,omitempty"`         // signature status in the commit detail (an extra git call per commit)
+	FileColumns           int            `toml:"file_columns,omitempty"`            // files flow into up to this many columns when the dashboard is wide enough
+}
+
+type AIConfig struct {
+	ContextDays int    `toml:"context_days,omitempty"` // default range for context export, default 7
+	ContextFile string `toml:"context_file,omitempty"` // path for context export to file, default ./gitdash-context.md
+}
+
+type CommitConfig struct {
+	PrefillTicket     bool   `toml:"prefill_ticket,omitempty"`      // pre-fill new commit messages from the branch name
+	BranchTicketRegex string `toml:"branch_ticket_regex,omitempty"` // ticket pattern, default [A-Z][A-Z0-9]+-[0-9]+
+	TicketTemplate    string `toml:"ticket_template,omitempty"`     // {{ticket}} and {{branch}} are substituted, default "[{{ticket}}] "
+	IssueAutocomplete bool   `toml:"issue_autocomplete,omitempty"`  // "#" offers open issues (GitHub via gh CLI)
+	Emoji             bool   `toml:"emoji,omitempty"`               // type selector prepends the gitmoji (✨ feat: ...)
+	EmojiOnly         bool   `toml:"emoji_only,omitempty"`          // with emoji, use the gitmoji instead of the text type
+	SortStaged        string `toml:"sort_staged,omitempty"`         // "changes" (largest first) or "path"; default is git's order
+	Sign              bool   `toml:"sign,omitempty"`                // pass -S so commits are signed with the repo's configured key
+	Template          string `toml:"template,omitempty"`            // file pre-filling new commit messages, default git's commit.template
+
+	// Message validation on submit
+	Validate            bool  `toml:"validate,omitempty"`               // check messages before committing
+	MaxSubjectLength    int   `toml:"max_subject_length,omitempty"`     // default 72
+	RequireType         bool  `toml:"require_type,omitempty"`           // subject must start with a conventional type
+	ImperativeMood      *bool `toml:"imperative_mood,omitempty"`        // flag "added"/"fixes" subjects, default true
+	BlankLineBeforeBody *bool `toml:"blank_line_before_body,omitempty"` // default true
 }
 
 type PriorityRule struct {
@@ -298,10 +347,12 @@ func DefaultFolderColors() map[string]string {
 	}
 }
 
-// ResolvedTheme merges config theme with defaults for any unset fields.
+// ResolvedTheme merges config theme with the preset (or defaults) for any unset fields.
 func (c Config) ResolvedTheme() ThemeConfig {
-	d := DefaultTheme()
+	d, _ := PresetTheme(c.Theme.Preset)
 	t := ThemeConfig{
+		Preset:       c.Theme.Preset,
+		GraphPalette: c.Theme.GraphPalette,
 		BG:          pick(c.Theme.BG, d.BG),
 		FG:          pick(c.Theme.FG, d.FG),
 		Accent:      pick(c.Theme.Accent, d.Accent),
@@ -339,14 +390,20 @@ func (c Config) ResolvedTheme() ThemeConfig {
 		FeedbackErrorBG:     pick(c.Theme.FeedbackErrorBG, d.FeedbackErrorBG),
 	}
 
-	// Merge folder colors: defaults first, then config overrides per-key
+	// Merge folder colors: defaults (or the preset's) first, then config overrides per-key
 	t.FolderColors = DefaultFolderColors()
+	for k, v := range d.FolderColors {
+		t.FolderColors[k] = v
+	}
 	for k, v := range c.Theme.FolderColors {
 		t.FolderColors[k] = v
 	}
 
-	// Merge prefix colors: defaults first, then config overrides per-key
+	// Merge prefix colors: defaults (or the preset's) first, then config overrides per-key
 	t.PrefixColors = DefaultPrefixColors()
+	for k, v := range d.PrefixColors {
+		t.PrefixColors[k] = v
+	}
 	for k, v := range c.Theme.PrefixColors {
 		t.PrefixColors[k] = v
 	}
@@ -359,11 +416,24 @@ func DefaultGraphColors() []string {
 	return []string{"#6699ff", "#ffc799", "#ff99cc", "#99ffe4", "#cc99ff", "#ffff99"}
 }
 
-// ResolvedGraphColors returns config graph colors if set, otherwise defaults.
+// ColorblindGraphColors returns the Okabe-Ito palette: hues that stay
+// distinct under deuteranopia/protanopia, ordered to alternate luminance.
+func ColorblindGraphColors() []string {
+	return []string{"#56b4e9", "#e69f00", "#0072b2", "#f0e442", "#cc79a7", "#d55e00"}
+}
+
+// ResolvedGraphColors returns config graph colors if set, then the named
+// graph_palette, then the preset's, otherwise defaults.
 func (c Config) ResolvedGraphColors() []string {
 	if len(c.Theme.GraphColors) > 0 {
 		return c.Theme.GraphColors
 	}
+	if c.Theme.GraphPalette == "colorblind" {
+		return ColorblindGraphColors()
+	}
+	if p, _ := PresetTheme(c.Theme.Preset); len(p.GraphColors) > 0 {
+		return p.GraphColors
+	}
 	return DefaultGraphColors()
 }
 
@@ -391,6 +461,50 @@ func (c Config) ResolvedShowConductor() bool {
 	return false
 }
 
+// ResolvedRestoreView returns the configured restore_view or true as default.
+func (c Config) ResolvedRestoreView() bool {
+	if c.Display.RestoreView != nil {
+		return *c.Display.RestoreView
+	}
+	return true
+}
+
+// ResolvedScrollbars returns the configured scrollbars or true as default.
+func (c Config) ResolvedScrollbars() bool {
+	if c.Display.Scrollbars != nil {
+		return *c.Display.Scrollbars
+	}
+	return true
+}
+
+// ResolvedConfirmBulk returns the configured bulk staging confirmation or true as default.
+func (c Config) ResolvedConfirmBulk() bool {
+	if c.Display.ConfirmBulk != nil {
+		return *c.Display.ConfirmBulk
+	}
+	return true
+}
+
+// ResolvedConfirmBulkMin returns the configured bulk confirmation threshold or 2 as default.
+func (c Config) ResolvedConfirmBulkMin() int {
+	if c.Display.ConfirmBulkMin > 0 {
+		return c.Display.ConfirmBulkMin
+	}
+	return 2
+}
+
+// ResolvedFileLimit returns how many files a repo section lists before the
+// rest collapse into a "more" row, 200 by default. 0 means no cap.
+func (c Config) ResolvedFileLimit() int {
+	switch {
+	case c.Display.FileLimit < 0:
+		return 0
+	case c.Display.FileLimit > 0:
+		return c.Display.FileLimit
+	}
+	return 200
+}
+
 // ResolvedDashboardWidth returns the configured dashboard width percentage or 25 as default.
 func (c Config) ResolvedDashboardWidth() int {
 	if c.Display.DashboardWidth > 0 && c.Display.DashboardWidth < 80 {
@@ -399,6 +513,84 @@ func (c Config) ResolvedDashboardWidth() int {
 	return 25
 }
 
+// ResolvedDashboardWidth2Col returns the dashboard width percentage for the
+// dashboard | graph layout, or 50 as default.
+func (c Config) ResolvedDashboardWidth2Col() int {
+	if c.Display.DashboardWidth2Col > 0 && c.Display.DashboardWidth2Col < 80 {
+		return c.Display.DashboardWidth2Col
+	}
+	return 50
+}
+
+// ResolvedBranchTicketRegex returns the configured ticket pattern or a JIRA-style default.
+func (c Config) ResolvedBranchTicketRegex() string {
+	if c.Commit.BranchTicketRegex != "" {
+		return c.Commit.BranchTicketRegex
+	}
+	return `[A-Z][A-Z0-9]+-[0-9]+`
+}
+
+// ResolvedTicketTemplate returns the configured commit message template or "[{{ticket}}] " as default.
+func (c Config) ResolvedTicketTemplate() string {
+	if c.Commit.TicketTemplate != "" {
+		return c.Commit.TicketTemplate
+	}
+	return "[{{ticket}}] "
+}
+
+// ResolvedMaxSubjectLength returns the configured subject length limit or 72 as default.
+func (c Config) ResolvedMaxSubjectLength() int {
+	if c.Commit.MaxSubjectLength > 0 {
+		return c.Commit.MaxSubjectLength
+	}
+	return 72
+}
+
+// ResolvedImperativeMood returns the configured imperative mood check or true as default.
+func (c Config) ResolvedImperativeMood() bool {
+	if c.Commit.ImperativeMood != nil {
+		return *c.Commit.ImperativeMood
+	}
+	return true
+}
+
+// ResolvedBlankLineBeforeBody returns the configured blank line check or true as default.
+func (c Config) ResolvedBlankLineBeforeBody() bool {
+	if c.Commit.BlankLineBeforeBody != nil {
+		return *c.Commit.BlankLineBeforeBody
+	}
+	return true
+}
+
+// ContextDayRanges are the ranges cycled through for context export.
+var ContextDayRanges = []int{1, 7, 30}
+
+// ResolvedContextDays returns the configured context export range or 7 as default.
+func (c Config) ResolvedContextDays() int {
+	if c.AI.ContextDays > 0 {
+		return c.AI.ContextDays
+	}
+	return 7
+}
+
+// ResolvedContextFile returns the absolute path for context export to file.
+// Defaults to gitdash-context.md in the working directory.
+func (c Config) ResolvedContextFile() string {
+	path := c.AI.ContextFile
+	if path == "" {
+		path = "gitdash-context.md"
+	}
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
 func pick(a, b string) string {
 	if a != "" {
 		return a
@@ -413,6 +605,8 @@ type saveableConfig struct {
 	Workspace WorkspaceInfo     `toml:"workspace"`
 	Projects  []saveableProject `toml:"project,omitempty"`
 	Display   DisplayConfig     `toml:"display,omitempty"`
+	AI        AIConfig          `toml:"ai,omitempty"`
+	Commit    CommitConfig      `toml:"commit,omitempty"`
 }
 
 type saveableProject struct {
@@ -424,6 +618,7 @@ type saveableProject struct {
 type saveableRepo struct {
 	Path           string   `toml:"path"`
 	IgnorePatterns []string `toml:"ignore_patterns,omitempty"`
+	CompareRemote  string   `toml:"compare_remote,omitempty"`
 }
 
 // Save writes the config back to a TOML file, converting absolute paths to relative.
@@ -438,6 +633,8 @@ func Save(path string, cfg Config) error {
 		Theme:     cfg.Theme,
 		Workspace: cfg.Workspace,
 		Display:   cfg.Display,
+		AI:        cfg.AI,
+		Commit:    cfg.Commit,
 	}
 
 	for _, proj := range cfg.Projects {
@@ -458,6 +655,7 @@ func Save(path string, cfg Config) error {
 		for _, repo := range proj.Repos {
 			sr := saveableRepo{
 				IgnorePatterns: repo.IgnorePatterns,
+				CompareRemote:  repo.CompareRemote,
 			}
 
 			// Convert repo path to relative (against project path if set, else config dir)
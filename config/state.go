@@ -0,0 +1,75 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// State holds UI state that persists across restarts. It lives beside the
+// config file so the user's hand-written config is never rewritten for it.
+type State struct {
+	HideClean     bool     `toml:"hide_clean,omitempty"`
+	ActiveProject string   `toml:"active_project,omitempty"` // project name, "" = all-projects view
+	Pinned        []string `toml:"pinned,omitempty"`         // repo paths pinned to the top
+	ShowGraph     *bool    `toml:"show_graph,omitempty"`     // last graph toggle, nil = never toggled
+	ShowConductor *bool    `toml:"show_conductor,omitempty"` // last conductor toggle
+
+	// Collapse flags keyed by repo path (folders by "repoPath:dir") so
+	// reordering projects doesn't scramble them.
+	Collapsed        map[string]bool `toml:"collapsed,omitempty"`
+	DocsCollapsed    map[string]bool `toml:"docs_collapsed,omitempty"`
+	FoldersCollapsed map[string]bool `toml:"folders_collapsed,omitempty"`
+
+	// Graph/conductor toggles keyed by project name. Projects without an
+	// entry use ShowGraph/ShowConductor.
+	ProjectPanes map[string]Panes `toml:"project_panes,omitempty"`
+}
+
+// Panes is a project's graph and conductor toggles.
+type Panes struct {
+	Graph     bool `toml:"graph"`
+	Conductor bool `toml:"conductor"`
+}
+
+// StatePath returns the state file path for a config path:
+// "~/.config/gitdash/config.toml" → "~/.config/gitdash/config.state.toml".
+func StatePath(configPath string) string {
+	ext := filepath.Ext(configPath)
+	return strings.TrimSuffix(configPath, ext) + ".state.toml"
+}
+
+// LoadState reads the state file. A missing file yields an empty state.
+func LoadState(path string) (State, error) {
+	var st State
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return st, fmt.Errorf("reading state: %w", err)
+	}
+	if err := toml.Unmarshal(data, &st); err != nil {
+		return st, fmt.Errorf("parsing state: %w", err)
+	}
+	return st, nil
+}
+
+// SaveState writes the state file, creating its directory if needed.
+func SaveState(path string, st State) error {
+	data, err := toml.Marshal(st)
+	if err != nil {
+		return fmt.Errorf("marshaling state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating state directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing state: %w", err)
+	}
+	return nil
+}
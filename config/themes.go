@@ -0,0 +1,232 @@
+package config
+
+// ThemePresets lists the bundled theme names in display order.
+var ThemePresets = []string{"vesper", "tokyonight", "gruvbox", "light", "high-contrast"}
+
+// PresetTheme returns the bundled theme for name. Unknown names (and "")
+// fall back to the Vesper default; ok reports whether name was recognized.
+func PresetTheme(name string) (t ThemeConfig, ok bool) {
+	switch name {
+	case "vesper":
+		return DefaultTheme(), true
+	case "tokyonight":
+		return TokyoNightTheme(), true
+	case "gruvbox":
+		return GruvboxTheme(), true
+	case "light":
+		return LightTheme(), true
+	case "high-contrast":
+		return HighContrastTheme(), true
+	}
+	return DefaultTheme(), false
+}
+
+// TokyoNightTheme returns a cool blue/purple dark palette.
+func TokyoNightTheme() ThemeConfig {
+	return ThemeConfig{
+		BG:          "#1a1b26",
+		FG:          "#c0caf5",
+		Accent:      "#7aa2f7",
+		Accent2:     "#9ece6a",
+		Muted:       "#414868",
+		Dim:         "#a9b1d6",
+		Staged:      "#9ece6a",
+		Unstaged:    "#f7768e",
+		DiffAdd:     "#9ece6a",
+		DiffRemove:  "#f7768e",
+		DiffHunk:    "#7dcfff",
+		RepoHeader:  "#c0caf5",
+		Branch:      "#bb9af7",
+		StatusBarBG: "#16161e",
+		StatusBarFG: "#a9b1d6",
+		Error:       "#f7768e",
+		CursorBG:    "#283457",
+		GraphColors: []string{"#7aa2f7", "#bb9af7", "#7dcfff", "#9ece6a", "#e0af68", "#ff9e64"},
+
+		PathDirFG:           "#565f89",
+		PathFileFG:          "#c0caf5",
+		StatAddBG:           "#1e2a1e",
+		StatDelBG:           "#2d1a22",
+		CommitDetailLabelFG: "#565f89",
+		SyncPushFG:          "#9ece6a",
+		SyncPushBG:          "#1e2a1e",
+		SyncPullFG:          "#7aa2f7",
+		SyncPullBG:          "#1a1f36",
+		SpinnerFG:           "#7aa2f7",
+		SpinnerType:         "minidot",
+		FeedbackSuccessFG:   "#9ece6a",
+		FeedbackSuccessBG:   "#1e2a1e",
+		FeedbackWarningFG:   "#e0af68",
+		FeedbackWarningBG:   "#2a2418",
+		FeedbackErrorFG:     "#f7768e",
+		FeedbackErrorBG:     "#2d1a22",
+	}
+}
+
+// GruvboxTheme returns a warm retro dark palette.
+func GruvboxTheme() ThemeConfig {
+	return ThemeConfig{
+		BG:          "#282828",
+		FG:          "#ebdbb2",
+		Accent:      "#fabd2f",
+		Accent2:     "#8ec07c",
+		Muted:       "#504945",
+		Dim:         "#a89984",
+		Staged:      "#b8bb26",
+		Unstaged:    "#fb4934",
+		DiffAdd:     "#b8bb26",
+		DiffRemove:  "#fb4934",
+		DiffHunk:    "#83a598",
+		RepoHeader:  "#ebdbb2",
+		Branch:      "#fe8019",
+		StatusBarBG: "#1d2021",
+		StatusBarFG: "#a89984",
+		Error:       "#fb4934",
+		CursorBG:    "#3c3836",
+		GraphColors: []string{"#83a598", "#fabd2f", "#d3869b", "#8ec07c", "#fe8019", "#b8bb26"},
+
+		PathDirFG:           "#7c6f64",
+		PathFileFG:          "#ebdbb2",
+		StatAddBG:           "#32361a",
+		StatDelBG:           "#3c1f1e",
+		CommitDetailLabelFG: "#7c6f64",
+		SyncPushFG:          "#b8bb26",
+		SyncPushBG:          "#32361a",
+		SyncPullFG:          "#83a598",
+		SyncPullBG:          "#1f2a2c",
+		SpinnerFG:           "#fabd2f",
+		SpinnerType:         "minidot",
+		FeedbackSuccessFG:   "#b8bb26",
+		FeedbackSuccessBG:   "#32361a",
+		FeedbackWarningFG:   "#fabd2f",
+		FeedbackWarningBG:   "#3a3016",
+		FeedbackErrorFG:     "#fb4934",
+		FeedbackErrorBG:     "#3c1f1e",
+	}
+}
+
+// LightTheme returns a palette for light terminal backgrounds. Colors are
+// darker, saturated tones so text keeps contrast against white.
+func LightTheme() ThemeConfig {
+	return ThemeConfig{
+		BG:          "#fafafa",
+		FG:          "#1f1f1f",
+		Accent:      "#b45309",
+		Accent2:     "#0f766e",
+		Muted:       "#b0b0b0",
+		Dim:         "#6b6b6b",
+		Staged:      "#15803d",
+		Unstaged:    "#b91c1c",
+		DiffAdd:     "#15803d",
+		DiffRemove:  "#b91c1c",
+		DiffHunk:    "#1d4ed8",
+		RepoHeader:  "#111111",
+		Branch:      "#7c3aed",
+		StatusBarBG: "#e8e8e8",
+		StatusBarFG: "#404040",
+		Error:       "#b91c1c",
+		CursorBG:    "#e0e7ff",
+		GraphColors: []string{"#1d4ed8", "#b45309", "#be185d", "#0f766e", "#7c3aed", "#a16207"},
+
+		PathDirFG:           "#8a8a8a",
+		PathFileFG:          "#111111",
+		StatAddBG:           "#dcfce7",
+		StatDelBG:           "#fee2e2",
+		CommitDetailLabelFG: "#8a8a8a",
+		SyncPushFG:          "#15803d",
+		SyncPushBG:          "#dcfce7",
+		SyncPullFG:          "#b45309",
+		SyncPullBG:          "#fef3c7",
+		SpinnerFG:           "#b45309",
+		SpinnerType:         "minidot",
+		FeedbackSuccessFG:   "#15803d",
+		FeedbackSuccessBG:   "#dcfce7",
+		FeedbackWarningFG:   "#92400e",
+		FeedbackWarningBG:   "#fef3c7",
+		FeedbackErrorFG:     "#b91c1c",
+		FeedbackErrorBG:     "#fee2e2",
+
+		FolderColors: map[string]string{
+			"src": "#b45309", "lib": "#b45309", "pkg": "#b45309",
+			"cmd": "#b45309", "internal": "#b45309", "api": "#b45309",
+			"components": "#0f766e", "routes": "#0f766e",
+			"test": "#7c3aed", "tests": "#7c3aed",
+			"docs": "#8a8a8a", "scripts": "#8a8a8a",
+		},
+		PrefixColors: map[string]PrefixColor{
+			"feat":     {FG: "#1d4ed8", BG: "#dbeafe"},
+			"fix":      {FG: "#92400e", BG: "#fef3c7"},
+			"test":     {FG: "#6d28d9", BG: "#ede9fe"},
+			"refactor": {FG: "#0f766e", BG: "#ccfbf1"},
+			"perf":     {FG: "#a16207", BG: "#fef9c3"},
+			"chore":    {FG: "#525252", BG: "#f0f0f0"},
+			"docs":     {FG: "#525252", BG: "#f0f0f0"},
+			"style":    {FG: "#525252", BG: "#f0f0f0"},
+			"ci":       {FG: "#525252", BG: "#f0f0f0"},
+			"build":    {FG: "#525252", BG: "#f0f0f0"},
+		},
+	}
+}
+
+// HighContrastTheme returns a maximum-legibility palette: pure black and
+// white with fully saturated accents, and badges as dark text on bright fills.
+func HighContrastTheme() ThemeConfig {
+	return ThemeConfig{
+		BG:          "#000000",
+		FG:          "#ffffff",
+		Accent:      "#ffff00",
+		Accent2:     "#00ffff",
+		Muted:       "#808080",
+		Dim:         "#d0d0d0",
+		Staged:      "#00ff00",
+		Unstaged:    "#ff5f5f",
+		DiffAdd:     "#00ff00",
+		DiffRemove:  "#ff5f5f",
+		DiffHunk:    "#00ffff",
+		RepoHeader:  "#ffffff",
+		Branch:      "#ffff00",
+		StatusBarBG: "#000000",
+		StatusBarFG: "#ffffff",
+		Error:       "#ff5f5f",
+		CursorBG:    "#0000aa",
+		GraphColors: []string{"#00ffff", "#ffff00", "#ff00ff", "#00ff00", "#ffffff", "#ff8700"},
+
+		PathDirFG:           "#c0c0c0",
+		PathFileFG:          "#ffffff",
+		StatAddBG:           "#004400",
+		StatDelBG:           "#550000",
+		CommitDetailLabelFG: "#c0c0c0",
+		SyncPushFG:          "#000000",
+		SyncPushBG:          "#00ff00",
+		SyncPullFG:          "#000000",
+		SyncPullBG:          "#ffff00",
+		SpinnerFG:           "#ffff00",
+		SpinnerType:         "minidot",
+		FeedbackSuccessFG:   "#000000",
+		FeedbackSuccessBG:   "#00ff00",
+		FeedbackWarningFG:   "#000000",
+		FeedbackWarningBG:   "#ffff00",
+		FeedbackErrorFG:     "#ffffff",
+		FeedbackErrorBG:     "#cc0000",
+
+		FolderColors: map[string]string{
+			"src": "#ffff00", "lib": "#ffff00", "pkg": "#ffff00",
+			"cmd": "#ffff00", "internal": "#ffff00", "api": "#ffff00",
+			"components": "#00ffff", "routes": "#00ffff",
+			"test": "#ff00ff", "tests": "#ff00ff",
+			"docs": "#c0c0c0", "scripts": "#c0c0c0",
+		},
+		PrefixColors: map[string]PrefixColor{
+			"feat":     {FG: "#000000", BG: "#00ffff"},
+			"fix":      {FG: "#000000", BG: "#ffff00"},
+			"test":     {FG: "#000000", BG: "#ff00ff"},
+			"refactor": {FG: "#000000", BG: "#00ff00"},
+			"perf":     {FG: "#000000", BG: "#ff8700"},
+			"chore":    {FG: "#ffffff", BG: "#000000"},
+			"docs":     {FG: "#ffffff", BG: "#000000"},
+			"style":    {FG: "#ffffff", BG: "#000000"},
+			"ci":       {FG: "#ffffff", BG: "#000000"},
+			"build":    {FG: "#ffffff", BG: "#000000"},
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dylan/gitdash/config"
+	"github.com/dylan/gitdash/git"
+)
+
+// countFlag is -count or -count=ahead. It's a bool-style flag so the bare
+// form works without a value.
+type countFlag string
+
+func (c *countFlag) String() string { return string(*c) }
+
+func (c *countFlag) Set(v string) error {
+	switch v {
+	case "true", "dirty":
+		*c = "dirty"
+	case "ahead":
+		*c = "ahead"
+	case "false":
+		*c = ""
+	default:
+		return fmt.Errorf("want -count or -count=ahead, got %q", v)
+	}
+	return nil
+}
+
+func (c *countFlag) IsBoolFlag() bool { return true }
+
+// countRepos returns how many configured repos have uncommitted changes
+// ("dirty") or unpushed commits ("ahead"). Repos are checked in parallel
+// and errors count as clean so a broken repo can't break a prompt.
+func countRepos(cfg config.Config, mode countFlag) int {
+	repos := cfg.AllRepos()
+	hits := make([]bool, len(repos))
+
+	var wg sync.WaitGroup
+	for i, repo := range repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if mode == "ahead" {
+				ahead, _ := git.AheadBehind(repo.Path)
+				hits[i] = ahead > 0
+				return
+			}
+			files, err := git.GetStatus(repo.Path, repo.IgnorePatterns)
+			hits[i] = err == nil && len(files) > 0
+		}()
+	}
+	wg.Wait()
+
+	n := 0
+	for _, hit := range hits {
+		if hit {
+			n++
+		}
+	}
+	return n
+}
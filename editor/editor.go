@@ -0,0 +1,63 @@
+// Package editor opens files in the user's editor (see git.Editor),
+// in a tmux split when running inside tmux.
+package editor
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dylan/gitdash/git"
+)
+
+type EditorFinishedMsg struct {
+	Err error
+}
+
+// RebaseFinishedMsg reports the end of an interactive rebase. Stopped is
+// set when it paused on a conflict or an edit and is still in progress.
+type RebaseFinishedMsg struct {
+	Stopped bool
+	Err     error
+}
+
+// OpenFile edits filePath (relative to repoPath). Editor commands are
+// shell strings ("code --wait"), so like git they're run through sh with
+// the file as an argument.
+func OpenFile(repoPath, filePath string) tea.Cmd {
+	editor := git.Editor(repoPath)
+
+	if os.Getenv("TMUX") != "" {
+		return func() tea.Msg {
+			cmd := exec.Command("tmux", "split-window", "-h",
+				"-c", repoPath,
+				"sh", "-c", editor+` "$@"`, editor, filePath)
+			err := cmd.Run()
+			return EditorFinishedMsg{Err: err}
+		}
+	}
+
+	c := exec.Command("sh", "-c", editor+` "$@"`, editor, filepath.Join(repoPath, filePath))
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		return EditorFinishedMsg{Err: err}
+	})
+}
+
+// Rebase runs an interactive autosquash rebase from hash onwards (see
+// git.AutosquashArgs) in the foreground, with the todo list opened in
+// git.SequenceEditor and commit messages in git.Editor. Resolving those
+// runs git, so it's done here rather than by the caller.
+func Rebase(repoPath, hash string) tea.Cmd {
+	return func() tea.Msg {
+		c := exec.Command("git", git.AutosquashArgs(repoPath, hash)...)
+		c.Dir = repoPath
+		c.Env = append(os.Environ(),
+			"GIT_SEQUENCE_EDITOR="+git.SequenceEditor(repoPath),
+			"GIT_EDITOR="+git.Editor(repoPath))
+		return tea.ExecProcess(c, func(err error) tea.Msg {
+			stopped := git.DetectInProgress(git.GitDir(repoPath)) == "rebasing"
+			return RebaseFinishedMsg{Stopped: stopped, Err: err}
+		})()
+	}
+}
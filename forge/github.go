@@ -0,0 +1,59 @@
+package forge
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Issue is an open issue on the repo's forge.
+type Issue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+}
+
+// ListIssues returns open GitHub issues for the repo via the gh CLI.
+// Returns nil, nil when gh is not installed so callers can degrade quietly.
+func ListIssues(repoPath string) ([]Issue, error) {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return nil, nil
+	}
+
+	cmd := exec.Command("gh", "issue", "list", "--state", "open", "--limit", "100", "--json", "number,title")
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("gh issue list: %s", strings.TrimSpace(string(ee.Stderr)))
+		}
+		return nil, fmt.Errorf("gh issue list: %w", err)
+	}
+
+	var issues []Issue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return nil, fmt.Errorf("parsing gh output: %w", err)
+	}
+	return issues, nil
+}
+
+// Slug lowercases title and joins its words with dashes, capped at 40 chars.
+func Slug(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+		if b.Len() >= 40 {
+			break
+		}
+	}
+	return b.String()
+}
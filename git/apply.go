@@ -0,0 +1,62 @@
+package git
+
+import (
+	"os"
+	"strings"
+)
+
+// applyPatch runs git apply with args on patch, handed over in a temp
+// file, and returns git's output.
+func applyPatch(repoPath, patch string, args ...string) (string, error) {
+	f, err := os.CreateTemp("", "gitdash-*.patch")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(patch)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+	return RunGit(repoPath, append(append([]string{"apply"}, args...), f.Name())...)
+}
+
+// CheckPatch dry-runs applying patch to repoPath's working tree. When it
+// wouldn't apply, rejected lists the "file:line" of each failing hunk.
+func CheckPatch(repoPath, patch string) (rejected []string, err error) {
+	out, err := applyPatch(repoPath, patch, "--check")
+	if err == nil {
+		return nil, nil
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if at, ok := strings.CutPrefix(line, "error: patch failed: "); ok {
+			rejected = append(rejected, at)
+		}
+	}
+	return rejected, err
+}
+
+// ApplyPatch applies patch to repoPath's working tree. With threeWay,
+// hunks that don't apply are merged against the blobs the patch records,
+// and files left with conflict markers are returned instead of an error.
+func ApplyPatch(repoPath, patch string, threeWay bool) (conflicts []string, err error) {
+	args := []string{"--whitespace=nowarn"}
+	if threeWay {
+		args = append(args, "-3")
+	}
+	out, err := applyPatch(repoPath, patch, args...)
+	if err == nil || !threeWay {
+		return nil, err
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if path, ok := strings.CutPrefix(line, "U "); ok {
+			conflicts = append(conflicts, path)
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil, err
+	}
+	return conflicts, nil
+}
@@ -1,15 +1,24 @@
 package git
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+)
 
 type BranchInfo struct {
-	Name      string
-	IsCurrent bool
-	Upstream  string
+	Name         string
+	IsCurrent    bool
+	Upstream     string
+	Ahead        int    // commits not on the upstream
+	Behind       int    // upstream commits not on the branch
+	UpstreamGone bool   // the upstream branch was deleted on the remote
+	LastCommit   string // relative date of the branch tip, e.g. "3 days ago"
+	CommitTime   int64  // unix time of the branch tip's commit
 }
 
 func ListBranches(repoPath string) ([]BranchInfo, error) {
-	out, err := RunGit(repoPath, "branch", "--format=%(refname:short)|%(HEAD)|%(upstream:short)")
+	out, err := RunGit(repoPath, "branch",
+		"--format=%(refname:short)|%(HEAD)|%(upstream:short)|%(upstream:track,nobracket)|%(committerdate:relative)|%(committerdate:unix)")
 	if err != nil {
 		return nil, err
 	}
@@ -19,25 +28,97 @@ func ListBranches(repoPath string) ([]BranchInfo, error) {
 
 	var branches []BranchInfo
 	for _, line := range strings.Split(out, "\n") {
-		parts := strings.SplitN(line, "|", 3)
-		if len(parts) < 3 {
+		parts := strings.SplitN(line, "|", 6)
+		if len(parts) < 6 {
 			continue
 		}
-		branches = append(branches, BranchInfo{
-			Name:      strings.TrimSpace(parts[0]),
-			IsCurrent: strings.TrimSpace(parts[1]) == "*",
-			Upstream:  strings.TrimSpace(parts[2]),
-		})
+		b := BranchInfo{
+			Name:       strings.TrimSpace(parts[0]),
+			IsCurrent:  strings.TrimSpace(parts[1]) == "*",
+			Upstream:   strings.TrimSpace(parts[2]),
+			LastCommit: strings.TrimSpace(parts[4]),
+		}
+		b.Ahead, b.Behind, b.UpstreamGone = parseTrack(parts[3])
+		b.CommitTime, _ = strconv.ParseInt(strings.TrimSpace(parts[5]), 10, 64)
+		branches = append(branches, b)
 	}
 	return branches, nil
 }
 
+// parseTrack reads %(upstream:track,nobracket): "ahead 2, behind 1",
+// "gone", or empty when in sync or there's no upstream.
+func parseTrack(track string) (ahead, behind int, gone bool) {
+	track = strings.TrimSpace(track)
+	if track == "gone" {
+		return 0, 0, true
+	}
+	for _, part := range strings.Split(track, ",") {
+		word, n, ok := strings.Cut(strings.TrimSpace(part), " ")
+		if !ok {
+			continue
+		}
+		count, _ := strconv.Atoi(n)
+		switch word {
+		case "ahead":
+			ahead = count
+		case "behind":
+			behind = count
+		}
+	}
+	return ahead, behind, false
+}
+
+// protectedBranches are never offered for pruning, even when merged.
+var protectedBranches = map[string]bool{"main": true, "master": true, "develop": true}
+
+// MergedBranches lists local branches fully merged into base (HEAD when
+// empty), leaving out the current branch, base itself and the protected
+// main/master/develop.
+func MergedBranches(repoPath, base string) ([]string, error) {
+	if base == "" {
+		base = "HEAD"
+	}
+	out, err := RunGit(repoPath, "branch", "--merged", base, "--format=%(refname:short)|%(HEAD)")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, line := range strings.Split(out, "\n") {
+		name, head, ok := strings.Cut(line, "|")
+		if !ok || head == "*" || name == base || protectedBranches[name] {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// DeleteBranch deletes a local branch. Like git branch -d, it refuses to
+// delete a branch with unmerged commits.
+func DeleteBranch(repoPath, branchName string) error {
+	_, err := RunGit(repoPath, "branch", "-d", branchName)
+	return err
+}
+
 func SwitchBranch(repoPath, branchName string) error {
 	_, err := RunGit(repoPath, "switch", branchName)
 	return err
 }
 
-func CreateBranch(repoPath, branchName string) error {
-	_, err := RunGit(repoPath, "switch", "-c", branchName)
+// CreateBranch creates and switches to branchName at startPoint, or at
+// HEAD when startPoint is empty.
+func CreateBranch(repoPath, branchName, startPoint string) error {
+	args := []string{"switch", "-c", branchName}
+	if startPoint != "" {
+		args = append(args, startPoint)
+	}
+	_, err := RunGit(repoPath, args...)
+	return err
+}
+
+// CheckoutDetached checks out hash with a detached HEAD. Like a plain
+// switch, it refuses when local changes would be overwritten.
+func CheckoutDetached(repoPath, hash string) error {
+	_, err := RunGit(repoPath, "switch", "--detach", hash)
 	return err
 }
@@ -1,21 +1,195 @@
 package git
 
-func Commit(repoPath, message string) error {
-	_, err := RunGit(repoPath, "commit", "-m", message)
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrSigning means a commit was to be signed and couldn't be. Nothing is
+// committed: git won't fall back to an unsigned commit.
+var ErrSigning = errors.New("couldn't sign the commit")
+
+// runCommit runs "git commit args...", with -S when sign is set so the
+// repo's configured key (user.signingkey, gpg.format) signs it. Signing
+// failures are returned as ErrSigning with a hint at the fix.
+func runCommit(repoPath string, sign bool, args ...string) error {
+	if sign {
+		args = append([]string{"-S"}, args...)
+	}
+	out, err := RunGit(repoPath, append([]string{"commit"}, args...)...)
+	if err != nil && sign {
+		if hint := signingHint(out); hint != "" {
+			return fmt.Errorf("%w: %s", ErrSigning, hint)
+		}
+	}
 	return err
 }
 
-func CommitAmend(repoPath, message string) error {
-	_, err := RunGit(repoPath, "commit", "--amend", "-m", message)
-	return err
+// signingHint says what to do about the signing failure in git commit's
+// output, or returns "" if it didn't fail to sign.
+func signingHint(out string) string {
+	lower := strings.ToLower(out)
+	switch {
+	case strings.Contains(lower, "cannot run"):
+		return "the signing program isn't installed (check gpg.program, or gpg.ssh.program for SSH keys)"
+	case strings.Contains(lower, "user.signingkey"):
+		return "no signing key is configured: git config user.signingkey <key>"
+	case strings.Contains(lower, "couldn't load public key"), strings.Contains(lower, "couldn't load private key"):
+		return "user.signingkey doesn't point at a usable SSH key"
+	case strings.Contains(lower, "no secret key"), strings.Contains(lower, "no default secret key"):
+		return "gpg has no secret key for your identity: set user.signingkey, or check gpg --list-secret-keys"
+	case strings.Contains(lower, "inappropriate ioctl"), strings.Contains(lower, "pinentry"),
+		strings.Contains(lower, "passphrase"), strings.Contains(lower, "agent"):
+		return "gpg-agent couldn't ask for the key's passphrase: unlock it in a terminal (echo | gpg --clearsign) and retry"
+	case strings.Contains(lower, "failed to sign"):
+		return "gpg failed to sign: check user.signingkey names a key you have, and that it's unlocked (echo | gpg --clearsign)"
+	}
+	return ""
+}
+
+// Commit commits the staged changes, signed when sign is set.
+func Commit(repoPath, message string, sign bool) error {
+	return runCommit(repoPath, sign, "-m", message)
+}
+
+// CommitEmpty creates a commit even when nothing is staged.
+func CommitEmpty(repoPath, message string, sign bool) error {
+	return runCommit(repoPath, sign, "--allow-empty", "-m", message)
+}
+
+func CommitAmend(repoPath, message string, sign bool) error {
+	return runCommit(repoPath, sign, "--amend", "-m", message)
+}
+
+// AmendWithAuthor amends HEAD with message, optionally replacing its author
+// ("Name <email>") and resetting its author date to now.
+func AmendWithAuthor(repoPath, message, author string, resetDate, sign bool) error {
+	args := []string{"--amend", "-m", message}
+	if author != "" {
+		args = append(args, "--author="+author)
+	}
+	if resetDate {
+		args = append(args, "--date=now")
+	}
+	return runCommit(repoPath, sign, args...)
+}
+
+// LastCommitAuthor returns HEAD's author as "Name <email>".
+func LastCommitAuthor(repoPath string) (string, error) {
+	return RunGit(repoPath, "log", "-1", "--format=%an <%ae>")
+}
+
+// CommitFixup commits the staged changes as "fixup! <subject of hash>", to
+// be folded into hash by RebaseAutosquash.
+func CommitFixup(repoPath, hash string, sign bool) error {
+	return runCommit(repoPath, sign, "--fixup="+hash)
+}
+
+// AmendNoEdit folds staged changes into HEAD, keeping its message.
+func AmendNoEdit(repoPath string, sign bool) error {
+	return runCommit(repoPath, sign, "--amend", "--no-edit")
+}
+
+// HasCommits reports whether HEAD points at a commit (false in a fresh repo).
+func HasCommits(repoPath string) bool {
+	_, err := RunGit(repoPath, "rev-parse", "--verify", "-q", "HEAD")
+	return err == nil
 }
 
 func LastCommitMessage(repoPath string) (string, error) {
 	return RunGit(repoPath, "log", "-1", "--format=%B")
 }
 
+// CommitMessage returns the full message (subject and body) of a commit.
+func CommitMessage(repoPath, hash string) (string, error) {
+	return RunGit(repoPath, "log", "-1", "--format=%B", hash)
+}
+
+// ErrRootCommit means HEAD has no parent to reset to.
+var ErrRootCommit = errors.New("HEAD is the root commit, there's no parent to reset to")
+
+// IsRootCommit reports whether HEAD exists and has no parent.
+func IsRootCommit(repoPath string) bool {
+	if !HasCommits(repoPath) {
+		return false
+	}
+	_, err := RunGit(repoPath, "rev-parse", "--verify", "-q", "HEAD~1")
+	return err != nil
+}
+
+// HeadSubject returns HEAD's short hash and subject line.
+func HeadSubject(repoPath string) (hash, subject string, err error) {
+	out, err := RunGit(repoPath, "log", "-1", "--format=%h|%s")
+	if err != nil {
+		return "", "", err
+	}
+	hash, subject, _ = strings.Cut(out, "|")
+	return hash, subject, nil
+}
+
+// UndoLastCommit soft-resets HEAD to its parent, leaving the commit's
+// changes staged, and returns the undone commit's short hash.
 func UndoLastCommit(repoPath string) (string, error) {
+	if IsRootCommit(repoPath) {
+		return "", ErrRootCommit
+	}
 	hash, _ := GetHeadHash(repoPath)
 	_, err := RunGit(repoPath, "reset", "--soft", "HEAD~1")
 	return hash, err
 }
+
+// RecentSubjects returns the subjects of the last n commits, oldest first.
+func RecentSubjects(repoPath string, n int) ([]string, error) {
+	out, err := RunGit(repoPath, "log", "-n", strconv.Itoa(n), "--format=%s")
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(out, "\n")
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+	return lines, nil
+}
+
+// CheckSquash reports why the last n commits can't be squashed, or nil if
+// they can. The root commit can't be included (there's nothing to reset
+// onto), and staged changes would silently end up in the squashed commit.
+func CheckSquash(repoPath string, n int) error {
+	if n < 2 {
+		return errors.New("squash needs at least 2 commits")
+	}
+	out, err := RunGit(repoPath, "rev-list", "--count", "HEAD")
+	if err != nil {
+		return err
+	}
+	count, _ := strconv.Atoi(out)
+	if n >= count {
+		return fmt.Errorf("only %d commits can be squashed (the root commit can't be)", count-1)
+	}
+	if _, err := RunGit(repoPath, "diff", "--cached", "--quiet"); err != nil {
+		return errors.New("staged changes would be folded in; commit or unstage them first")
+	}
+	return nil
+}
+
+// SquashLast replaces the last n commits with a single commit carrying
+// message, via a soft reset. If the commit fails the branch is put back.
+func SquashLast(repoPath string, n int, message string, sign bool) error {
+	if err := CheckSquash(repoPath, n); err != nil {
+		return err
+	}
+	orig, err := GetHeadHash(repoPath)
+	if err != nil {
+		return err
+	}
+	if _, err := RunGit(repoPath, "reset", "--soft", fmt.Sprintf("HEAD~%d", n)); err != nil {
+		return err
+	}
+	if err := Commit(repoPath, message, sign); err != nil {
+		RunGit(repoPath, "reset", "--soft", orig)
+		return err
+	}
+	return nil
+}
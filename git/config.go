@@ -0,0 +1,73 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConfigGet returns the value of a git config key as seen from repoPath
+// (repo, then global and system config), or "" when it isn't set.
+func ConfigGet(repoPath, key string) string {
+	out, err := RunGit(repoPath, "config", "--get", key)
+	if err != nil {
+		return ""
+	}
+	return out
+}
+
+// DefaultEditor is used when nothing configures an editor. Git itself
+// would fall back to vi.
+const DefaultEditor = "nvim"
+
+// Editor returns the editor command for files in repoPath, resolved like
+// git resolves its own: $GIT_EDITOR, then core.editor, then $VISUAL, then
+// $EDITOR, then DefaultEditor.
+func Editor(repoPath string) string {
+	return PickEditor(os.Getenv("GIT_EDITOR"), ConfigGet(repoPath, "core.editor"),
+		os.Getenv("VISUAL"), os.Getenv("EDITOR"))
+}
+
+// SequenceEditor returns the editor command for an interactive rebase's
+// todo list: $GIT_SEQUENCE_EDITOR, then sequence.editor, then Editor.
+func SequenceEditor(repoPath string) string {
+	return PickEditor(os.Getenv("GIT_SEQUENCE_EDITOR"), ConfigGet(repoPath, "sequence.editor"),
+		Editor(repoPath))
+}
+
+// PickEditor returns the first non-empty candidate, or DefaultEditor.
+func PickEditor(candidates ...string) string {
+	for _, c := range candidates {
+		if c != "" {
+			return c
+		}
+	}
+	return DefaultEditor
+}
+
+// CommitTemplate reads the commit message template at path, or at git's
+// commit.template when path is "". It returns "" with no error when
+// neither is set. "~/" is expanded, and relative paths are taken from the
+// repo root.
+func CommitTemplate(repoPath, path string) (string, error) {
+	if path == "" {
+		path = ConfigGet(repoPath, "commit.template")
+	}
+	if path == "" {
+		return "", nil
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(repoPath, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading commit template: %w", err)
+	}
+	return string(data), nil
+}
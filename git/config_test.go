@@ -0,0 +1,70 @@
+package git
+
+import "testing"
+
+func TestEditor(t *testing.T) {
+	tests := []struct {
+		name       string
+		gitEditor  string
+		coreEditor string
+		visual     string
+		editor     string
+		want       string
+	}{
+		{name: "GIT_EDITOR first", gitEditor: "ge", coreEditor: "ce", visual: "vi", editor: "ed", want: "ge"},
+		{name: "then core.editor", coreEditor: "ce", visual: "vi", editor: "ed", want: "ce"},
+		{name: "then VISUAL", visual: "vi", editor: "ed", want: "vi"},
+		{name: "then EDITOR", editor: "ed", want: "ed"},
+		{name: "then the default", want: DefaultEditor},
+		{name: "commands with args", coreEditor: "code --wait", want: "code --wait"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepo(t)
+			t.Setenv("GIT_EDITOR", tt.gitEditor)
+			t.Setenv("VISUAL", tt.visual)
+			t.Setenv("EDITOR", tt.editor)
+			if tt.coreEditor != "" {
+				runTestGit(t, repo, "config", "core.editor", tt.coreEditor)
+			}
+			if got := Editor(repo); got != tt.want {
+				t.Errorf("Editor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSequenceEditor(t *testing.T) {
+	tests := []struct {
+		name           string
+		gitSeqEditor   string
+		sequenceEditor string
+		gitEditor      string
+		coreEditor     string
+		want           string
+	}{
+		{name: "GIT_SEQUENCE_EDITOR first", gitSeqEditor: "gse", sequenceEditor: "se", gitEditor: "ge", coreEditor: "ce", want: "gse"},
+		{name: "then sequence.editor", sequenceEditor: "se", gitEditor: "ge", coreEditor: "ce", want: "se"},
+		{name: "then GIT_EDITOR", gitEditor: "ge", coreEditor: "ce", want: "ge"},
+		{name: "then core.editor", coreEditor: "ce", want: "ce"},
+		{name: "then the default", want: DefaultEditor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepo(t)
+			t.Setenv("GIT_SEQUENCE_EDITOR", tt.gitSeqEditor)
+			t.Setenv("GIT_EDITOR", tt.gitEditor)
+			t.Setenv("VISUAL", "")
+			t.Setenv("EDITOR", "")
+			if tt.sequenceEditor != "" {
+				runTestGit(t, repo, "config", "sequence.editor", tt.sequenceEditor)
+			}
+			if tt.coreEditor != "" {
+				runTestGit(t, repo, "config", "core.editor", tt.coreEditor)
+			}
+			if got := SequenceEditor(repo); got != tt.want {
+				t.Errorf("SequenceEditor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,21 +1,79 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 )
 
-func GetDiff(repoPath, filePath string, staged bool) (string, error) {
-	if staged {
-		return RunGit(repoPath, "diff", "--cached", "--", filePath)
+// DiffMode selects which two versions of a file a diff compares.
+type DiffMode int
+
+const (
+	DiffUnstaged DiffMode = iota // working tree vs index
+	DiffStaged                   // index vs HEAD
+	DiffHead                     // working tree vs HEAD (staged and unstaged together)
+)
+
+func (m DiffMode) String() string {
+	switch m {
+	case DiffStaged:
+		return "staged"
+	case DiffHead:
+		return "vs HEAD"
 	}
-	return RunGit(repoPath, "diff", "--", filePath)
+	return "unstaged"
+}
+
+// Next cycles unstaged -> staged -> vs HEAD.
+func (m DiffMode) Next() DiffMode {
+	return (m + 1) % 3
 }
 
-func GetDiffOrContent(repoPath, filePath string, entry FileEntry) (string, error) {
-	if entry.Status == StatusUntracked {
+// DefaultDiffMode is the mode matching where the entry's changes are.
+func DefaultDiffMode(entry FileEntry) DiffMode {
+	if entry.StagingState == Staged {
+		return DiffStaged
+	}
+	return DiffUnstaged
+}
+
+// UncommittedStat is the diffstat of every change to tracked files, staged
+// or not, against HEAD.
+func UncommittedStat(repoPath string) (string, error) {
+	return RunGit(repoPath, "diff", "--stat", "HEAD")
+}
+
+func GetDiff(ctx context.Context, repoPath, filePath string, mode DiffMode) (string, error) {
+	return diffPaths(ctx, repoPath, mode, filePath)
+}
+
+// diffPaths diffs paths in mode with rename detection, which only kicks in
+// when both sides of a rename are in paths.
+func diffPaths(ctx context.Context, repoPath string, mode DiffMode, paths ...string) (string, error) {
+	args := []string{"diff", "-M"}
+	switch mode {
+	case DiffStaged:
+		args = append(args, "--cached")
+	case DiffHead:
+		if HasCommits(repoPath) {
+			args = append(args, "HEAD")
+		} else {
+			// Nothing to compare against yet; the index is the closest base
+			args = append(args, "--cached")
+		}
+	}
+	args = append(args, "--")
+	return RunGitContext(ctx, repoPath, append(args, paths...)...)
+}
+
+// GetDiffOrContent diffs filePath in the given mode. Untracked files have
+// nothing to diff against, so their content is shown as all-added unless
+// mode is DiffStaged.
+func GetDiffOrContent(ctx context.Context, repoPath, filePath string, entry FileEntry, mode DiffMode) (string, error) {
+	if entry.Status == StatusUntracked && mode != DiffStaged {
 		fullPath := filepath.Join(repoPath, filePath)
 		data, err := os.ReadFile(fullPath)
 		if err != nil {
@@ -32,5 +90,8 @@ func GetDiffOrContent(repoPath, filePath string, entry FileEntry) (string, error
 		return b.String(), nil
 	}
 
-	return GetDiff(repoPath, filePath, entry.StagingState == Staged)
+	if entry.OrigPath != "" {
+		return diffPaths(ctx, repoPath, mode, entry.OrigPath, filePath)
+	}
+	return GetDiff(ctx, repoPath, filePath, mode)
 }
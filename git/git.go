@@ -1,24 +1,82 @@
 package git
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"os"
 	"os/exec"
 	"strings"
+	"time"
 )
 
+// logger records every git invocation when debug logging is on.
+var logger *slog.Logger
+
+// SetLogger enables logging of git commands. nil disables it.
+func SetLogger(l *slog.Logger) {
+	logger = l
+}
+
 // GetHeadHash returns the short hash of HEAD.
 func GetHeadHash(repoPath string) (string, error) {
 	return RunGit(repoPath, "rev-parse", "--short", "HEAD")
 }
 
 func RunGit(repoPath string, args ...string) (string, error) {
-	cmd := exec.Command("git", args...)
+	return runGit(context.Background(), repoPath, nil, args)
+}
+
+// RunGitEnv is RunGit with extra environment variables ("KEY=value").
+func RunGitEnv(repoPath string, env []string, args ...string) (string, error) {
+	return runGit(context.Background(), repoPath, env, args)
+}
+
+// RunGitContext is RunGit bound to ctx: cancelling it kills the git process
+// and returns ctx's error. Only use it for read-only commands.
+func RunGitContext(ctx context.Context, repoPath string, args ...string) (string, error) {
+	return runGit(ctx, repoPath, nil, args)
+}
+
+func runGit(ctx context.Context, repoPath string, env, args []string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Dir = repoPath
+	if ctx.Done() != nil {
+		// Don't wait on output pipes a cancelled git's children may hold open
+		cmd.WaitDelay = 100 * time.Millisecond
+	}
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 
+	start := time.Now()
 	out, err := cmd.CombinedOutput()
 	output := strings.TrimRight(string(out), " \t\r\n")
+	if logger != nil {
+		logCommand(repoPath, args, time.Since(start), err, output)
+	}
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
 	if err != nil {
 		return output, fmt.Errorf("git %s: %s: %w", strings.Join(args, " "), output, err)
 	}
 	return output, nil
 }
+
+func logCommand(repoPath string, args []string, elapsed time.Duration, err error, output string) {
+	exit := 0
+	var ee *exec.ExitError
+	if errors.As(err, &ee) {
+		exit = ee.ExitCode()
+	} else if err != nil {
+		exit = -1 // didn't run, e.g. git not found
+	}
+	attrs := []any{"dir", repoPath, "args", strings.Join(args, " "), "duration", elapsed, "exit", exit}
+	if err != nil {
+		logger.Warn("git", append(attrs, "output", output)...)
+		return
+	}
+	logger.Debug("git", attrs...)
+}
@@ -0,0 +1,53 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestRepo creates an empty repo on branch main, isolated from the
+// user's and system git config.
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "Test Author")
+	t.Setenv("GIT_AUTHOR_EMAIL", "author@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test Committer")
+	t.Setenv("GIT_COMMITTER_EMAIL", "committer@example.com")
+	dir := t.TempDir()
+	runTestGit(t, dir, "init", "-q", "-b", "main")
+	return dir
+}
+
+// runTestGit runs git in dir and fails the test if it errors.
+func runTestGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	out, err := RunGit(dir, args...)
+	if err != nil {
+		t.Fatalf("git %v: %v", args, err)
+	}
+	return out
+}
+
+// writeTestFile writes content to name under dir, creating parent dirs.
+func writeTestFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// commitTestFiles stages everything in dir, commits it, and returns the
+// new commit's full hash. Extra args go to git commit.
+func commitTestFiles(t *testing.T, dir, message string, args ...string) string {
+	t.Helper()
+	runTestGit(t, dir, "add", "-A")
+	runTestGit(t, dir, append([]string{"commit", "-q", "-m", message}, args...)...)
+	return runTestGit(t, dir, "rev-parse", "HEAD")
+}
@@ -0,0 +1,77 @@
+package git
+
+import (
+	"errors"
+	"strings"
+)
+
+// Hunk is one "@@" section of a file's diff, with the file header it
+// needs to be applied on its own.
+type Hunk struct {
+	Patch   string
+	Added   int
+	Removed int
+}
+
+// SplitHunks splits a diff into its hunks, in order.
+func SplitHunks(diff string) []Hunk {
+	var hunks []Hunk
+	var header []string
+	var cur *Hunk
+	var body []string
+	flush := func() {
+		if cur != nil {
+			cur.Patch = strings.Join(header, "\n") + "\n" + strings.Join(body, "\n") + "\n"
+			hunks = append(hunks, *cur)
+			cur, body = nil, nil
+		}
+	}
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flush()
+			header = []string{line}
+		case strings.HasPrefix(line, "@@"):
+			flush()
+			cur = &Hunk{}
+			body = []string{line}
+		case cur == nil:
+			header = append(header, line)
+		default:
+			body = append(body, line)
+			switch {
+			case strings.HasPrefix(line, "+"):
+				cur.Added++
+			case strings.HasPrefix(line, "-"):
+				cur.Removed++
+			}
+		}
+	}
+	flush()
+	return hunks
+}
+
+// StageHunk applies h to the index, or takes it back out when unstage is
+// set (h then being a hunk of the staged diff). The working tree is left
+// alone.
+func StageHunk(repoPath string, h Hunk, unstage bool) error {
+	args := []string{"--cached", "--whitespace=nowarn"}
+	if unstage {
+		args = append(args, "--reverse")
+	}
+	_, err := applyPatch(repoPath, h.Patch, args...)
+	return err
+}
+
+// ErrOthersStaged is returned by StageHunkAlone when the index already
+// holds changes that would end up in the hunk's commit.
+var ErrOthersStaged = errors.New("other changes are already staged; commit or unstage them first")
+
+// StageHunkAlone stages h when nothing else is staged, so committing the
+// index commits exactly h.
+func StageHunkAlone(repoPath string, h Hunk) error {
+	if HasStagedChanges(repoPath) {
+		return ErrOthersStaged
+	}
+	return StageHunk(repoPath, h, false)
+}
@@ -0,0 +1,49 @@
+package git
+
+// MergeAbort abandons an in-progress merge, restoring the pre-merge state.
+func MergeAbort(repoPath string) error {
+	_, err := RunGit(repoPath, "merge", "--abort")
+	return err
+}
+
+// MergeContinue concludes a merge whose conflicts are resolved and staged,
+// keeping git's prepared merge message.
+func MergeContinue(repoPath string) error {
+	_, err := RunGit(repoPath, "-c", "core.editor=true", "merge", "--continue")
+	return err
+}
+
+// RebaseAbort abandons an in-progress rebase and returns to the original branch.
+func RebaseAbort(repoPath string) error {
+	_, err := RunGit(repoPath, "rebase", "--abort")
+	return err
+}
+
+// RebaseContinue resumes a rebase after conflicts are resolved and staged.
+// The editor is bypassed so git keeps each commit's message; if conflicts
+// remain, git's explanation is returned in the error.
+func RebaseContinue(repoPath string) error {
+	_, err := RunGit(repoPath, "-c", "core.editor=true", "rebase", "--continue")
+	return err
+}
+
+// RebaseAutosquash rebases everything from hash onwards, folding fixup!
+// and squash! commits into their targets without opening the todo editor.
+// Local changes are stashed around the rebase. If it stops on a conflict,
+// the rebase is left in progress for RebaseContinue or RebaseAbort.
+func RebaseAutosquash(repoPath, hash string) error {
+	args := append([]string{"-c", "core.editor=true"}, AutosquashArgs(repoPath, hash)...)
+	_, err := RunGitEnv(repoPath, []string{"GIT_SEQUENCE_EDITOR=true"}, args...)
+	return err
+}
+
+// AutosquashArgs returns the git arguments for an interactive autosquash
+// rebase of everything from hash onwards, with local changes stashed
+// around it. A root commit is rebased with --root.
+func AutosquashArgs(repoPath, hash string) []string {
+	args := []string{"rebase", "-i", "--autosquash", "--autostash"}
+	if _, err := RunGit(repoPath, "rev-parse", "--verify", "-q", hash+"^"); err != nil {
+		return append(args, "--root")
+	}
+	return append(args, hash+"^")
+}
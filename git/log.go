@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -9,13 +10,61 @@ type GraphLine struct {
 	GraphChars string
 	Hash       string
 	Refs       string
+	Author     string // mailmap-canonical author name
 	Message    string
 	IsCommit   bool
 }
 
-func GetGraph(repoPath string, maxCount int) ([]GraphLine, error) {
-	out, err := RunGit(repoPath, "log", "--graph", "--all", "--decorate=short",
-		"--color=never", fmt.Sprintf("--format=COMMIT:%%h|%%d|%%s"), fmt.Sprintf("-n%d", maxCount))
+// Ref is a branch or tag decorating a commit.
+type Ref struct {
+	Name string
+	Tag  bool
+	Hash string
+}
+
+// RefList parses the line's decoration ("(HEAD -> main, tag: v1.0,
+// origin/main)") into its branches and tags. Remote HEADs are left out.
+func (l GraphLine) RefList() []Ref {
+	decoration := strings.TrimSuffix(strings.TrimPrefix(l.Refs, "("), ")")
+	if decoration == "" {
+		return nil
+	}
+	var refs []Ref
+	for _, name := range strings.Split(decoration, ", ") {
+		name = strings.TrimPrefix(name, "HEAD -> ")
+		if strings.HasSuffix(name, "/HEAD") {
+			continue
+		}
+		tag, isTag := strings.CutPrefix(name, "tag: ")
+		if isTag {
+			name = tag
+		}
+		refs = append(refs, Ref{Name: name, Tag: isTag, Hash: l.Hash})
+	}
+	return refs
+}
+
+// LogOneline lists commits one per line ("abc1234 subject"); revs are
+// passed to git log as they are ("a..b", "HEAD --not c").
+func LogOneline(repoPath string, revs ...string) (string, error) {
+	return RunGit(repoPath, append([]string{"log", "--oneline", "--no-decorate"}, revs...)...)
+}
+
+// IsHead reports whether the line is the checked-out commit: its
+// decoration has "HEAD -> branch", or a bare "HEAD" when detached.
+func (l GraphLine) IsHead() bool {
+	decoration := strings.TrimSuffix(strings.TrimPrefix(l.Refs, "("), ")")
+	for _, name := range strings.Split(decoration, ", ") {
+		if name == "HEAD" || strings.HasPrefix(name, "HEAD -> ") {
+			return true
+		}
+	}
+	return false
+}
+
+func GetGraph(ctx context.Context, repoPath string, maxCount int) ([]GraphLine, error) {
+	out, err := RunGitContext(ctx, repoPath, "log", "--graph", "--all", "--decorate=short",
+		"--color=never", fmt.Sprintf("--format=COMMIT:%%h|%%d|%%aN|%%s"), fmt.Sprintf("-n%d", maxCount))
 	if err != nil {
 		return nil, err
 	}
@@ -31,6 +80,27 @@ func GetGraph(repoPath string, maxCount int) ([]GraphLine, error) {
 	return lines, nil
 }
 
+// CommitPosition resolves rev (usually a short hash) to a full commit hash
+// and counts the commits before it in GetGraph's order, so a graph can be
+// loaded deep enough to show it. pos is -1 when no branch or tag reaches it.
+func CommitPosition(ctx context.Context, repoPath, rev string) (hash string, pos int, err error) {
+	hash, err = RunGitContext(ctx, repoPath, "rev-parse", "--verify", "-q", rev+"^{commit}")
+	if err != nil {
+		return "", -1, fmt.Errorf("no commit matches %q", rev)
+	}
+	// --graph implies --topo-order, so this lists commits as the graph does
+	out, err := RunGitContext(ctx, repoPath, "rev-list", "--all", "--topo-order")
+	if err != nil {
+		return hash, -1, err
+	}
+	for i, h := range strings.Split(out, "\n") {
+		if h == hash {
+			return hash, i, nil
+		}
+	}
+	return hash, -1, nil
+}
+
 func parseLine(line string) GraphLine {
 	idx := strings.Index(line, "COMMIT:")
 	if idx == -1 {
@@ -40,7 +110,7 @@ func parseLine(line string) GraphLine {
 	graphChars := line[:idx]
 	rest := line[idx+len("COMMIT:"):]
 
-	parts := strings.SplitN(rest, "|", 3)
+	parts := strings.SplitN(rest, "|", 4)
 	gl := GraphLine{
 		GraphChars: graphChars,
 		IsCommit:   true,
@@ -52,7 +122,10 @@ func parseLine(line string) GraphLine {
 		gl.Refs = strings.TrimSpace(parts[1])
 	}
 	if len(parts) >= 3 {
-		gl.Message = strings.TrimSpace(parts[2])
+		gl.Author = strings.TrimSpace(parts[2])
+	}
+	if len(parts) >= 4 {
+		gl.Message = strings.TrimSpace(parts[3])
 	}
 	return gl
 }
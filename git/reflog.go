@@ -0,0 +1,57 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ReflogEntry struct {
+	Hash     string // short hash
+	Selector string // e.g. "HEAD@{3}"
+	Action   string // e.g. "commit", "reset", "rebase (finish)"
+	Subject  string // what the action did, e.g. "moving to HEAD~1"
+}
+
+// Reflog returns the last maxCount HEAD reflog entries, newest first.
+func Reflog(repoPath string, maxCount int) ([]ReflogEntry, error) {
+	out, err := RunGit(repoPath, "reflog", fmt.Sprintf("-n%d", maxCount), "--format=%h|%gd|%gs")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+
+	var entries []ReflogEntry
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(line, "|", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		// %gs is "action: subject", e.g. "commit (amend): fix typo"
+		action, subject, ok := strings.Cut(parts[2], ": ")
+		if !ok {
+			action, subject = "", parts[2]
+		}
+		entries = append(entries, ReflogEntry{
+			Hash:     parts[0],
+			Selector: parts[1],
+			Action:   action,
+			Subject:  subject,
+		})
+	}
+	return entries, nil
+}
+
+// ResetKeep moves the current branch to hash with `git reset --keep`, which
+// refuses rather than discards when local changes would be overwritten.
+func ResetKeep(repoPath, hash string) error {
+	_, err := RunGit(repoPath, "reset", "--keep", hash)
+	return err
+}
+
+// CreateBranchAt creates a branch pointing at hash without switching to it.
+func CreateBranchAt(repoPath, branchName, hash string) error {
+	_, err := RunGit(repoPath, "branch", branchName, hash)
+	return err
+}
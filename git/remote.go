@@ -0,0 +1,56 @@
+package git
+
+import "strings"
+
+// RemoteURL returns the fetch URL of the named remote, or "" if it isn't set.
+func RemoteURL(repoPath, remote string) string {
+	url, err := RunGit(repoPath, "remote", "get-url", remote)
+	if err != nil {
+		return ""
+	}
+	return url
+}
+
+// ParseRemoteURL splits a remote URL into its host and "owner/repo" path.
+// It understands scp-style ("git@host:owner/repo.git"), ssh:// and http(s)://
+// forms; ok is false for anything else, such as local paths.
+func ParseRemoteURL(url string) (host, slug string, ok bool) {
+	rest := url
+	if i := strings.Index(rest, "://"); i != -1 {
+		rest = rest[i+3:]
+		slash := strings.Index(rest, "/")
+		if slash == -1 {
+			return "", "", false
+		}
+		host, rest = rest[:slash], rest[slash+1:]
+	} else {
+		colon := strings.Index(rest, ":")
+		if colon == -1 || strings.Contains(rest[:colon], "/") {
+			return "", "", false
+		}
+		host, rest = rest[:colon], rest[colon+1:]
+	}
+
+	// Drop credentials and ports: "git@host:22" -> "host"
+	if at := strings.LastIndex(host, "@"); at != -1 {
+		host = host[at+1:]
+	}
+	if colon := strings.Index(host, ":"); colon != -1 {
+		host = host[:colon]
+	}
+
+	slug = strings.TrimSuffix(strings.Trim(rest, "/"), ".git")
+	if host == "" || !strings.Contains(slug, "/") {
+		return "", "", false
+	}
+	return host, slug, true
+}
+
+// RemoteSlug returns "owner/repo" for a remote URL, or the URL unchanged
+// when it can't be parsed.
+func RemoteSlug(url string) string {
+	if _, slug, ok := ParseRemoteURL(url); ok {
+		return slug
+	}
+	return url
+}
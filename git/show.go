@@ -1,154 +1,142 @@
 package git
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 )
 
 type CommitFileStat struct {
 	Path    string
+	OldPath string // path before a rename, "" otherwise
 	Added   int
 	Deleted int
+	Binary  bool // git counts no lines for it; Added and Deleted are 0
 }
 
 type CommitDetail struct {
-	Hash     string
-	Author   string
-	Date     string
-	Message  string
-	Files    []CommitFileStat
-	TotalAdd int
-	TotalDel int
+	Hash           string
+	Author         string
+	Date           string
+	Parents        []string // full hashes, more than one for merges
+	AuthorEmail    string
+	Committer      string
+	CommitterEmail string
+	CommitDate     string
+	Message        string
+	Files          []CommitFileStat
+	TotalAdd       int
+	TotalDel       int
+	Signature      string // %G? code, only set when fetched with SignatureStatus
 }
 
-func GetCommitDetail(repoPath, hash string) (CommitDetail, error) {
-	out, err := RunGit(repoPath, "show", "--stat", "--format=%H%n%an%n%ai%n%B", hash)
+// GetCommitDetail reads a commit's metadata and message, then its file
+// stats in a second call, so nothing in the message can be mistaken for a
+// stat line. Merge commits are diffed against their first parent.
+func GetCommitDetail(ctx context.Context, repoPath, hash string) (CommitDetail, error) {
+	out, err := RunGitContext(ctx, repoPath, "show", "-s", "--format=%H%x00%aN%x00%ai%x00%P%x00%aE%x00%cN%x00%cE%x00%ci%x00%B", hash)
 	if err != nil {
 		return CommitDetail{}, err
 	}
-
-	lines := strings.Split(out, "\n")
-	if len(lines) < 4 {
+	fields := strings.SplitN(out, "\x00", 9)
+	if len(fields) != 9 {
 		return CommitDetail{}, fmt.Errorf("unexpected git show output")
 	}
-
 	detail := CommitDetail{
-		Hash:   lines[0],
-		Author: lines[1],
-		Date:   lines[2],
-	}
-
-	// Message is everything until the first blank line after the body,
-	// then stat lines follow. Find the stat separator.
-	// The stat block ends with a summary line like " N files changed, ..."
-	// Work backwards from the end to find the stat summary line.
-	statSummaryIdx := -1
-	for i := len(lines) - 1; i >= 3; i-- {
-		trimmed := strings.TrimSpace(lines[i])
-		if strings.Contains(trimmed, "file changed") || strings.Contains(trimmed, "files changed") {
-			statSummaryIdx = i
-			break
-		}
+		Hash:           fields[0],
+		Author:         fields[1],
+		Date:           fields[2],
+		Parents:        strings.Fields(fields[3]),
+		AuthorEmail:    fields[4],
+		Committer:      fields[5],
+		CommitterEmail: fields[6],
+		CommitDate:     fields[7],
+		Message:        strings.TrimSpace(fields[8]),
 	}
 
-	if statSummaryIdx == -1 {
-		// No stat block — entire remainder is message
-		detail.Message = strings.TrimSpace(strings.Join(lines[3:], "\n"))
-		return detail, nil
-	}
-
-	// Find where stat lines start (they come before the summary).
-	// Stat lines look like: " path/to/file | N ++++---"
-	statStart := statSummaryIdx
-	for i := statSummaryIdx - 1; i >= 3; i-- {
-		trimmed := strings.TrimSpace(lines[i])
-		if trimmed == "" {
-			break
-		}
-		if strings.Contains(lines[i], "|") {
-			statStart = i
-		}
-	}
-
-	// Message is between line 3 and the blank line before stats
-	msgEnd := statStart
-	for msgEnd > 3 && strings.TrimSpace(lines[msgEnd-1]) == "" {
-		msgEnd--
+	out, err = RunGitContext(ctx, repoPath, "show", "-m", "--first-parent", "-M", "--numstat", "--format=", hash)
+	if err != nil {
+		return CommitDetail{}, err
 	}
-	detail.Message = strings.TrimSpace(strings.Join(lines[3:msgEnd], "\n"))
-
-	// Parse stat lines
-	for i := statStart; i < statSummaryIdx; i++ {
-		fs := parseStatLine(lines[i])
-		if fs.Path != "" {
-			detail.Files = append(detail.Files, fs)
-			detail.TotalAdd += fs.Added
-			detail.TotalDel += fs.Deleted
-		}
+	detail.Files = parseNumstat(out)
+	for _, fs := range detail.Files {
+		detail.TotalAdd += fs.Added
+		detail.TotalDel += fs.Deleted
 	}
-
 	return detail, nil
 }
 
-func parseStatLine(line string) CommitFileStat {
-	// Format: " path/to/file | 5 ++---"
-	// or:     " path/to/file | Bin 0 -> 1234 bytes"
-	// or:     " src/{old => new}/file.go | 5 ++---"  (rename)
-	parts := strings.SplitN(line, "|", 2)
-	if len(parts) != 2 {
-		return CommitFileStat{}
-	}
-
-	path := strings.TrimSpace(parts[0])
-	stats := strings.TrimSpace(parts[1])
-
-	// Resolve rename notation to the new path
-	path = resolveRenamePath(path)
+// CommitStat summarizes rev: its short hash and subject, then its diffstat.
+func CommitStat(repoPath, rev string) (string, error) {
+	return RunGit(repoPath, "show", "--stat", "--format=%h %s", rev)
+}
 
-	fs := CommitFileStat{Path: path}
+// SignatureStatus returns git's one-letter verdict on hash's signature
+// (%G?): G good, B bad, U good but of unknown validity, X good but expired,
+// Y good by an expired key, R good by a revoked key, E can't be checked,
+// N none.
+func SignatureStatus(ctx context.Context, repoPath, hash string) (string, error) {
+	return RunGitContext(ctx, repoPath, "show", "--no-patch", "--format=%G?", hash)
+}
 
-	// Try to parse numeric changes
-	fields := strings.Fields(stats)
-	if len(fields) >= 1 {
-		if _, err := strconv.Atoi(fields[0]); err == nil && len(fields) >= 2 {
-			changes := fields[1]
-			for _, ch := range changes {
-				if ch == '+' {
-					fs.Added++
-				} else if ch == '-' {
-					fs.Deleted++
-				}
-			}
-		}
+// SignatureLabel describes a SignatureStatus code.
+func SignatureLabel(code string) string {
+	switch code {
+	case "G":
+		return "signed"
+	case "B":
+		return "bad sig"
+	case "U":
+		return "signed (unknown key validity)"
+	case "X":
+		return "signed (signature expired)"
+	case "Y":
+		return "signed (key expired)"
+	case "R":
+		return "signed (key revoked)"
+	case "E":
+		return "signed (can't check: missing key)"
 	}
-
-	return fs
+	return "unsigned"
 }
 
-// resolveRenamePath converts git's rename notation to the new path.
+// resolveRenamePath splits git's rename notation into the old and new
+// paths. oldPath is "" when path isn't a rename.
 //
-//	"src/{old => new}/file.go" → "src/new/file.go"
-//	"old.go => new.go"         → "new.go"
-func resolveRenamePath(path string) string {
+//	"src/{old => new}/file.go" → "src/old/file.go", "src/new/file.go"
+//	"src/{ => sub}/file.go"    → "src/file.go", "src/sub/file.go"
+//	"old.go => new.go"         → "old.go", "new.go"
+func resolveRenamePath(path string) (oldPath, newPath string) {
 	if braceStart := strings.Index(path, "{"); braceStart >= 0 {
 		braceEnd := strings.Index(path, "}")
 		if braceEnd > braceStart {
 			inner := path[braceStart+1 : braceEnd]
-			if arrowIdx := strings.Index(inner, " => "); arrowIdx >= 0 {
-				newPart := inner[arrowIdx+4:]
-				return path[:braceStart] + newPart + path[braceEnd+1:]
+			if oldPart, newPart, ok := strings.Cut(inner, " => "); ok {
+				join := func(part string) string {
+					p := path[:braceStart] + part + path[braceEnd+1:]
+					// An empty side leaves a doubled or leading slash
+					return strings.TrimPrefix(strings.ReplaceAll(p, "//", "/"), "/")
+				}
+				return join(oldPart), join(newPart)
 			}
 		}
 	}
-	if arrowIdx := strings.Index(path, " => "); arrowIdx >= 0 {
-		return strings.TrimSpace(path[arrowIdx+4:])
+	if oldPart, newPart, ok := strings.Cut(path, " => "); ok {
+		return strings.TrimSpace(oldPart), strings.TrimSpace(newPart)
 	}
-	return path
+	return "", path
 }
 
-func GetCommitFileDiff(repoPath, hash, file string) (string, error) {
-	out, err := RunGit(repoPath, "show", "--format=", hash, "--", file)
+// GetCommitFileDiff returns hash's diff for one file. For a rename, pass
+// the old path too so git pairs the two sides up instead of showing the
+// file as added.
+func GetCommitFileDiff(repoPath, hash, file, oldFile string) (string, error) {
+	args := []string{"show", "-m", "--first-parent", "-M", "--format=", hash, "--", file}
+	if oldFile != "" {
+		args = append(args, oldFile)
+	}
+	out, err := RunGit(repoPath, args...)
 	if err != nil {
 		return "", err
 	}
@@ -158,3 +146,24 @@ func GetCommitFileDiff(repoPath, hash, file string) (string, error) {
 	}
 	return out, nil
 }
+
+// ErrMergeCommit is returned by FormatPatch for merges, which have no
+// single patch.
+var ErrMergeCommit = errors.New("merge commits can't be copied as a patch; pick one of the merged commits instead")
+
+// FormatPatch returns hash as a mailbox patch (git format-patch), ready
+// for git am elsewhere.
+func FormatPatch(repoPath, hash string) (string, error) {
+	parents, err := RunGit(repoPath, "rev-list", "--parents", "-n", "1", hash)
+	if err != nil {
+		return "", err
+	}
+	if len(strings.Fields(parents)) > 2 {
+		return "", ErrMergeCommit
+	}
+	out, err := RunGit(repoPath, "format-patch", "-1", "--stdout", hash)
+	if err != nil {
+		return "", err
+	}
+	return out + "\n", nil
+}
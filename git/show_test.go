@@ -0,0 +1,146 @@
+package git
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// showFixture builds a repo with a subject containing "|", a rename, and a
+// merge, and returns their hashes.
+func showFixture(t *testing.T) (repo, pipe, rename, merge string) {
+	t.Helper()
+	repo = newTestRepo(t)
+	writeTestFile(t, repo, "a.txt", "one\ntwo\nthree\nfour\nfive\n")
+	writeTestFile(t, repo, "b.txt", "b\n")
+	commitTestFiles(t, repo, "initial")
+
+	runTestGit(t, repo, "checkout", "-q", "-b", "feature")
+	writeTestFile(t, repo, "b.txt", "b\nfeature\n")
+	commitTestFiles(t, repo, "feature work")
+	runTestGit(t, repo, "checkout", "-q", "main")
+
+	writeTestFile(t, repo, "a.txt", "one\n2\nthree\nfour\nfive\n")
+	pipe = commitTestFiles(t, repo, "fix: split a | b\n\nKeep | in the body\n 3 files changed | too")
+
+	runTestGit(t, repo, "mv", "a.txt", "a-renamed.txt")
+	rename = commitTestFiles(t, repo, "Rename a")
+
+	runTestGit(t, repo, "merge", "-q", "--no-ff", "-m", "Merge feature", "feature")
+	merge = runTestGit(t, repo, "rev-parse", "HEAD")
+	return repo, pipe, rename, merge
+}
+
+func TestGetCommitDetail(t *testing.T) {
+	repo, pipe, rename, merge := showFixture(t)
+
+	tests := []struct {
+		name        string
+		hash        string
+		wantMessage string
+		wantFiles   []CommitFileStat
+		wantParents int
+	}{
+		{
+			name:        "pipes in message",
+			hash:        pipe,
+			wantMessage: "fix: split a | b\n\nKeep | in the body\n 3 files changed | too",
+			wantFiles:   []CommitFileStat{{Path: "a.txt", Added: 1, Deleted: 1}},
+			wantParents: 1,
+		},
+		{
+			name:        "rename",
+			hash:        rename,
+			wantMessage: "Rename a",
+			wantFiles:   []CommitFileStat{{Path: "a-renamed.txt", OldPath: "a.txt"}},
+			wantParents: 1,
+		},
+		{
+			name:        "merge against first parent",
+			hash:        merge,
+			wantMessage: "Merge feature",
+			wantFiles:   []CommitFileStat{{Path: "b.txt", Added: 1}},
+			wantParents: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detail, err := GetCommitDetail(context.Background(), repo, tt.hash)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if detail.Hash != tt.hash {
+				t.Errorf("Hash = %q, want %q", detail.Hash, tt.hash)
+			}
+			if detail.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", detail.Message, tt.wantMessage)
+			}
+			if !reflect.DeepEqual(detail.Files, tt.wantFiles) {
+				t.Errorf("Files = %+v, want %+v", detail.Files, tt.wantFiles)
+			}
+			var add, del int
+			for _, f := range tt.wantFiles {
+				add += f.Added
+				del += f.Deleted
+			}
+			if detail.TotalAdd != add || detail.TotalDel != del {
+				t.Errorf("totals = +%d -%d, want +%d -%d", detail.TotalAdd, detail.TotalDel, add, del)
+			}
+			if len(detail.Parents) != tt.wantParents {
+				t.Errorf("Parents = %v, want %d", detail.Parents, tt.wantParents)
+			}
+			if detail.Author != "Test Author" || !strings.Contains(detail.CommitDate, "-") {
+				t.Errorf("Author = %q, CommitDate = %q", detail.Author, detail.CommitDate)
+			}
+		})
+	}
+}
+
+func TestGetCommitFileDiffRename(t *testing.T) {
+	repo, _, rename, _ := showFixture(t)
+
+	detail, err := GetCommitDetail(context.Background(), repo, rename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(detail.Files) != 1 {
+		t.Fatalf("Files = %+v, want one rename", detail.Files)
+	}
+	f := detail.Files[0]
+	if f.OldPath != "a.txt" || f.Path != "a-renamed.txt" {
+		t.Fatalf("rename = (%q, %q), want (%q, %q)", f.OldPath, f.Path, "a.txt", "a-renamed.txt")
+	}
+
+	diff, err := GetCommitFileDiff(repo, rename, f.Path, f.OldPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == "" {
+		t.Fatal("GetCommitFileDiff() returned an empty diff for a rename")
+	}
+	for _, want := range []string{"diff --git a/a.txt b/a-renamed.txt", "rename from a.txt", "rename to a-renamed.txt"} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("diff is missing %q:\n%s", want, diff)
+		}
+	}
+}
+
+func TestResolveRenamePath(t *testing.T) {
+	tests := []struct {
+		in               string
+		wantOld, wantNew string
+	}{
+		{"plain.go", "", "plain.go"},
+		{"old.go => new.go", "old.go", "new.go"},
+		{"src/{old => new}/file.go", "src/old/file.go", "src/new/file.go"},
+		{"src/{ => sub}/file.go", "src/file.go", "src/sub/file.go"},
+		{"{lib => }/file.go", "lib/file.go", "file.go"},
+	}
+	for _, tt := range tests {
+		gotOld, gotNew := resolveRenamePath(tt.in)
+		if gotOld != tt.wantOld || gotNew != tt.wantNew {
+			t.Errorf("resolveRenamePath(%q) = (%q, %q), want (%q, %q)", tt.in, gotOld, gotNew, tt.wantOld, tt.wantNew)
+		}
+	}
+}
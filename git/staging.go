@@ -1,6 +1,9 @@
 package git
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 )
@@ -11,8 +14,13 @@ func GetStagedDiffStats(repoPath string) ([]CommitFileStat, error) {
 	if err != nil {
 		return nil, err
 	}
+	return parseNumstat(out), nil
+}
+
+// parseNumstat parses `git diff --numstat` output.
+func parseNumstat(out string) []CommitFileStat {
 	if strings.TrimSpace(out) == "" {
-		return nil, nil
+		return nil
 	}
 
 	var stats []CommitFileStat
@@ -29,15 +37,64 @@ func GetStagedDiffStats(repoPath string) ([]CommitFileStat, error) {
 		}
 		added, _ := strconv.Atoi(fields[0])
 		deleted, _ := strconv.Atoi(fields[1])
-		path := fields[2]
-		path = resolveRenamePath(path)
+		oldPath, path := resolveRenamePath(fields[2])
 		stats = append(stats, CommitFileStat{
 			Path:    path,
+			OldPath: oldPath,
 			Added:   added,
 			Deleted: deleted,
+			Binary:  fields[0] == "-" && fields[1] == "-",
 		})
 	}
-	return stats, nil
+	return stats
+}
+
+// maxCountBytes caps how much of an untracked file is read to count lines.
+const maxCountBytes = 1 << 20
+
+// AttachLineStats fills in Added/Deleted for files from the staged and
+// unstaged numstat. Untracked files count all their lines as added;
+// binary and very large ones are left at zero.
+func AttachLineStats(repoPath string, files []FileEntry) {
+	staged, _ := RunGit(repoPath, "diff", "--cached", "--numstat")
+	unstaged, _ := RunGit(repoPath, "diff", "--numstat")
+	byState := map[StagingState]map[string]CommitFileStat{
+		Staged:   {},
+		Unstaged: {},
+	}
+	for _, s := range parseNumstat(staged) {
+		byState[Staged][s.Path] = s
+	}
+	for _, s := range parseNumstat(unstaged) {
+		byState[Unstaged][s.Path] = s
+	}
+
+	for i := range files {
+		f := &files[i]
+		if f.Status == StatusUntracked {
+			f.Added = countLines(filepath.Join(repoPath, f.Path))
+			continue
+		}
+		if s, ok := byState[f.StagingState][f.Path]; ok {
+			f.Added, f.Deleted = s.Added, s.Deleted
+		}
+	}
+}
+
+func countLines(path string) int {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() || info.Size() > maxCountBytes {
+		return 0
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || bytes.IndexByte(data, 0) != -1 {
+		return 0
+	}
+	n := bytes.Count(data, []byte("\n"))
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		n++
+	}
+	return n
 }
 
 func StageFile(repoPath, filePath string) error {
@@ -45,11 +102,70 @@ func StageFile(repoPath, filePath string) error {
 	return err
 }
 
+// ResolveConflict resolves an unmerged path by taking our or their side
+// and staging the result. When the chosen side has no version of the file
+// (deleted there, or only added on the other side), it is removed.
+func ResolveConflict(repoPath string, file FileEntry, theirs bool) error {
+	side, missing := "--ours", file.Conflict == "DD" || file.Conflict == "DU" || file.Conflict == "UA"
+	if theirs {
+		side, missing = "--theirs", file.Conflict == "DD" || file.Conflict == "UD" || file.Conflict == "AU"
+	}
+	if missing {
+		_, err := RunGit(repoPath, "rm", "--", file.Path)
+		return err
+	}
+	if _, err := RunGit(repoPath, "checkout", side, "--", file.Path); err != nil {
+		return err
+	}
+	return StageFile(repoPath, file.Path)
+}
+
 func UnstageFile(repoPath, filePath string) error {
 	_, err := RunGit(repoPath, "restore", "--staged", "--", filePath)
 	return err
 }
 
+// StagePath stages everything under dir, including deletions.
+func StagePath(repoPath, dir string) error {
+	_, err := RunGit(repoPath, "add", "--", dir)
+	return err
+}
+
+// UnstagePath unstages everything under dir.
+func UnstagePath(repoPath, dir string) error {
+	_, err := RunGit(repoPath, "restore", "--staged", "--", dir)
+	return err
+}
+
+// SectionPaths lists the paths of files in state, for staging or
+// unstaging them by name. A rename contributes its old path too, so both
+// sides of it move together.
+func SectionPaths(files []FileEntry, state StagingState) []string {
+	var paths []string
+	for _, f := range files {
+		if f.StagingState != state {
+			continue
+		}
+		if f.OrigPath != "" {
+			paths = append(paths, f.OrigPath)
+		}
+		paths = append(paths, f.Path)
+	}
+	return paths
+}
+
+// StageFiles stages the given paths in one git call.
+func StageFiles(repoPath string, paths []string) error {
+	_, err := RunGit(repoPath, append([]string{"add", "--"}, paths...)...)
+	return err
+}
+
+// UnstageFiles unstages the given paths in one git call.
+func UnstageFiles(repoPath string, paths []string) error {
+	_, err := RunGit(repoPath, append([]string{"restore", "--staged", "--"}, paths...)...)
+	return err
+}
+
 func StageAll(repoPath string) error {
 	_, err := RunGit(repoPath, "add", "-A")
 	return err
@@ -59,3 +175,9 @@ func UnstageAll(repoPath string) error {
 	_, err := RunGit(repoPath, "reset", "HEAD")
 	return err
 }
+
+// HasStagedChanges reports whether the index differs from HEAD.
+func HasStagedChanges(repoPath string) bool {
+	_, err := RunGit(repoPath, "diff", "--cached", "--quiet")
+	return err != nil
+}
@@ -0,0 +1,70 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestBulkStageRoundTrip stages and unstages a repo's listed files by name,
+// as the dashboard's stage-all does, and checks that doing the opposite
+// restores the index exactly.
+func TestBulkStageRoundTrip(t *testing.T) {
+	ignore := []string{"*.log"}
+	tests := []struct {
+		name      string
+		state     StagingState
+		stage     func(repo string, paths []string) error
+		undo      func(repo string, paths []string) error
+		wantPaths []string
+	}{
+		{
+			name:      "stage all",
+			state:     Unstaged,
+			stage:     StageFiles,
+			undo:      UnstageFiles,
+			wantPaths: []string{"edited.txt", "new.txt"},
+		},
+		{
+			name:      "unstage all",
+			state:     Staged,
+			stage:     UnstageFiles,
+			undo:      StageFiles,
+			wantPaths: []string{"old.txt", "renamed.txt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepo(t)
+			writeTestFile(t, repo, "old.txt", "one\ntwo\nthree\nfour\n")
+			writeTestFile(t, repo, "edited.txt", "a\n")
+			commitTestFiles(t, repo, "initial")
+			runTestGit(t, repo, "mv", "old.txt", "renamed.txt")
+			writeTestFile(t, repo, "edited.txt", "b\n")
+			writeTestFile(t, repo, "new.txt", "new\n")
+			// Hidden from the dashboard by ignore_patterns, so never touched
+			writeTestFile(t, repo, "debug.log", "noise\n")
+			before := runTestGit(t, repo, "status", "--porcelain")
+
+			files, err := GetStatus(repo, ignore)
+			if err != nil {
+				t.Fatal(err)
+			}
+			paths := SectionPaths(files, tt.state)
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Fatalf("SectionPaths() = %v, want %v", paths, tt.wantPaths)
+			}
+			if err := tt.stage(repo, paths); err != nil {
+				t.Fatal(err)
+			}
+			if after := runTestGit(t, repo, "status", "--porcelain"); after == before {
+				t.Fatal("staging changed nothing")
+			}
+			if err := tt.undo(repo, paths); err != nil {
+				t.Fatal(err)
+			}
+			if after := runTestGit(t, repo, "status", "--porcelain"); after != before {
+				t.Errorf("after undo status =\n%s\nwant\n%s", after, before)
+			}
+		})
+	}
+}
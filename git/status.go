@@ -1,6 +1,8 @@
 package git
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -15,6 +17,7 @@ const (
 	StatusRenamed
 	StatusCopied
 	StatusUntracked
+	StatusConflicted
 )
 
 func (s FileStatus) String() string {
@@ -31,6 +34,8 @@ func (s FileStatus) String() string {
 		return "copied"
 	case StatusUntracked:
 		return "untracked"
+	case StatusConflicted:
+		return "conflict"
 	default:
 		return "unknown"
 	}
@@ -48,16 +53,54 @@ type FileEntry struct {
 	Status       FileStatus
 	StagingState StagingState
 	OrigPath     string // for renames
+	Conflict     string // porcelain XY code for unmerged paths ("UU", "AA", "DU", ...)
+	Added        int    // lines added, only set when line stats are fetched
+	Deleted      int    // lines deleted, only set when line stats are fetched
+}
+
+// conflictCodes are the porcelain XY pairs git reports for unmerged paths.
+var conflictCodes = map[string]string{
+	"DD": "both deleted",
+	"AU": "added by us",
+	"UD": "deleted by them",
+	"UA": "added by them",
+	"DU": "deleted by us",
+	"AA": "both added",
+	"UU": "both modified",
+}
+
+// ConflictDescription returns a readable description of an unmerged path's
+// conflict, e.g. "both modified", or "" for ordinary entries.
+func (f FileEntry) ConflictDescription() string {
+	return conflictCodes[f.Conflict]
 }
 
 type RepoStatus struct {
-	Path   string
-	Name   string
-	Branch string
-	Files  []FileEntry
-	Ahead  int
-	Behind int
-	Error  error
+	Path       string
+	Name       string
+	Branch     string
+	Files      []FileEntry
+	Ahead      int
+	Behind     int
+	InProgress string // "merging", "rebasing", "cherry-picking", "reverting", or ""
+	StashCount int
+	Detached   bool   // HEAD isn't on a branch; Branch is then "HEAD"
+	HeadHash   string // short HEAD hash, only set when Detached
+	RemoteURL  string // origin's URL, only fetched when display.show_remote is set
+	UntrackedDirs []string // wholly untracked directories, only fetched when display.collapse_untracked_dirs is set
+	CompareRef    string   // the repo's compare_remote ref, only set when configured and found
+	CompareAhead  int      // commits ahead of CompareRef
+	CompareBehind int      // commits behind CompareRef
+	Error      error
+}
+
+// BranchLabel returns the branch name, or "(detached @ <hash>)" when HEAD
+// isn't on a branch.
+func (r RepoStatus) BranchLabel() string {
+	if r.Detached {
+		return "(detached @ " + r.HeadHash + ")"
+	}
+	return r.Branch
 }
 
 func GetBranch(repoPath string) (string, error) {
@@ -93,6 +136,17 @@ func GetStatus(repoPath string, ignorePatterns []string) ([]FileEntry, error) {
 			continue
 		}
 
+		// Unmerged paths get a single unstaged entry; staging marks them resolved
+		if code := line[:2]; conflictCodes[code] != "" {
+			entries = append(entries, FileEntry{
+				Path:         path,
+				Status:       StatusConflicted,
+				StagingState: Unstaged,
+				Conflict:     code,
+			})
+			continue
+		}
+
 		// Index (staged) changes
 		if indexStatus != ' ' && indexStatus != '?' {
 			status := parseStatusChar(indexStatus)
@@ -139,10 +193,18 @@ func GetRepoStatus(repoPath, name string, ignorePatterns []string) RepoStatus {
 		return rs
 	}
 	rs.Branch = branch
+	// --abbrev-ref prints the literal "HEAD" when no branch is checked out
+	if branch == "HEAD" {
+		rs.Detached = true
+		rs.HeadHash, _ = RunGit(repoPath, "rev-parse", "--short", "HEAD")
+	}
 
 	ahead, behind := getAheadBehind(repoPath)
 	rs.Ahead = ahead
 	rs.Behind = behind
+	gitDir := GitDir(repoPath)
+	rs.InProgress = DetectInProgress(gitDir)
+	rs.StashCount = countStashes(CommonDir(gitDir))
 
 	files, err := GetStatus(repoPath, ignorePatterns)
 	if err != nil {
@@ -154,6 +216,131 @@ func GetRepoStatus(repoPath, name string, ignorePatterns []string) RepoStatus {
 	return rs
 }
 
+// IgnoredDirs lists the directories git ignores in repoPath, relative to
+// it. Only the outermost ignored directory of a subtree is listed.
+func IgnoredDirs(repoPath string) ([]string, error) {
+	out, err := RunGit(repoPath, "ls-files", "--others", "--ignored", "--exclude-standard", "--directory")
+	if err != nil {
+		return nil, err
+	}
+	return dirLines(out), nil
+}
+
+// UntrackedDirs lists the directories in repoPath holding only untracked
+// files, the ones plain `git status` shows as "dir/". Only the outermost
+// directory of a subtree is listed.
+func UntrackedDirs(repoPath string) ([]string, error) {
+	out, err := RunGit(repoPath, "ls-files", "--others", "--exclude-standard", "--directory", "--no-empty-directory")
+	if err != nil {
+		return nil, err
+	}
+	return dirLines(out), nil
+}
+
+// dirLines picks the "dir/" entries out of ls-files --directory output.
+func dirLines(out string) []string {
+	var dirs []string
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasSuffix(line, "/") {
+			dirs = append(dirs, strings.TrimSuffix(line, "/"))
+		}
+	}
+	return dirs
+}
+
+// IsIgnored reports whether git ignores path in repoPath.
+func IsIgnored(repoPath, path string) bool {
+	_, err := RunGit(repoPath, "check-ignore", "-q", path)
+	return err == nil
+}
+
+// IsRepo reports whether dir is the top of a git working tree: its .git
+// is a directory, or a "gitdir:" pointer file as in linked worktrees and
+// submodules.
+func IsRepo(dir string) bool {
+	dotGit := filepath.Join(dir, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return false
+	}
+	if info.IsDir() {
+		return true
+	}
+	data, err := os.ReadFile(dotGit)
+	return err == nil && strings.HasPrefix(string(data), "gitdir: ")
+}
+
+// GitDir returns the git directory for a worktree, following the "gitdir:"
+// pointer file used by linked worktrees and submodules.
+func GitDir(repoPath string) string {
+	dotGit := filepath.Join(repoPath, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil || info.IsDir() {
+		return dotGit
+	}
+	data, err := os.ReadFile(dotGit)
+	if err != nil {
+		return dotGit
+	}
+	dir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+	if !ok {
+		return dotGit
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(repoPath, dir)
+	}
+	return dir
+}
+
+// CommonDir returns where the parts of a repo shared by all its worktrees
+// (refs, the stash) live: gitDir itself, unless it's a linked worktree's
+// git dir, which names the main one in a "commondir" file.
+func CommonDir(gitDir string) string {
+	data, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return gitDir
+	}
+	dir := strings.TrimSpace(string(data))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(gitDir, dir)
+	}
+	return filepath.Clean(dir)
+}
+
+// DetectInProgress reports an interrupted merge, rebase, cherry-pick, or
+// revert by looking for git's state files in gitDir.
+func DetectInProgress(gitDir string) string {
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(gitDir, name))
+		return err == nil
+	}
+	switch {
+	case exists("rebase-merge"), exists("rebase-apply"):
+		return "rebasing"
+	case exists("MERGE_HEAD"):
+		return "merging"
+	case exists("CHERRY_PICK_HEAD"):
+		return "cherry-picking"
+	case exists("REVERT_HEAD"):
+		return "reverting"
+	}
+	return ""
+}
+
+// countStashes counts stash entries from the stash reflog, which is what
+// `git stash list` reads, without spawning a git process on every poll.
+func countStashes(gitDir string) int {
+	data, err := os.ReadFile(filepath.Join(gitDir, "logs", "refs", "stash"))
+	if err != nil {
+		return 0
+	}
+	log := strings.TrimSpace(string(data))
+	if log == "" {
+		return 0
+	}
+	return strings.Count(log, "\n") + 1
+}
+
 func parseStatusChar(c byte) FileStatus {
 	switch c {
 	case 'M':
@@ -171,25 +358,66 @@ func parseStatusChar(c byte) FileStatus {
 	}
 }
 
+// AheadBehind returns how many commits HEAD is ahead of and behind its
+// upstream. Without an upstream, ahead counts commits on no remote branch.
+func AheadBehind(repoPath string) (ahead, behind int) {
+	return getAheadBehind(repoPath)
+}
+
 func getAheadBehind(repoPath string) (ahead, behind int) {
-	out, err := RunGit(repoPath, "rev-list", "--count", "--left-right", "@{upstream}...HEAD")
+	ahead, behind, err := countAheadBehind(repoPath, "@{upstream}")
 	if err != nil {
 		// No upstream tracking branch (e.g. new local branch).
 		// Count commits not reachable from any remote branch.
-		out, err = RunGit(repoPath, "rev-list", "--count", "HEAD", "--not", "--remotes")
+		out, err := RunGit(repoPath, "rev-list", "--count", "HEAD", "--not", "--remotes")
 		if err != nil {
 			return 0, 0
 		}
 		ahead, _ = strconv.Atoi(strings.TrimSpace(out))
 		return ahead, 0
 	}
+	return ahead, behind
+}
+
+// countAheadBehind counts the commits HEAD has that ref doesn't, and the
+// other way round.
+func countAheadBehind(repoPath, ref string) (ahead, behind int, err error) {
+	out, err := RunGit(repoPath, "rev-list", "--count", "--left-right", ref+"...HEAD")
+	if err != nil {
+		return 0, 0, err
+	}
 	parts := strings.Fields(out)
 	if len(parts) != 2 {
-		return 0, 0
+		return 0, 0, fmt.Errorf("unexpected rev-list output %q", out)
 	}
 	behind, _ = strconv.Atoi(parts[0])
 	ahead, _ = strconv.Atoi(parts[1])
-	return ahead, behind
+	return ahead, behind, nil
+}
+
+// CompareRemote counts HEAD's commits ahead of and behind a second remote,
+// for repos that push to one remote but follow another (a fork's origin
+// and upstream). remote is a remote name, compared on the same branch or
+// else that remote's default branch, or a ref like "upstream/main". ref is
+// "" when none of them exist.
+func CompareRemote(repoPath, remote, branch string) (ref string, ahead, behind int) {
+	candidates := []string{remote}
+	if !strings.Contains(remote, "/") {
+		candidates = []string{remote + "/" + branch, remote + "/HEAD"}
+	}
+	for _, c := range candidates {
+		// --abbrev-ref names what upstream/HEAD points at
+		name, err := RunGit(repoPath, "rev-parse", "--abbrev-ref", "refs/remotes/"+c)
+		if err != nil {
+			continue
+		}
+		ahead, behind, err := countAheadBehind(repoPath, "refs/remotes/"+c)
+		if err != nil {
+			continue
+		}
+		return name, ahead, behind
+	}
+	return "", 0, 0
 }
 
 func shouldIgnore(path string, patterns []string) bool {
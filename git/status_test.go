@@ -0,0 +1,153 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectInProgress(t *testing.T) {
+	tests := []struct {
+		name  string
+		dirs  []string
+		files []string
+		want  string
+	}{
+		{name: "clean", want: ""},
+		{name: "merge", files: []string{"MERGE_HEAD"}, want: "merging"},
+		{name: "interactive rebase", dirs: []string{"rebase-merge"}, want: "rebasing"},
+		{name: "am rebase", dirs: []string{"rebase-apply"}, want: "rebasing"},
+		{name: "cherry-pick", files: []string{"CHERRY_PICK_HEAD"}, want: "cherry-picking"},
+		{name: "revert", files: []string{"REVERT_HEAD"}, want: "reverting"},
+		// A conflicted pick during a rebase leaves both; the rebase wins
+		{name: "rebase with merge head", dirs: []string{"rebase-merge"}, files: []string{"MERGE_HEAD"}, want: "rebasing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitDir := t.TempDir()
+			for _, d := range tt.dirs {
+				if err := os.Mkdir(filepath.Join(gitDir, d), 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, f := range tt.files {
+				if err := os.WriteFile(filepath.Join(gitDir, f), []byte("0000000\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := DetectInProgress(gitDir); got != tt.want {
+				t.Errorf("DetectInProgress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRepoStatusDetached(t *testing.T) {
+	repo := newTestRepo(t)
+	writeTestFile(t, repo, "a.txt", "a\n")
+	first := commitTestFiles(t, repo, "first")
+	writeTestFile(t, repo, "a.txt", "b\n")
+	commitTestFiles(t, repo, "second")
+
+	rs := GetRepoStatus(repo, "repo", nil)
+	if rs.Error != nil {
+		t.Fatal(rs.Error)
+	}
+	if rs.Detached || rs.HeadHash != "" || rs.Branch != "main" {
+		t.Errorf("on a branch: Detached = %v, HeadHash = %q, Branch = %q", rs.Detached, rs.HeadHash, rs.Branch)
+	}
+
+	runTestGit(t, repo, "checkout", "-q", first)
+	rs = GetRepoStatus(repo, "repo", nil)
+	if rs.Error != nil {
+		t.Fatal(rs.Error)
+	}
+	short := runTestGit(t, repo, "rev-parse", "--short", first)
+	if !rs.Detached {
+		t.Error("Detached = false after checking out a commit")
+	}
+	if rs.HeadHash != short {
+		t.Errorf("HeadHash = %q, want %q", rs.HeadHash, short)
+	}
+	if want := "(detached @ " + short + ")"; rs.BranchLabel() != want {
+		t.Errorf("BranchLabel() = %q, want %q", rs.BranchLabel(), want)
+	}
+}
+
+func TestGitDirFromDotGitFile(t *testing.T) {
+	root := t.TempDir()
+	// A linked worktree: main/.git/worktrees/wt names the main git dir in
+	// commondir, and wt/.git points back at it with a relative path
+	mainGit := filepath.Join(root, "main", ".git")
+	wtGit := filepath.Join(mainGit, "worktrees", "wt")
+	writeTestFile(t, wtGit, "commondir", "../..\n")
+	writeTestFile(t, root, "wt/.git", "gitdir: ../main/.git/worktrees/wt\n")
+	// A submodule: its git dir lives under the superproject's .git/modules
+	subGit := filepath.Join(root, "super", ".git", "modules", "sub")
+	if err := os.MkdirAll(subGit, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, root, "super/sub/.git", "gitdir: ../.git/modules/sub\n")
+	writeTestFile(t, root, "notrepo/.git", "not a pointer\n")
+
+	tests := []struct {
+		name          string
+		dir           string
+		wantRepo      bool
+		wantGitDir    string
+		wantCommonDir string
+	}{
+		{name: "repo", dir: "main", wantRepo: true, wantGitDir: mainGit, wantCommonDir: mainGit},
+		{name: "worktree", dir: "wt", wantRepo: true, wantGitDir: wtGit, wantCommonDir: mainGit},
+		{name: "submodule", dir: "super/sub", wantRepo: true, wantGitDir: subGit, wantCommonDir: subGit},
+		{name: "bad .git file", dir: "notrepo"},
+		{name: "no .git", dir: "super/.git/modules"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(root, tt.dir)
+			if got := IsRepo(dir); got != tt.wantRepo {
+				t.Errorf("IsRepo() = %v, want %v", got, tt.wantRepo)
+			}
+			if !tt.wantRepo {
+				return
+			}
+			gitDir := GitDir(dir)
+			if gitDir != tt.wantGitDir {
+				t.Errorf("GitDir() = %q, want %q", gitDir, tt.wantGitDir)
+			}
+			if got := CommonDir(gitDir); got != tt.wantCommonDir {
+				t.Errorf("CommonDir() = %q, want %q", got, tt.wantCommonDir)
+			}
+		})
+	}
+}
+
+func TestGetRepoStatusInWorktree(t *testing.T) {
+	repo := newTestRepo(t)
+	writeTestFile(t, repo, "a.txt", "a\n")
+	commitTestFiles(t, repo, "first")
+	writeTestFile(t, repo, "a.txt", "stashed\n")
+	runTestGit(t, repo, "stash", "-q")
+
+	wt := filepath.Join(t.TempDir(), "wt")
+	runTestGit(t, repo, "worktree", "add", "-q", "-b", "side", wt)
+	writeTestFile(t, wt, "b.txt", "b\n")
+
+	if !IsRepo(wt) {
+		t.Fatal("IsRepo() = false for a linked worktree")
+	}
+	rs := GetRepoStatus(wt, "wt", nil)
+	if rs.Error != nil {
+		t.Fatal(rs.Error)
+	}
+	if rs.Branch != "side" {
+		t.Errorf("Branch = %q, want %q", rs.Branch, "side")
+	}
+	if rs.StashCount != 1 {
+		t.Errorf("StashCount = %d, want the main repo's 1", rs.StashCount)
+	}
+	if len(rs.Files) != 1 || rs.Files[0].Path != "b.txt" {
+		t.Errorf("Files = %+v, want just b.txt", rs.Files)
+	}
+}
@@ -8,7 +8,7 @@ import (
 
 type RecentCommitInfo struct {
 	Hash         string
-	Author       string
+	Author       string // canonical name per .mailmap (%aN)
 	Date         string
 	RelativeDate string
 	Message      string
@@ -17,7 +17,7 @@ type RecentCommitInfo struct {
 
 func GetRecentCommits(repoPath string, days int) ([]RecentCommitInfo, error) {
 	since := fmt.Sprintf("--since=%d days ago", days)
-	out, err := RunGit(repoPath, "log", since, "--format=%h|%an|%ai|%ar|%s", "--shortstat")
+	out, err := RunGit(repoPath, "log", since, "--format=%h|%aN|%ai|%ar|%s", "--shortstat")
 	if err != nil {
 		return nil, err
 	}
@@ -57,12 +57,46 @@ func GetRecentCommits(repoPath string, days int) ([]RecentCommitInfo, error) {
 
 // GetRecentCommitsByCount returns the last N commits for a repo.
 func GetRecentCommitsByCount(repoPath string, count int) ([]RecentCommitInfo, error) {
-	out, err := RunGit(repoPath, "log", fmt.Sprintf("-n%d", count), "--format=%h|%an|%ai|%ar|%s")
+	out, err := RunGit(repoPath, "log", fmt.Sprintf("-n%d", count), "--format=%h|%aN|%ai|%ar|%s")
 	if err != nil {
 		return nil, err
 	}
+	return parseCommitLines(out), nil
+}
+
+// IncomingCommits returns commits on the upstream branch that HEAD doesn't
+// have yet, newest first. Only as fresh as the last fetch.
+func IncomingCommits(repoPath string) ([]RecentCommitInfo, error) {
+	out, err := RunGit(repoPath, "log", "HEAD..@{upstream}", "--format=%h|%aN|%ai|%ar|%s")
+	if err != nil {
+		return nil, err
+	}
+	return parseCommitLines(out), nil
+}
+
+// CommitsBetween returns the non-merge commits reachable from to but not
+// from, newest first. An empty from means everything up to to.
+func CommitsBetween(repoPath, from, to string) ([]RecentCommitInfo, error) {
+	rng := to
+	if from != "" {
+		rng = from + ".." + to
+	}
+	out, err := RunGit(repoPath, "log", "--no-merges", rng, "--format=%h|%aN|%ai|%ar|%s", "--")
+	if err != nil {
+		return nil, err
+	}
+	return parseCommitLines(out), nil
+}
+
+// Upstream returns the short name of the current branch's upstream, e.g. "origin/main".
+func Upstream(repoPath string) (string, error) {
+	return RunGit(repoPath, "rev-parse", "--abbrev-ref", "@{upstream}")
+}
+
+// parseCommitLines parses "hash|author|date|relative date|subject" log output.
+func parseCommitLines(out string) []RecentCommitInfo {
 	if strings.TrimSpace(out) == "" {
-		return nil, nil
+		return nil
 	}
 
 	var commits []RecentCommitInfo
@@ -83,7 +117,7 @@ func GetRecentCommitsByCount(repoPath string, count int) ([]RecentCommitInfo, er
 			Message:      parts[4],
 		})
 	}
-	return commits, nil
+	return commits
 }
 
 func parseFilesChanged(stat string) int {
@@ -0,0 +1,58 @@
+package git
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAuthorsFollowMailmap(t *testing.T) {
+	repo := newTestRepo(t)
+	writeTestFile(t, repo, ".mailmap", "Jane Doe <jane@example.com> <jdoe@old.example.com>\n")
+	writeTestFile(t, repo, "a.txt", "a\n")
+	commitTestFiles(t, repo, "first", "--author=jdoe <jdoe@old.example.com>")
+	writeTestFile(t, repo, "b.txt", "b\n")
+	hash := commitTestFiles(t, repo, "second", "--author=Jane Doe <jane@example.com>")
+
+	commits, err := GetRecentCommits(repo, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("GetRecentCommits() returned %d commits, want 2", len(commits))
+	}
+	authors := map[string]int{}
+	for _, c := range commits {
+		authors[c.Author]++
+	}
+	if authors["Jane Doe"] != 2 {
+		t.Errorf("GetRecentCommits() authors = %v, want both commits by Jane Doe", authors)
+	}
+
+	byCount, err := GetRecentCommitsByCount(repo, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range byCount {
+		if c.Author != "Jane Doe" {
+			t.Errorf("GetRecentCommitsByCount() author of %s = %q, want %q", c.Message, c.Author, "Jane Doe")
+		}
+	}
+
+	lines, err := GetGraph(context.Background(), repo, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range lines {
+		if l.IsCommit && l.Author != "Jane Doe" {
+			t.Errorf("GetGraph() author of %s = %q, want %q", l.Message, l.Author, "Jane Doe")
+		}
+	}
+
+	detail, err := GetCommitDetail(context.Background(), repo, hash+"~1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.Author != "Jane Doe" || detail.AuthorEmail != "jane@example.com" {
+		t.Errorf("GetCommitDetail() author = %q <%s>, want Jane Doe <jane@example.com>", detail.Author, detail.AuthorEmail)
+	}
+}
@@ -0,0 +1,20 @@
+package git
+
+import "strings"
+
+// ListTags returns the repo's tags, most recently created first; tags
+// from the same moment go highest version first.
+func ListTags(repoPath string) ([]string, error) {
+	// The last --sort is the primary key
+	out, err := RunGit(repoPath, "tag", "--list", "--sort=-version:refname", "--sort=-creatordate")
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for _, line := range strings.Split(out, "\n") {
+		if t := strings.TrimSpace(line); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags, nil
+}
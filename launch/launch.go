@@ -0,0 +1,80 @@
+// Package launch starts external programs (a terminal, the file manager)
+// at a directory without suspending the TUI.
+package launch
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// linuxTerminals are tried in order when neither an override nor $TERMINAL is set.
+var linuxTerminals = []string{
+	"x-terminal-emulator", "gnome-terminal", "konsole", "alacritty",
+	"kitty", "wezterm", "foot", "xterm",
+}
+
+// Terminal opens a terminal in dir. command overrides detection; otherwise
+// tmux gets a new window, then $TERMINAL, then a platform default.
+func Terminal(dir, command string) error {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		args = defaultTerminal(dir)
+	}
+	if len(args) == 0 {
+		return errors.New("no terminal found; set display.terminal_cmd")
+	}
+	return start(dir, args)
+}
+
+func defaultTerminal(dir string) []string {
+	if os.Getenv("TMUX") != "" {
+		return []string{"tmux", "new-window", "-c", dir}
+	}
+	if t := strings.Fields(os.Getenv("TERMINAL")); len(t) > 0 {
+		return t
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"open", "-a", "Terminal", "."}
+	case "windows":
+		return []string{"cmd", "/c", "start", "cmd"}
+	}
+	for _, t := range linuxTerminals {
+		if _, err := exec.LookPath(t); err == nil {
+			return []string{t}
+		}
+	}
+	return nil
+}
+
+// FileManager opens dir in the OS file manager, or with command when set
+// (dir is passed as its last argument).
+func FileManager(dir, command string) error {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		switch runtime.GOOS {
+		case "darwin":
+			args = []string{"open"}
+		case "windows":
+			args = []string{"explorer"}
+		default:
+			args = []string{"xdg-open"}
+		}
+	}
+	return start(dir, append(args, dir))
+}
+
+// start runs args in dir without waiting, so the TUI keeps running. The
+// process is reaped in the background.
+func start(dir string, args []string) error {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = dir
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
@@ -4,17 +4,50 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"runtime/debug"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/dylan/gitdash/config"
+	"github.com/dylan/gitdash/git"
 	"github.com/dylan/gitdash/tui"
 )
 
 func main() {
 	configPath := flag.String("config", "", "path to config file (default: ~/.config/gitdash/config.toml)")
+	var count countFlag
+	flag.Var(&count, "count", "print the number of repos with uncommitted changes (-count=ahead: with unpushed commits) and exit")
+	logPath := flag.String("log", os.Getenv("GITDASH_LOG"), "write a debug log of git commands and UI events to this file (or set GITDASH_LOG)")
 	flag.Parse()
 
+	if *logPath != "" {
+		// The TUI owns stdout, so logs can only go to a file
+		f, err := tea.LogToFile(*logPath, "gitdash")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error opening log file: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		logger := slog.New(slog.NewTextHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		git.SetLogger(logger)
+		tui.SetDebugLog(logger)
+		tui.SetCrashDir(filepath.Dir(*logPath))
+	}
+
+	// Panics during startup, before bubbletea takes over the terminal
+	defer func() {
+		if r := recover(); r != nil {
+			path := tui.WriteCrashReport(r, debug.Stack())
+			fmt.Fprintf(os.Stderr, "gitdash crashed: %v\n\n%s\n", r, debug.Stack())
+			if path != "" {
+				fmt.Fprintf(os.Stderr, "Crash report written to %s\n", path)
+			}
+			os.Exit(2)
+		}
+	}()
+
 	path := *configPath
 	explicit := path != ""
 	if !explicit {
@@ -22,10 +55,22 @@ func main() {
 	}
 
 	cfg, err := config.Load(path)
+	if count != "" {
+		// Headless mode for shell prompts: a bare number, no TUI
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(countRepos(cfg, count))
+		return
+	}
+
+	firstRun := false
 	if err != nil {
-		// If using default path and file doesn't exist, use empty config
+		// If using default path and file doesn't exist, start the setup wizard
 		if !explicit && errors.Is(err, os.ErrNotExist) {
 			cfg = config.Config{}
+			firstRun = true
 		} else {
 			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 			os.Exit(1)
@@ -33,8 +78,20 @@ func main() {
 	}
 
 	app := tui.NewApp(cfg, path)
-	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	if firstRun {
+		app.StartSetup()
+	}
+	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithReportFocus())
 	if _, err := p.Run(); err != nil {
+		// bubbletea recovers panics itself and restores the terminal; our
+		// guards inside the app have already written the report
+		if errors.Is(err, tea.ErrProgramPanic) {
+			fmt.Fprintln(os.Stderr, "gitdash crashed; the panic and stack trace are printed above.")
+			if path := tui.CrashReportPath(); path != "" {
+				fmt.Fprintf(os.Stderr, "Crash report written to %s, please attach it to a bug report.\n", path)
+			}
+			os.Exit(2)
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
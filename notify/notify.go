@@ -0,0 +1,49 @@
+// Package notify gets the user's attention when something finishes while
+// they're looking at another window: a terminal bell and a desktop
+// notification.
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+)
+
+// Bell rings the terminal bell.
+func Bell() {
+	os.Stdout.WriteString("\a")
+}
+
+// Send shows a desktop notification with terminal-notifier or osascript on
+// macOS, and notify-send elsewhere.
+func Send(title, body string) error {
+	args, err := command(title, body)
+	if err != nil {
+		return err
+	}
+	out, err := exec.Command(args[0], args[1:]...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", args[0], err, out)
+	}
+	return nil
+}
+
+func command(title, body string) ([]string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		if _, err := exec.LookPath("terminal-notifier"); err == nil {
+			return []string{"terminal-notifier", "-title", title, "-message", body}, nil
+		}
+		script := "display notification " + strconv.Quote(body) + " with title " + strconv.Quote(title)
+		return []string{"osascript", "-e", script}, nil
+	case "windows":
+		return nil, errors.New("desktop notifications aren't supported on Windows")
+	}
+	if _, err := exec.LookPath("notify-send"); err != nil {
+		return nil, errors.New("notify-send not found")
+	}
+	return []string{"notify-send", "--app-name=gitdash", title, body}, nil
+}
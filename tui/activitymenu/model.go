@@ -0,0 +1,140 @@
+package activitymenu
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dylan/gitdash/tui/shared"
+)
+
+type ActionKind int
+
+const (
+	ActionNone ActionKind = iota
+	ActionClose
+	ActionCopy
+	ActionWrite
+)
+
+type KeyResult struct {
+	Action ActionKind
+	Days   int
+	ByType bool
+}
+
+// Model is a small menu for a repo's activity export: the day range, how
+// commits are grouped, and whether it's copied or written to a file.
+type Model struct {
+	repoPath string
+	repoName string
+	outPath  string
+	ranges   []int // day ranges to pick from
+	rangeIdx int
+	byType   bool
+	cursor   int // 0 = range, 1 = grouping
+}
+
+func New(ranges []int) Model {
+	return Model{ranges: ranges}
+}
+
+// Open shows the menu for a repo, starting at the range closest to days.
+// The grouping picked last time is kept. outPath is where "write" puts
+// the file.
+func (m *Model) Open(repoPath, repoName string, days int, outPath string) {
+	m.repoPath = repoPath
+	m.repoName = repoName
+	m.outPath = outPath
+	m.cursor = 0
+	m.rangeIdx = 0
+	for i, d := range m.ranges {
+		if d <= days {
+			m.rangeIdx = i
+		}
+	}
+}
+
+func (m Model) RepoPath() string {
+	return m.repoPath
+}
+
+func (m Model) RepoName() string {
+	return m.repoName
+}
+
+func (m Model) result(action ActionKind) KeyResult {
+	return KeyResult{Action: action, Days: m.ranges[m.rangeIdx], ByType: m.byType}
+}
+
+func (m *Model) HandleKey(msg tea.KeyMsg) KeyResult {
+	switch msg.String() {
+	case "esc", "q":
+		return KeyResult{Action: ActionClose}
+	case "j", "down":
+		m.cursor = 1
+	case "k", "up":
+		m.cursor = 0
+	case " ", "l", "right":
+		m.change(1)
+	case "h", "left":
+		m.change(-1)
+	case "enter", "y":
+		return m.result(ActionCopy)
+	case "w":
+		return m.result(ActionWrite)
+	}
+	return KeyResult{Action: ActionNone}
+}
+
+// change steps the option under the cursor.
+func (m *Model) change(dir int) {
+	if m.cursor == 1 {
+		m.byType = !m.byType
+		return
+	}
+	n := len(m.ranges)
+	m.rangeIdx = (m.rangeIdx + dir + n) % n
+}
+
+func (m Model) ViewOverlay(background string, w, h int) string {
+	overlay := shared.BranchPickerOverlayStyle.Render(m.renderContent())
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color("0")),
+	)
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+
+	b.WriteString(shared.TitleStyle.Render("Export Activity"))
+	b.WriteString(" ")
+	b.WriteString(shared.GraphHashStyle.Render(m.repoName))
+	b.WriteString("\n\n")
+
+	group := "day"
+	if m.byType {
+		group = "commit type"
+	}
+	days := fmt.Sprintf("last %d days", m.ranges[m.rangeIdx])
+	if m.ranges[m.rangeIdx] == 1 {
+		days = "last day"
+	}
+	rows := []string{
+		"Range:     ‹ " + days + " ›",
+		fmt.Sprintf("Group by:  ‹ %s ›", group),
+	}
+	for i, row := range rows {
+		if i == m.cursor {
+			row = shared.CursorStyle.Render(row)
+		}
+		b.WriteString(row + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(shared.HelpDescStyle.Render("←/→: change  enter: copy  w: write to " + m.outPath))
+	b.WriteString("\n")
+	b.WriteString(shared.HelpDescStyle.Render("esc: cancel"))
+	return b.String()
+}
@@ -1,37 +1,81 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/dylan/gitdash/activity"
 	"github.com/dylan/gitdash/ai"
+	"github.com/dylan/gitdash/changelog"
 	"github.com/dylan/gitdash/config"
 	"github.com/dylan/gitdash/git"
-	"github.com/dylan/gitdash/nvim"
+	"github.com/dylan/gitdash/launch"
+	"github.com/dylan/gitdash/notify"
+	"github.com/dylan/gitdash/editor"
+	"github.com/dylan/gitdash/commit"
 	"github.com/dylan/gitdash/conductor"
+	"github.com/dylan/gitdash/forge"
+	"github.com/dylan/gitdash/tui/activitymenu"
 	"github.com/dylan/gitdash/tui/branchpicker"
 	"github.com/dylan/gitdash/tui/commitview"
+	"github.com/dylan/gitdash/tui/conductorexport"
+	"github.com/dylan/gitdash/tui/confirmpreview"
 	"github.com/dylan/gitdash/tui/conductorpane"
 	"github.com/dylan/gitdash/tui/dashboard"
 	"github.com/dylan/gitdash/tui/diffview"
 	"github.com/dylan/gitdash/tui/featurelinker"
 	"github.com/dylan/gitdash/tui/graphpane"
+	"github.com/dylan/gitdash/tui/hashprompt"
+	"github.com/dylan/gitdash/tui/palette"
+	"github.com/dylan/gitdash/tui/patchprompt"
+	"github.com/dylan/gitdash/tui/refpicker"
 	"github.com/dylan/gitdash/tui/help"
 	"github.com/dylan/gitdash/tui/projectmanager"
+	"github.com/dylan/gitdash/tui/quickcommit"
+	"github.com/dylan/gitdash/tui/rangepicker"
+	"github.com/dylan/gitdash/tui/reflog"
+	"github.com/dylan/gitdash/tui/settings"
+	"github.com/dylan/gitdash/tui/setup"
+	"github.com/dylan/gitdash/tui/themepicker"
 	"github.com/dylan/gitdash/tui/icons"
 	"github.com/dylan/gitdash/tui/shared"
+	"github.com/dylan/gitdash/tui/squash"
+	"github.com/dylan/gitdash/watch"
 )
 
 const pollInterval = 2 * time.Second
 
 type pollTickMsg time.Time
 
+type watchStartedMsg struct {
+	watcher *watch.Watcher
+	err     error
+}
+
+// reposChangedMsg carries repos the watcher saw change on disk.
+type reposChangedMsg []string
+
+// reposRefreshedMsg is a status refresh of only some repos.
+type reposRefreshedMsg []git.RepoStatus
+
+// aiChunkMsg is a piece of an AI commit message as it streams in. More
+// arrive on stream, ending with a shared.AICommitMsgMsg.
+type aiChunkMsg struct {
+	text   string
+	stream <-chan tea.Msg
+}
+
 type ActiveView int
 
 const (
@@ -40,8 +84,30 @@ const (
 	CommitView
 	BranchPickerView
 	ProjectManagerView
+	SetupView
+	ThemePickerView
+	SettingsView
+	ReflogView
+	QuickCommitView
+	SquashView
+	ConductorExportView
+	ChangelogView
+	PaletteView
+	PatchPromptView
+	RefPickerView
+	HashPromptView
+	ActivityView
+	ConfirmPreviewView
 )
 
+// hunkCommit is a hunk staged on its own from the diff view, waiting on the
+// quick-commit prompt. Cancelling the prompt unstages it again.
+type hunkCommit struct {
+	repoPath string
+	hunk     git.Hunk
+	what     string // e.g. "1 hunk of main.go (+3 -1)"
+}
+
 // FocusPanel tracks which column has focus in the 3-column layout.
 type FocusPanel int
 
@@ -51,9 +117,24 @@ const (
 	FocusConductor
 )
 
+// stageUndo is the inverse of the last staging operation: restage (or
+// unstage) paths in repoPath. staged is the direction that was applied.
+type stageUndo struct {
+	repoPath string
+	paths    []string
+	staged   bool
+}
+
+// confirmAction is a pending y/n prompt shown in the status bar.
+type confirmAction struct {
+	prompt string
+	cmd    tea.Cmd
+}
+
 type App struct {
 	cfg        config.Config
 	configPath string
+	state      config.State
 	activeView ActiveView
 	showHelp   bool
 	statusMsg  string
@@ -68,6 +149,20 @@ type App struct {
 	conductorPane  conductorpane.Model
 	featureLinker  featurelinker.Model
 	projectManager projectmanager.Model
+	setup          setup.Model
+	themePicker    themepicker.Model
+	settings       settings.Model
+	reflogView     reflog.Model
+	exportMenu     conductorexport.Model // conductor markdown export
+	changelogMenu  rangepicker.Model     // changelog tag range
+	palette        palette.Model
+	quickCommit    quickcommit.Model
+	squash         squash.Model
+	patchPrompt    patchprompt.Model
+	hashPrompt     hashprompt.Model
+	refPicker      refpicker.Model
+	activityMenu   activitymenu.Model // one repo's activity export
+	confirmPreview confirmpreview.Model
 
 	showGraph       bool
 	showConductor   bool
@@ -75,6 +170,12 @@ type App struct {
 	focusPanel      FocusPanel
 	graphRepo       string // repo path of last graph fetch
 	lastDetailHash  string // hash of last fetched commit detail
+	pendingHash     string // commit to select once a deeper graph loads
+	deepGraphRepo   string // repo whose graph was loaded past the configured max
+	deepGraphDepth  int    // how many commits to load for deepGraphRepo
+	fetches         *fetches
+	watcher         *watch.Watcher // nil unless display.watch is on
+	lastRefresh     time.Time      // when repo status last came in
 	conductorRepo   string // repo path of last conductor fetch
 
 	// Conductor data cache (per repo)
@@ -85,43 +186,122 @@ type App struct {
 	spinnerLabels map[shared.LoaderOp]string
 	pushingRepoIdx int // repo index being pushed (-1 = none)
 
+	// Terminal focus, for notifications. Terminals that don't report focus
+	// are treated as unfocused.
+	focusReported bool
+	focused       bool
+
+	contextDays int // range for context export
+
 	// Feedback system
 	feedback *shared.Feedback
+	confirm  *confirmAction
+
+	lastStage *stageUndo // one-level undo for staging
+	hunkCommit *hunkCommit
 
 	width  int
 	height int
 }
 
+// debugLog records handled messages when debug logging is on.
+var debugLog *slog.Logger
+
+// SetDebugLog enables logging of tea messages and warnings. nil disables it.
+func SetDebugLog(l *slog.Logger) {
+	debugLog = l
+}
+
+func logMsg(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		return // several per second while loading
+	case tea.KeyMsg:
+		debugLog.Debug("key", "key", msg.String())
+		recordMsg("key " + msg.String())
+		return
+	}
+	debugLog.Debug("msg", "type", fmt.Sprintf("%T", msg))
+	recordMsg(fmt.Sprintf("%T", msg))
+}
+
 func NewApp(cfg config.Config, configPath string) App {
 	shared.InitStyles(cfg.ResolvedTheme(), cfg.ResolvedGraphColors())
 	icons.SetNerdFonts(cfg.Display.NerdFonts)
+	ai.SetOSC52(cfg.Display.OSC52Clipboard)
+	shared.SetScrollbars(cfg.ResolvedScrollbars())
 
 	gp := graphpane.New()
 	gp.SetShowIcons(cfg.Display.Icons || cfg.Display.NerdFonts)
+	gp.SetShowAuthors(cfg.Display.GraphAuthors)
+
+	cv := commitview.New()
+	cv.SetEmoji(cfg.Commit.Emoji, cfg.Commit.EmojiOnly)
+	cv.SetSortStaged(cfg.Commit.SortStaged)
+	cv.SetSign(cfg.Commit.Sign)
+
+	state, _ := config.LoadState(config.StatePath(configPath))
+	showGraph, showConductor := cfg.ResolvedShowGraph(), cfg.ResolvedShowConductor()
+	restored := state
+	if cfg.ResolvedRestoreView() {
+		if state.ShowGraph != nil {
+			showGraph = *state.ShowGraph
+		}
+		if state.ShowConductor != nil {
+			showConductor = *state.ShowConductor
+		}
+		if p, ok := state.ProjectPanes[state.ActiveProject]; ok && state.ActiveProject != "" {
+			showGraph, showConductor = p.Graph, p.Conductor
+		}
+	} else {
+		restored.ActiveProject = "" // start at the top level
+		// Top-level toggles from last time don't apply either
+		state.ShowGraph, state.ShowConductor = nil, nil
+	}
 
 	dash := dashboard.New(cfg.ResolvedPriorityRules(), cfg.Display)
 	dash.SetProjects(cfg.Projects)
+	dash.RestoreState(restored)
+	dash.SetPinned(state.Pinned)
+	dash.SetHideClean(state.HideClean)
+	dash.SetFileLimit(cfg.ResolvedFileLimit())
 
 	return App{
 		cfg:            cfg,
 		configPath:     configPath,
+		state:          state,
 		activeView:     DashboardView,
 		dashboard:      dash,
 		diffView:       diffview.New(),
-		commitView:     commitview.New(),
+		commitView:     cv,
 		helpView:       help.New(),
 		graphPane:      gp,
 		branchPicker:   branchpicker.New(),
 		conductorPane:  conductorpane.New(),
 		featureLinker:  featurelinker.New(),
+		themePicker:    themepicker.New(),
+		settings:       settings.New(),
+		reflogView:     reflog.New(),
+		exportMenu:     conductorexport.New(),
+		changelogMenu:  rangepicker.New(),
+		palette:        palette.New(),
+		quickCommit:    quickcommit.New(),
+		patchPrompt:    patchprompt.New(),
+		hashPrompt:     hashprompt.New(),
+		refPicker:      refpicker.New(),
+		activityMenu:   activitymenu.New(config.ContextDayRanges),
+		confirmPreview: confirmpreview.New(),
+		squash:         squash.New(),
 		projectManager: projectmanager.New(filepath.Dir(configPath), cfg.ResolvedScanRoot()),
-		showGraph:      cfg.ResolvedShowGraph(),
-		showConductor:  cfg.ResolvedShowConductor(),
+		showGraph:      showGraph,
+		showConductor:  showConductor,
 		focusPanel:     FocusDashboard,
 		conductorData:  make(map[string]*conductor.ConductorData),
 		spinners:       make(map[shared.LoaderOp]spinner.Model),
 		spinnerLabels:  make(map[shared.LoaderOp]string),
+		fetches:        newFetches(),
 		pushingRepoIdx: -1,
+		contextDays:    cfg.ResolvedContextDays(),
 	}
 }
 
@@ -130,6 +310,46 @@ func (a *App) setStatus(msg string) {
 	a.statusTime = time.Now()
 }
 
+// exportProjectContext exports the context summary for the active project,
+// or the highlighted project in all-projects mode.
+func (a *App) exportProjectContext() tea.Cmd {
+	pi := a.dashboard.ActiveProject()
+	if pi == -1 {
+		item, ok := a.dashboard.SelectedItem()
+		if !ok || item.Kind != dashboard.ProjectHeader {
+			return nil
+		}
+		pi = item.ProjectIndex
+	}
+	if pi < 0 || pi >= len(a.cfg.Projects) {
+		return nil
+	}
+	proj := a.cfg.Projects[pi]
+	spinCmd := a.startLoader(shared.OpExport, "Exporting "+proj.Name+" context")
+	return tea.Batch(spinCmd, exportContextCmd(proj.Repos, proj.Name, a.contextDays, ""))
+}
+
+// cycleContextDays advances the context export range through config.ContextDayRanges.
+func (a *App) cycleContextDays() {
+	next := config.ContextDayRanges[0]
+	for i, d := range config.ContextDayRanges {
+		if d == a.contextDays && i+1 < len(config.ContextDayRanges) {
+			next = config.ContextDayRanges[i+1]
+			break
+		}
+	}
+	a.contextDays = next
+	a.setFeedback(shared.FeedbackInfo, "Export range: "+daysLabel(next), "", "")
+}
+
+// daysLabel formats a day range as "last N days" (or "last day").
+func daysLabel(days int) string {
+	if days == 1 {
+		return "last day"
+	}
+	return fmt.Sprintf("last %d days", days)
+}
+
 func (a *App) newSpinner() spinner.Model {
 	theme := a.cfg.ResolvedTheme()
 	s := spinner.New()
@@ -150,7 +370,240 @@ func (a *App) stopLoader(op shared.LoaderOp) {
 	delete(a.spinnerLabels, op)
 }
 
+// saveState persists UI state, surfacing failures as a warning.
+func (a *App) saveState() {
+	if err := config.SaveState(config.StatePath(a.configPath), a.state); err != nil {
+		a.setFeedback(shared.FeedbackWarning, "Could not save state: "+err.Error(), err.Error(), "")
+	}
+}
+
+// savePanes records the graph and conductor toggles in the state file,
+// when they changed. Inside a project they're saved for that project only.
+func (a *App) savePanes() {
+	st := &a.state
+	if name := a.dashboard.ProjectName(); name != "" {
+		panes := config.Panes{Graph: a.showGraph, Conductor: a.showConductor}
+		if p, ok := st.ProjectPanes[name]; ok && p == panes {
+			return
+		}
+		if st.ProjectPanes == nil {
+			st.ProjectPanes = make(map[string]config.Panes)
+		}
+		st.ProjectPanes[name] = panes
+		a.saveState()
+		return
+	}
+	if st.ShowGraph != nil && *st.ShowGraph == a.showGraph &&
+		st.ShowConductor != nil && *st.ShowConductor == a.showConductor {
+		return
+	}
+	showGraph, showConductor := a.showGraph, a.showConductor
+	st.ShowGraph, st.ShowConductor = &showGraph, &showConductor
+	a.saveState()
+}
+
+// applyPanes shows the graph and conductor as last toggled in the active
+// project, falling back to the top-level toggles, then the config defaults.
+func (a *App) applyPanes() {
+	showGraph, showConductor := a.cfg.ResolvedShowGraph(), a.cfg.ResolvedShowConductor()
+	if a.state.ShowGraph != nil {
+		showGraph = *a.state.ShowGraph
+	}
+	if a.state.ShowConductor != nil {
+		showConductor = *a.state.ShowConductor
+	}
+	if p, ok := a.state.ProjectPanes[a.dashboard.ProjectName()]; ok {
+		showGraph, showConductor = p.Graph, p.Conductor
+	}
+	if showGraph == a.showGraph && showConductor == a.showConductor {
+		return
+	}
+	a.showGraph, a.showConductor = showGraph, showConductor
+	if (!a.showGraph && a.focusPanel == FocusGraph) || (!a.showConductor && a.focusPanel == FocusConductor) {
+		a.focusPanel = FocusDashboard
+		a.graphFocused = false
+	}
+	a.layoutSizes()
+}
+
+// syncState captures dashboard layout into the state file.
+func (a *App) syncState() {
+	a.dashboard.SaveState(&a.state)
+	a.saveState()
+}
+
+// toggleHideClean flips the hide-clean filter and persists it.
+func (a *App) toggleHideClean() tea.Cmd {
+	a.state.HideClean = !a.state.HideClean
+	a.dashboard.SetHideClean(a.state.HideClean)
+	if a.state.HideClean {
+		a.setFeedback(shared.FeedbackInfo, "Hiding clean repos", "", "")
+	} else {
+		a.setFeedback(shared.FeedbackInfo, "Showing all repos", "", "")
+	}
+	a.saveState()
+	return a.maybeRefreshGraph()
+}
+
+// toggleByStatus switches the dashboard in and out of the by-status list.
+func (a *App) toggleByStatus() tea.Cmd {
+	if a.dashboard.ToggleByStatus() {
+		a.setFeedback(shared.FeedbackInfo, "Showing all repos by status", "", "")
+	} else {
+		a.setFeedback(shared.FeedbackInfo, "Showing repos", "", "")
+	}
+	a.graphRepo = ""
+	a.conductorRepo = ""
+	return a.maybeRefreshGraph()
+}
+
+// togglePin pins or unpins the selected repo and persists the pin list.
+func (a *App) togglePin() tea.Cmd {
+	name, pinned, ok := a.dashboard.TogglePin()
+	if !ok {
+		return nil
+	}
+	if pinned {
+		a.setFeedback(shared.FeedbackInfo, "Pinned "+name, "", "")
+	} else {
+		a.setFeedback(shared.FeedbackInfo, "Unpinned "+name, "", "")
+	}
+	a.state.Pinned = a.dashboard.PinnedPaths()
+	a.saveState()
+	return a.maybeRefreshGraph()
+}
+
+// copySelectedPath copies the selected file's path (repo-relative unless
+// copy_absolute is set), or the repo path when a repo header is selected.
+func (a *App) copySelectedPath() tea.Cmd {
+	item, ok := a.dashboard.SelectedItem()
+	if !ok || item.Repo == nil {
+		return nil
+	}
+	var path string
+	switch {
+	case item.Kind == dashboard.File && item.File != nil:
+		path = item.File.Path
+	case item.Kind == dashboard.FolderHeader, item.Kind == dashboard.UntrackedDir:
+		path = item.Dir
+	case item.Kind == dashboard.RepoHeader:
+		path = item.Repo.Path
+	default:
+		return nil
+	}
+	if item.Kind != dashboard.RepoHeader && a.cfg.Display.CopyAbsolute {
+		path = filepath.Join(item.Repo.Path, path)
+	}
+	return func() tea.Msg {
+		return shared.PathCopiedMsg{Path: path, Err: ai.CopyToClipboard(path)}
+	}
+}
+
+// askConfirm shows a y/n prompt; cmd runs only if the user presses y.
+func (a *App) askConfirm(prompt string, cmd tea.Cmd) {
+	a.confirm = &confirmAction{prompt: prompt, cmd: cmd}
+}
+
+// askConfirmPreview asks before a destructive action, showing what it will
+// drop or change; load builds that preview in the background. cmd runs
+// only if the user presses y.
+func (a *App) askConfirmPreview(title, prompt string, cmd tea.Cmd, load func() (string, error)) tea.Cmd {
+	seq := a.confirmPreview.Open(title, prompt, cmd)
+	a.activeView = ConfirmPreviewView
+	return func() tea.Msg {
+		preview, err := load()
+		return shared.ConfirmPreviewMsg{Seq: seq, Preview: preview, Err: err}
+	}
+}
+
+// previewSection heads a preview's git output, or says empty when there's
+// none.
+func previewSection(heading, empty, out string, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	if out == "" {
+		return empty, nil
+	}
+	return heading + "\n" + out, nil
+}
+
+// bulkStage stages (or unstages) every file listed for repo, asking first
+// when enough files would change for a mis-key to hurt. Only the listed
+// paths are touched, so undo can reverse exactly what was done.
+func (a *App) bulkStage(repo *git.RepoStatus, stage bool) tea.Cmd {
+	from, verb := git.Unstaged, "Stage"
+	if !stage {
+		from, verb = git.Staged, "Unstage"
+	}
+	n := 0
+	for _, f := range repo.Files {
+		if f.StagingState == from {
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	paths := git.SectionPaths(repo.Files, from)
+	cmd := stageFilesCmd(repo.Path, paths)
+	if !stage {
+		cmd = unstageFilesCmd(repo.Path, paths)
+	}
+	if a.cfg.ResolvedConfirmBulk() && n >= a.cfg.ResolvedConfirmBulkMin() {
+		a.askConfirm(fmt.Sprintf("%s all %d files in %s?", verb, n, repo.Name), cmd)
+		return nil
+	}
+	return cmd
+}
+
+// stageFailed reports a failed stage or unstage. What it changed before
+// failing is unknown, so there's nothing safe to undo.
+func (a *App) stageFailed(staged bool, err error) tea.Cmd {
+	a.lastStage = nil
+	what := "Staging failed"
+	if !staged {
+		what = "Unstaging failed"
+	}
+	a.setFeedback(shared.FeedbackError, what, err.Error(), "")
+	return refreshAllStatus(a.cfg)
+}
+
+// folderStage stages or unstages the files of a folder header's section.
+// A tree level is staged as a whole directory; a flat folder only holds
+// the files directly in it, so those are staged by name.
+func (a *App) folderStage(item dashboard.FlatItem, stage bool) tea.Cmd {
+	from, verb := git.Unstaged, "Stage"
+	if !stage {
+		from, verb = git.Staged, "Unstage"
+	}
+	paths, nested := a.dashboard.FolderFiles(item, from)
+	n := len(paths)
+	if n == 0 {
+		return nil
+	}
+	var cmd tea.Cmd
+	switch {
+	case nested && stage:
+		cmd = stagePathCmd(item.Repo.Path, item.Dir, paths)
+	case nested:
+		cmd = unstagePathCmd(item.Repo.Path, item.Dir, paths)
+	case stage:
+		cmd = stageFilesCmd(item.Repo.Path, paths)
+	default:
+		cmd = unstageFilesCmd(item.Repo.Path, paths)
+	}
+	if a.cfg.ResolvedConfirmBulk() && n >= a.cfg.ResolvedConfirmBulkMin() {
+		a.askConfirm(fmt.Sprintf("%s all %d files in %s/?", verb, n, item.Dir), cmd)
+		return nil
+	}
+	return cmd
+}
+
 func (a *App) setFeedback(level shared.FeedbackLevel, message string, detail string, op shared.LoaderOp) {
+	if debugLog != nil && level >= shared.FeedbackWarning {
+		debugLog.Warn("feedback", "message", message, "detail", detail)
+	}
 	a.feedback = &shared.Feedback{
 		Level:     level,
 		Message:   message,
@@ -161,7 +614,21 @@ func (a *App) setFeedback(level shared.FeedbackLevel, message string, detail str
 }
 
 func (a App) Init() tea.Cmd {
-	return tea.Batch(refreshAllStatus(a.cfg), pollTickCmd())
+	if a.activeView == SetupView {
+		return tea.Batch(scanReposCmd(a.setup.ScanRoot()), pollTickCmd())
+	}
+	cmds := []tea.Cmd{refreshAllStatus(a.cfg), pollTickCmd()}
+	if a.cfg.Display.Watch {
+		cmds = append(cmds, startWatchCmd(a.cfg))
+	}
+	return tea.Batch(cmds...)
+}
+
+// StartSetup opens the first-run wizard instead of the empty dashboard.
+func (a *App) StartSetup() {
+	a.setup = setup.New(a.cfg.ResolvedScanRoot(), a.configPath)
+	a.setup.SetSize(a.width, a.height)
+	a.activeView = SetupView
 }
 
 func pollTickCmd() tea.Cmd {
@@ -171,7 +638,24 @@ func pollTickCmd() tea.Cmd {
 }
 
 func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer crashGuard()
+	if debugLog != nil {
+		logMsg(msg)
+	}
+	m, cmd := a.update(msg)
+	return m, guardCmd(cmd)
+}
+
+func (a App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.FocusMsg:
+		a.focusReported, a.focused = true, true
+		return a, nil
+
+	case tea.BlurMsg:
+		a.focusReported, a.focused = true, false
+		return a, nil
+
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
 		a.height = msg.Height
@@ -180,8 +664,15 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.commitView.SetSize(msg.Width, msg.Height)
 		a.helpView.SetSize(msg.Width, msg.Height)
 		a.branchPicker.SetSize(msg.Width, msg.Height)
+		a.reflogView.SetSize(msg.Width, msg.Height)
+		a.confirmPreview.SetSize(msg.Width, msg.Height)
 		a.featureLinker.SetSize(msg.Width, msg.Height)
 		a.projectManager.SetSize(msg.Width, msg.Height)
+		a.setup.SetSize(msg.Width, msg.Height)
+		return a, nil
+
+	case shared.SetupScannedMsg:
+		a.setup.SetRepos(msg.Repos)
 		return a, nil
 
 	case shared.LoaderStartMsg:
@@ -231,6 +722,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case shared.StatusRefreshedMsg:
 		a.dashboard.SetRepos(msg.Repos)
+		a.lastRefresh = time.Now()
 		// Auto-clear legacy status messages after 4s
 		if a.statusMsg != "" && time.Since(a.statusTime) > 4*time.Second {
 			a.statusMsg = ""
@@ -244,42 +736,219 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, a.maybeRefreshGraph()
 
-	case shared.FileStageToggledMsg, shared.AllStagedMsg, shared.AllUnstagedMsg:
+	case watchStartedMsg:
+		if msg.err != nil {
+			a.setFeedback(shared.FeedbackWarning, "File watching unavailable, polling instead", msg.err.Error(), "")
+			return a, nil
+		}
+		a.watcher = msg.watcher
+		return a, waitForChangesCmd(a.watcher)
+
+	case reposChangedMsg:
+		return a, tea.Batch(refreshReposCmd(a.cfg, msg), waitForChangesCmd(a.watcher))
+
+	case reposRefreshedMsg:
+		a.dashboard.UpdateRepos(msg)
+		a.lastRefresh = time.Now()
+		return a, a.maybeRefreshGraph()
+
+	case shared.FileStageToggledMsg:
+		if msg.Err != nil {
+			return a, a.stageFailed(msg.Staged, msg.Err)
+		}
+		a.lastStage = &stageUndo{repoPath: msg.RepoPath, paths: []string{msg.Path}, staged: msg.Staged}
+		return a, refreshAllStatus(a.cfg)
+
+	case shared.HunkStagedMsg:
+		// A hunk staged to commit is re-fetched once the prompt is done, as
+		// a fetched diff switches back to the diff view
+		_, file, entry, mode := a.diffView.Source()
+		var cmds []tea.Cmd
+		if a.activeView == DiffView && file == msg.File && (!msg.Commit || msg.Err != nil) {
+			cmds = append(cmds, a.fetchDiffCmd(msg.RepoPath, file, entry, mode))
+		}
+		switch {
+		case errors.Is(msg.Err, git.ErrOthersStaged):
+			a.setFeedback(shared.FeedbackWarning, "Can't commit the hunk on its own: "+msg.Err.Error(), "", "")
+			return a, nil
+		case msg.Err != nil:
+			a.setFeedback(shared.FeedbackError, "Hunk staging failed", msg.Err.Error(), "")
+			return a, tea.Batch(cmds...)
+		}
+		a.lastStage = nil // undo works on whole files, not hunks
+		cmds = append(cmds, refreshAllStatus(a.cfg))
+		switch {
+		case msg.Unstaged:
+			a.setStatus("Hunk unstaged")
+		case !msg.Commit:
+			a.setStatus("Hunk staged")
+		}
+		if msg.Commit {
+			what := fmt.Sprintf("1 hunk of %s (+%d -%d)", msg.File, msg.Hunk.Added, msg.Hunk.Removed)
+			a.hunkCommit = &hunkCommit{repoPath: msg.RepoPath, hunk: msg.Hunk, what: what}
+			name, branch := filepath.Base(msg.RepoPath), ""
+			if repo, ok := a.dashboard.SelectedRepo(); ok && repo.Path == msg.RepoPath {
+				name, branch = repo.Name, repo.BranchLabel()
+			}
+			a.quickCommit.OpenFor(msg.RepoPath, name, branch, what)
+			a.activeView = QuickCommitView
+		}
+		return a, tea.Batch(cmds...)
+
+	case shared.AllStagedMsg:
+		if msg.Err != nil {
+			return a, a.stageFailed(true, msg.Err)
+		}
+		a.lastStage = &stageUndo{repoPath: msg.RepoPath, paths: msg.Paths, staged: true}
+		return a, refreshAllStatus(a.cfg)
+
+	case shared.AllUnstagedMsg:
+		if msg.Err != nil {
+			return a, a.stageFailed(false, msg.Err)
+		}
+		a.lastStage = &stageUndo{repoPath: msg.RepoPath, paths: msg.Paths, staged: false}
+		return a, refreshAllStatus(a.cfg)
+
+	case shared.StageUndoneMsg:
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackError, "Undo failed", msg.Err.Error(), "")
+		} else {
+			a.setFeedback(shared.FeedbackSuccess, msg.Summary, "", "")
+		}
+		return a, refreshAllStatus(a.cfg)
+
+	case shared.ConflictResolvedMsg:
+		side := "ours"
+		if msg.Theirs {
+			side = "theirs"
+		}
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackError, "Could not take "+side+" for "+msg.Path, msg.Err.Error(), "")
+		} else {
+			a.setFeedback(shared.FeedbackSuccess, "Resolved "+msg.Path+" with "+side, "", "")
+		}
+		return a, refreshAllStatus(a.cfg)
+
+	case shared.OperationStepMsg:
+		op := "Merge"
+		if msg.Op == "rebasing" {
+			op = "Rebase"
+		}
+		switch {
+		case msg.Err != nil && msg.Continue:
+			a.setFeedback(shared.FeedbackError, op+" could not continue", msg.Err.Error(), "")
+		case msg.Err != nil:
+			a.setFeedback(shared.FeedbackError, op+" abort failed", msg.Err.Error(), "")
+		case !msg.Continue:
+			a.setFeedback(shared.FeedbackSuccess, op+" aborted", "", "")
+		case msg.Stopped:
+			a.setFeedback(shared.FeedbackWarning, op+" stopped at the next conflict", "", "")
+		default:
+			a.setFeedback(shared.FeedbackSuccess, op+" complete", "", "")
+		}
+		a.graphRepo = "" // force graph refresh
+		return a, refreshAllStatus(a.cfg)
+
+	case shared.FixupCommittedMsg:
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackError, "Fixup failed: "+msg.Err.Error(), msg.Err.Error(), "")
+			return a, nil
+		}
+		a.lastStage = nil
+		a.setFeedback(shared.FeedbackSuccess, "Created fixup for "+msg.Target+" — S in the graph autosquashes it", "", "")
+		a.graphRepo = "" // force graph refresh
+		return a, refreshAllStatus(a.cfg)
+
+	case shared.AutosquashCompleteMsg:
+		switch {
+		case msg.Stopped:
+			a.setFeedback(shared.FeedbackWarning, "Autosquash stopped on a conflict — resolve and stage, then n to continue or a to abort", msg.Err.Error(), "")
+		case msg.Err != nil:
+			a.setFeedback(shared.FeedbackError, "Autosquash failed: "+msg.Err.Error(), msg.Err.Error(), "")
+		default:
+			a.setFeedback(shared.FeedbackSuccess, "Autosquash complete", "", "")
+		}
+		a.graphRepo = "" // force graph refresh
 		return a, refreshAllStatus(a.cfg)
 
 	case shared.DiffFetchedMsg:
+		if !a.fetches.current(fetchDiff, msg.Gen) {
+			return a, nil
+		}
 		if msg.Err != nil {
 			a.setStatus("Error: " + msg.Err.Error())
 			return a, nil
 		}
-		a.activeView = DiffView
-		a.diffView.SetSize(a.width, a.height)
-		item, _ := a.dashboard.SelectedItem()
-		a.diffView.SetContent(msg.Content, item.File.Path, item.Repo.Path)
+		if a.activeView != DiffView {
+			a.activeView = DiffView
+			a.diffView.SetSize(a.width, a.height)
+		}
+		a.diffView.SetContent(msg.Content, msg.File, msg.RepoPath, msg.Entry, msg.Mode)
 		return a, nil
 
 	case shared.CommitCompleteMsg:
+		hc := a.hunkCommit
+		a.hunkCommit = nil
 		if msg.Err != nil {
+			failed := "Commit failed"
+			if errors.Is(msg.Err, git.ErrSigning) {
+				// Say how to fix it rather than just that it failed
+				failed = "Not committed, " + msg.Err.Error()
+			}
+			if hc != nil {
+				a.setFeedback(shared.FeedbackError, failed+"; the hunk is still staged", msg.Err.Error(), "")
+				return a, nil
+			}
+			if msg.Quick {
+				a.setFeedback(shared.FeedbackError, failed, msg.Err.Error(), "")
+				return a, nil
+			}
 			a.commitView.SetError(msg.Err)
 			return a, nil
 		}
+		a.lastStage = nil // the index was committed; nothing to undo
+		cmds := []tea.Cmd{refreshAllStatus(a.cfg)}
+		if hc != nil {
+			// Back to the diff, minus the committed hunk
+			a.setFeedback(shared.FeedbackSuccess, "Committed "+msg.Hash+": "+hc.what, "", "")
+			repoPath, file, entry, mode := a.diffView.Source()
+			cmds = append(cmds, a.fetchDiffCmd(repoPath, file, entry, mode))
+			return a, tea.Batch(cmds...)
+		}
 		a.activeView = DashboardView
 		a.setFeedback(shared.FeedbackSuccess, "Committed successfully", "", "")
-		cmds := []tea.Cmd{refreshAllStatus(a.cfg)}
 		// Try to match commit to conductor feature using project-aware path
 		if repo, ok := a.dashboard.SelectedRepo(); ok {
-			commitMsg := a.commitView.Value()
 			conductorPath := a.conductorPathForActiveProject(repo.Path)
-			cmds = append(cmds, matchFeaturesCmd(conductorPath, msg.Hash, commitMsg, nil))
+			cmds = append(cmds, matchFeaturesCmd(conductorPath, msg.Hash, msg.Message, nil))
 		}
 		return a, tea.Batch(cmds...)
 
+	case shared.IssuesFetchedMsg:
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackWarning, "Issue autocomplete unavailable", msg.Err.Error(), "")
+			return a, nil
+		}
+		if repo, ok := a.dashboard.SelectedRepo(); ok && repo.Path == msg.RepoPath {
+			a.commitView.SetIssues(msg.Issues)
+		}
+		return a, nil
+
 	case shared.CommitContextFetchedMsg:
 		if msg.Err == nil {
 			a.commitView.SetContextData(msg.StagedStats, msg.RecentCommits, msg.FeatureSuggestions)
 		}
+		if msg.TemplateErr != nil {
+			a.setFeedback(shared.FeedbackWarning, "Commit template not loaded: "+msg.TemplateErr.Error(), msg.TemplateErr.Error(), "")
+		} else {
+			a.commitView.ApplyTemplate(msg.Template)
+		}
 		return a, nil
 
+	case aiChunkMsg:
+		a.commitView.AppendAIChunk(msg.text)
+		return a, waitForAIStreamCmd(msg.stream)
+
 	case shared.AICommitMsgMsg:
 		a.stopLoader(shared.OpGenerate)
 		a.commitView.SetGenerating(false)
@@ -295,7 +964,77 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.setFeedback(shared.FeedbackError, "Undo failed: "+msg.Err.Error(), msg.Err.Error(), "")
 			return a, nil
 		}
-		a.setFeedback(shared.FeedbackSuccess, "Undid commit "+msg.Hash+", changes staged", "", "")
+		a.setFeedback(shared.FeedbackSuccess, "Undid commit "+msg.Hash+" (soft reset to its parent); its changes are staged", "", "")
+		return a, refreshAllStatus(a.cfg)
+
+	case shared.AmendCompleteMsg:
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackError, "Amend failed: "+msg.Err.Error(), msg.Err.Error(), "")
+			return a, nil
+		}
+		a.lastStage = nil
+		a.setFeedback(shared.FeedbackSuccess, "Amended last commit → "+msg.Hash, "", "")
+		a.graphRepo = "" // force graph refresh
+		return a, refreshAllStatus(a.cfg)
+
+	case shared.SquashPreparedMsg:
+		if a.activeView != SquashView || msg.RepoPath != a.squash.RepoPath() {
+			return a, nil
+		}
+		if msg.Err != nil {
+			a.squash.SetError(msg.Err)
+			return a, nil
+		}
+		a.squash.SetSubjects(msg.N, msg.Subjects, msg.Pushed)
+		return a, nil
+
+	case shared.AmendPreparedMsg:
+		if a.activeView != DashboardView {
+			return a, nil
+		}
+		if msg.NoCommits {
+			a.setFeedback(shared.FeedbackWarning, "Nothing to amend: "+msg.Name+" has no commits yet", "", "")
+			return a, nil
+		}
+		a.askConfirm("Amend last commit in "+msg.Name+" (rewrites history)?", amendNoEditCmd(msg.RepoPath, a.commitView.Sign()))
+		return a, nil
+
+	case shared.UndoCommitPreparedMsg:
+		if a.activeView != DashboardView {
+			return a, nil
+		}
+		switch {
+		case msg.NoCommits:
+			a.setFeedback(shared.FeedbackWarning, "Nothing to undo: "+msg.Name+" has no commits yet", "", "")
+			return a, nil
+		case msg.Root:
+			a.setFeedback(shared.FeedbackWarning, "Can't undo the first commit in "+msg.Name+": it has no parent to reset to", "", "")
+			return a, nil
+		}
+		// The subject gets what the status bar has left after the rest of
+		// the prompt
+		subject := msg.Subject
+		maxSubject := max(a.width-80-len(msg.Name), 20)
+		if r := []rune(subject); len(r) > maxSubject {
+			subject = string(r[:maxSubject-1]) + "…"
+		}
+		prompt := fmt.Sprintf("Undo commit %s %q in %s? Its changes stay staged", msg.Hash, subject, msg.Name)
+		if msg.Pushed {
+			prompt = fmt.Sprintf("Undo commit %s %q in %s? It's already pushed; its changes stay staged", msg.Hash, subject, msg.Name)
+		}
+		repoPath := msg.RepoPath
+		return a, a.askConfirmPreview("Undo Commit", prompt, undoCommitCmd(repoPath), func() (string, error) {
+			out, err := git.CommitStat(repoPath, "HEAD")
+			return previewSection("Commit taken off the branch (its changes stay staged):", "", out, err)
+		})
+
+	case shared.SquashCompleteMsg:
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackError, "Squash failed: "+msg.Err.Error(), msg.Err.Error(), "")
+			return a, nil
+		}
+		a.setFeedback(shared.FeedbackSuccess, fmt.Sprintf("Squashed %d commits → %s", msg.N, msg.Hash), "", "")
+		a.graphRepo = "" // force graph refresh
 		return a, refreshAllStatus(a.cfg)
 
 	case shared.PushCompleteMsg:
@@ -306,17 +1045,63 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if msg.Err != nil {
 			a.setFeedback(shared.FeedbackError, "Push failed: "+msg.Err.Error(), msg.Err.Error(), shared.OpPush)
-			return a, nil
+			return a, a.notifyCmd("Push failed", msg.Err.Error())
 		}
 		a.setFeedback(shared.FeedbackSuccess, "Pushed "+msg.Branch+" to origin", "", shared.OpPush)
-		return a, refreshAllStatus(a.cfg)
+		return a, tea.Batch(refreshAllStatus(a.cfg), a.notifyCmd("Push complete", "Pushed "+msg.Branch+" to origin"))
 
-	case shared.ContextSummaryCopiedMsg:
+	case shared.LaunchedMsg:
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackError, "Could not open "+msg.What, msg.Err.Error(), "")
+		} else {
+			a.setFeedback(shared.FeedbackInfo, "Opened "+msg.What+" in "+filepath.Base(msg.Dir), "", "")
+		}
+		return a, nil
+
+	case shared.PathCopiedMsg:
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackError, "Copy failed", msg.Err.Error(), "")
+		} else {
+			a.setFeedback(shared.FeedbackSuccess, "Copied "+msg.Path, "", "")
+		}
+		return a, nil
+
+	case shared.ContextSummaryCopiedMsg:
 		a.stopLoader(shared.OpExport)
 		if msg.Err != nil {
 			a.setFeedback(shared.FeedbackError, "Export failed: "+msg.Err.Error(), msg.Err.Error(), shared.OpExport)
 		} else {
-			a.setFeedback(shared.FeedbackSuccess, fmt.Sprintf("Context copied to clipboard (%d commits across %d repos)", msg.NumCommits, msg.NumRepos), "", shared.OpExport)
+			dest := "copied to clipboard"
+			if msg.Path != "" {
+				dest = "written to " + msg.Path
+			}
+			scope := ""
+			if msg.Scope != "" {
+				scope = " in " + msg.Scope
+			}
+			a.setFeedback(shared.FeedbackSuccess, fmt.Sprintf("Context %s (%d commits across %d repos%s, %s)", dest, msg.NumCommits, msg.NumRepos, scope, daysLabel(msg.Days)), "", shared.OpExport)
+		}
+		return a, nil
+
+	case shared.ActivityExportedMsg:
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackError, "Export failed: "+msg.Err.Error(), msg.Err.Error(), "")
+			return a, nil
+		}
+		dest := "copied to clipboard"
+		if msg.Path != "" {
+			dest = "written to " + msg.Path
+		}
+		a.setFeedback(shared.FeedbackSuccess, fmt.Sprintf("%s activity %s (%d commits, %s)", msg.Repo, dest, msg.NumCommits, daysLabel(msg.Days)), "", "")
+		return a, nil
+
+	case shared.ConductorExportedMsg:
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackError, "Export failed: "+msg.Err.Error(), msg.Err.Error(), "")
+		} else if msg.Path != "" {
+			a.setFeedback(shared.FeedbackSuccess, "Conductor status written to "+msg.Path, "", "")
+		} else {
+			a.setFeedback(shared.FeedbackSuccess, "Conductor status copied to clipboard", "", "")
 		}
 		return a, nil
 
@@ -374,10 +1159,23 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
-	case nvim.EditorFinishedMsg:
+	case editor.EditorFinishedMsg:
+		return a, refreshAllStatus(a.cfg)
+
+	case editor.RebaseFinishedMsg:
+		switch {
+		case msg.Stopped:
+			a.setFeedback(shared.FeedbackWarning, "Rebase stopped — resolve and stage, then n to continue or a to abort", "", "")
+		case msg.Err != nil:
+			a.setFeedback(shared.FeedbackError, "Rebase failed: "+msg.Err.Error(), msg.Err.Error(), "")
+		default:
+			a.setFeedback(shared.FeedbackSuccess, "Rebase complete", "", "")
+		}
+		a.graphRepo = "" // force graph refresh
 		return a, refreshAllStatus(a.cfg)
 
 	case shared.CloseDiffMsg:
+		a.fetches.stop(fetchDiff) // a reload may still be running
 		a.activeView = DashboardView
 		return a, refreshAllStatus(a.cfg)
 
@@ -386,12 +1184,49 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	case shared.GraphFetchedMsg:
+		if !a.fetches.current(fetchGraph, msg.Gen) {
+			return a, nil
+		}
 		if msg.Err == nil {
 			a.graphPane.SetGraph(msg.Lines, msg.RepoPath)
+			if hash := a.pendingHash; hash != "" && msg.RepoPath == a.deepGraphRepo {
+				a.pendingHash = ""
+				if hashes := a.graphPane.FindHash(hash); len(hashes) == 1 {
+					return a, a.selectGraphHash(hashes[0])
+				}
+			}
+		}
+		return a, nil
+
+	case shared.ConfirmPreviewMsg:
+		if a.activeView == ConfirmPreviewView {
+			a.confirmPreview.SetPreview(msg.Seq, msg.Preview, msg.Err)
+		}
+		return a, nil
+
+	case shared.CommitResolvedMsg:
+		if msg.RepoPath != a.graphPane.RepoPath() {
+			return a, nil
+		}
+		switch {
+		case msg.Err != nil:
+			a.setFeedback(shared.FeedbackWarning, "Commit not found: "+msg.Rev, msg.Err.Error(), "")
+		case msg.Pos < 0:
+			a.setFeedback(shared.FeedbackWarning, msg.Rev+" isn't on any branch or tag", "", "")
+		case msg.Pos >= maxGraphJump:
+			a.setFeedback(shared.FeedbackWarning, fmt.Sprintf("%s is %d commits back, too far to load in the graph", msg.Rev, msg.Pos), "", "")
+		default:
+			a.deepGraphRepo = msg.RepoPath
+			a.deepGraphDepth = msg.Pos + a.cfg.ResolvedGraphMaxCommits()
+			a.pendingHash = msg.Hash
+			return a, a.fetchGraphCmd(msg.RepoPath, a.graphDepth(msg.RepoPath))
 		}
 		return a, nil
 
 	case shared.CommitDetailFetchedMsg:
+		if !a.fetches.current(fetchDetail, msg.Gen) {
+			return a, nil
+		}
 		if msg.Err == nil {
 			a.graphPane.SetCommitDetail(msg.Detail)
 			a.lastDetailHash = msg.Hash
@@ -421,6 +1256,89 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.activeView = BranchPickerView
 		return a, nil
 
+	case shared.CommitExplainedMsg:
+		a.graphPane.SetSummary(msg.Hash, msg.Summary, msg.Err)
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackWarning, "Could not summarize "+msg.Hash, msg.Err.Error(), "")
+		}
+		return a, nil
+
+	case shared.PatchAppliedMsg:
+		name := filepath.Base(msg.RepoPath)
+		switch {
+		case msg.Err != nil:
+			a.setFeedback(shared.FeedbackError, "Applying the patch from "+msg.Source+" failed", msg.Err.Error(), "")
+			return a, nil
+		case msg.Rejected != nil:
+			prompt := "Patch from " + msg.Source + " doesn't apply cleanly"
+			if len(msg.Rejected) > 0 {
+				prompt += " (" + strings.Join(msg.Rejected, ", ") + ")"
+			}
+			a.askConfirm(prompt+". Try a three-way merge (-3)?", threeWayApplyCmd(msg.RepoPath, msg.Source, msg.Patch))
+			return a, nil
+		case len(msg.Conflicts) > 0:
+			a.setFeedback(shared.FeedbackWarning, fmt.Sprintf("Applied to %s with conflicts in %s; resolve them, then stage", name, strings.Join(msg.Conflicts, ", ")), "", "")
+		default:
+			noun := "files"
+			if msg.Files == 1 {
+				noun = "file"
+			}
+			how := ""
+			if msg.ThreeWay {
+				how = " with a three-way merge"
+			}
+			a.setFeedback(shared.FeedbackSuccess, fmt.Sprintf("Applied the patch from %s to %s%s (%d %s)", msg.Source, name, how, msg.Files, noun), "", "")
+		}
+		return a, refreshAllStatus(a.cfg)
+
+	case shared.PatchCopiedMsg:
+		switch {
+		case errors.Is(msg.Err, git.ErrMergeCommit):
+			a.setFeedback(shared.FeedbackWarning, msg.Hash+" is a merge: "+msg.Err.Error(), "", "")
+		case msg.Err != nil:
+			a.setFeedback(shared.FeedbackError, "Copying the patch failed", msg.Err.Error(), "")
+		default:
+			a.setFeedback(shared.FeedbackSuccess, fmt.Sprintf("Copied the patch of %s (%s)", msg.Hash, sizeLabel(msg.Bytes)), "", "")
+		}
+		return a, nil
+
+	case shared.BranchNameSuggestedMsg:
+		if msg.RepoPath == a.branchPicker.RepoPath() {
+			a.branchPicker.SetSuggestedName(msg.Name, msg.Err)
+		}
+		return a, nil
+
+	case shared.MergedBranchesMsg:
+		if a.activeView != BranchPickerView || msg.RepoPath != a.branchPicker.RepoPath() {
+			return a, nil
+		}
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackError, "Could not list merged branches", msg.Err.Error(), "")
+			a.activeView = DashboardView
+			return a, nil
+		}
+		if len(msg.Branches) == 0 {
+			a.setFeedback(shared.FeedbackInfo, "No merged branches to prune", "", "")
+			a.activeView = DashboardView
+			return a, nil
+		}
+		a.branchPicker.SetMerged(msg.Branches)
+		return a, nil
+
+	case shared.BranchesDeletedMsg:
+		a.activeView = DashboardView
+		noun := "branches"
+		if len(msg.Deleted) == 1 {
+			noun = "branch"
+		}
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackError, fmt.Sprintf("Deleted %d merged %s, but some failed", len(msg.Deleted), noun), msg.Err.Error(), "")
+		} else {
+			a.setFeedback(shared.FeedbackSuccess, fmt.Sprintf("Deleted %d merged %s", len(msg.Deleted), noun), strings.Join(msg.Deleted, ", "), "")
+		}
+		a.graphRepo = "" // force graph refresh
+		return a, refreshAllStatus(a.cfg)
+
 	case shared.BranchSwitchedMsg:
 		if msg.Err != nil {
 			a.setStatus("Error: " + msg.Err.Error())
@@ -441,10 +1359,76 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.graphRepo = "" // force graph refresh
 		return a, refreshAllStatus(a.cfg)
 
+	case shared.CommitCheckedOutMsg:
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackError, "Checkout failed", msg.Err.Error(), "")
+		} else {
+			a.setFeedback(shared.FeedbackWarning, "HEAD detached at "+msg.Hash, "", "")
+		}
+		a.graphRepo = "" // force graph refresh
+		return a, refreshAllStatus(a.cfg)
+
 	case shared.CloseBranchPickerMsg:
 		a.activeView = DashboardView
 		return a, nil
 
+	case shared.IncomingFetchedMsg:
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackWarning, "No upstream to compare with", msg.Err.Error(), "")
+			return a, nil
+		}
+		if !a.showGraph {
+			a.showGraph = true
+			a.layoutSizes()
+			a.savePanes()
+		}
+		a.graphPane.SetIncoming(msg.Commits, msg.Upstream, msg.RepoPath)
+		a.graphFocused = true
+		a.focusPanel = FocusGraph
+		if a.graphRepo != msg.RepoPath {
+			// Load the graph underneath so esc returns to the right repo
+			return a, a.maybeRefreshGraph()
+		}
+		return a, nil
+
+	case shared.TagsFetchedMsg:
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackError, "Could not list tags", msg.Err.Error(), "")
+			return a, nil
+		}
+		a.changelogMenu.Open(msg.RepoPath, msg.Tags, filepath.Join(msg.RepoPath, "CHANGELOG.md"))
+		a.activeView = ChangelogView
+		return a, nil
+
+	case shared.ChangelogBuiltMsg:
+		switch {
+		case msg.Err != nil:
+			a.setFeedback(shared.FeedbackError, "Changelog failed: "+msg.Err.Error(), msg.Err.Error(), "")
+		case msg.Path != "":
+			a.setFeedback(shared.FeedbackSuccess, fmt.Sprintf("Changelog (%d commits) added to %s", msg.Count, msg.Path), "", "")
+		default:
+			a.setFeedback(shared.FeedbackSuccess, fmt.Sprintf("Changelog (%d commits) copied to clipboard", msg.Count), "", "")
+		}
+		return a, nil
+
+	case shared.ReflogFetchedMsg:
+		if msg.Err != nil {
+			a.setStatus("Error: " + msg.Err.Error())
+			return a, nil
+		}
+		a.reflogView.SetEntries(msg.Entries, msg.RepoPath)
+		a.activeView = ReflogView
+		return a, nil
+
+	case shared.ReflogRecoveredMsg:
+		if msg.Err != nil {
+			a.setFeedback(shared.FeedbackError, msg.Summary+" failed", msg.Err.Error(), "")
+		} else {
+			a.setFeedback(shared.FeedbackSuccess, msg.Summary, "", "")
+		}
+		a.graphRepo = "" // force graph refresh
+		return a, refreshAllStatus(a.cfg)
+
 	case pollTickMsg:
 		// Auto-clear feedback based on TTL (runs on every poll, even outside dashboard)
 		if a.feedback != nil && a.feedback.Level != shared.FeedbackFatal {
@@ -458,8 +1442,8 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.statusMsg = ""
 		}
 		// Only auto-refresh on the dashboard view to avoid disrupting other views
-		if a.activeView == DashboardView || a.activeView == BranchPickerView {
-			cmds := []tea.Cmd{refreshAllStatus(a.cfg), pollTickCmd()}
+		if a.activeView == DashboardView || a.activeView == BranchPickerView || a.activeView == ThemePickerView || a.activeView == SettingsView || a.activeView == ConfirmPreviewView {
+			cmds := []tea.Cmd{a.pollStatusCmd(), pollTickCmd()}
 			// Refresh conductor data on the same tick (project-aware)
 			if a.conductorRepo != "" {
 				cmds = append(cmds, refreshConductorCmd(a.conductorRepo))
@@ -473,6 +1457,9 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		return a.handleKey(msg)
+
+	case tea.MouseMsg:
+		return a.handleMouse(msg)
 	}
 
 	// Route updates to active view
@@ -489,10 +1476,34 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var cmd tea.Cmd
 		a.branchPicker, cmd = a.branchPicker.Update(msg)
 		return a, cmd
+	case ReflogView:
+		var cmd tea.Cmd
+		a.reflogView, cmd = a.reflogView.Update(msg)
+		return a, cmd
+	case QuickCommitView:
+		var cmd tea.Cmd
+		a.quickCommit, cmd = a.quickCommit.Update(msg)
+		return a, cmd
+	case PatchPromptView:
+		var cmd tea.Cmd
+		a.patchPrompt, cmd = a.patchPrompt.Update(msg)
+		return a, cmd
+	case HashPromptView:
+		var cmd tea.Cmd
+		a.hashPrompt, cmd = a.hashPrompt.Update(msg)
+		return a, cmd
+	case SquashView:
+		var cmd tea.Cmd
+		a.squash, cmd = a.squash.Update(msg)
+		return a, cmd
 	case ProjectManagerView:
 		var cmd tea.Cmd
 		a.projectManager, cmd = a.projectManager.Update(msg)
 		return a, cmd
+	case SetupView:
+		var cmd tea.Cmd
+		a.setup, cmd = a.setup.Update(msg)
+		return a, cmd
 	}
 
 	return a, nil
@@ -505,15 +1516,31 @@ func (a App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 	}
 
-	// Help toggle is global
-	if key.Matches(msg, shared.Keys.Help) {
+	// Pending confirmation: y runs the action, any other key cancels
+	if a.confirm != nil {
+		c := a.confirm
+		a.confirm = nil
+		if msg.String() == "y" {
+			return a, c.cmd
+		}
+		a.setFeedback(shared.FeedbackInfo, "Cancelled", "", "")
+		return a, nil
+	}
+
+	// Help toggle is global, except while typing a palette filter
+	if key.Matches(msg, shared.Keys.Help) && a.activeView != PaletteView {
 		a.showHelp = !a.showHelp
+		if a.showHelp {
+			a.helpView.Open(a.helpContext())
+		}
 		return a, nil
 	}
 
-	// If help is shown, any key closes it
+	// While help is shown, keys search it
 	if a.showHelp {
-		a.showHelp = false
+		if a.helpView.HandleKey(msg).Action == help.ActionClose {
+			a.showHelp = false
+		}
 		return a, nil
 	}
 
@@ -528,6 +1555,34 @@ func (a App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a.handleBranchPickerKey(msg)
 	case ProjectManagerView:
 		return a.handleProjectManagerKey(msg)
+	case SetupView:
+		return a.handleSetupKey(msg)
+	case ThemePickerView:
+		return a.handleThemePickerKey(msg)
+	case SettingsView:
+		return a.handleSettingsKey(msg)
+	case ReflogView:
+		return a.handleReflogKey(msg)
+	case QuickCommitView:
+		return a.handleQuickCommitKey(msg)
+	case SquashView:
+		return a.handleSquashKey(msg)
+	case ConductorExportView:
+		return a.handleConductorExportKey(msg)
+	case ChangelogView:
+		return a.handleChangelogKey(msg)
+	case PaletteView:
+		return a.handlePaletteKey(msg)
+	case PatchPromptView:
+		return a.handlePatchPromptKey(msg)
+	case RefPickerView:
+		return a.handleRefPickerKey(msg)
+	case HashPromptView:
+		return a.handleHashPromptKey(msg)
+	case ActivityView:
+		return a.handleActivityKey(msg)
+	case ConfirmPreviewView:
+		return a.handleConfirmPreviewKey(msg)
 	}
 
 	return a, nil
@@ -563,6 +1618,13 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 	}
 
+	// The command palette opens from any pane, offering what applies there
+	if key.Matches(msg, shared.Keys.Palette) {
+		a.palette.Open(a.paletteScope())
+		a.activeView = PaletteView
+		return a, nil
+	}
+
 	// When conductor is focused, route keys to conductor pane
 	if a.focusPanel == FocusConductor {
 		switch {
@@ -587,12 +1649,22 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			a.graphFocused = false
 			a.focusPanel = FocusDashboard
 			a.layoutSizes()
+			a.savePanes()
 			return a, nil
 		case key.Matches(msg, shared.Keys.ToggleConductor):
 			a.showConductor = false
 			a.focusPanel = FocusDashboard
 			a.graphFocused = false
 			a.layoutSizes()
+			a.savePanes()
+			return a, nil
+		case key.Matches(msg, shared.Keys.ConductorExport):
+			if a.conductorData[a.conductorRepo] == nil {
+				a.setFeedback(shared.FeedbackWarning, "No conductor data to export", "", "")
+				return a, nil
+			}
+			a.exportMenu.Open(a.conductorExportPath())
+			a.activeView = ConductorExportView
 			return a, nil
 		default:
 			var cmd tea.Cmd
@@ -604,6 +1676,9 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// When graph is focused, route keys to the graph pane
 	if a.graphFocused || a.focusPanel == FocusGraph {
 		switch {
+		case key.Matches(msg, shared.Keys.Escape) && a.graphPane.ShowingIncoming():
+			a.graphPane.ClearIncoming()
+			return a, nil
 		case key.Matches(msg, shared.Keys.FocusLeft), key.Matches(msg, shared.Keys.Escape):
 			a.graphFocused = false
 			a.focusPanel = FocusDashboard
@@ -622,15 +1697,81 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			a.graphFocused = false
 			a.focusPanel = FocusDashboard
 			a.layoutSizes()
+			a.savePanes()
 			return a, nil
 		case key.Matches(msg, shared.Keys.ToggleConductor):
 			a.showConductor = !a.showConductor
 			a.layoutSizes()
+			a.savePanes()
 			if a.showConductor {
 				a.conductorRepo = ""
 				return a, a.maybeRefreshConductor()
 			}
 			return a, nil
+		case key.Matches(msg, shared.Keys.BranchAtCommit) && a.graphPane.ActiveSection() == graphpane.GraphSection && !a.graphPane.ShowingIncoming():
+			hash := a.graphPane.SelectedHash()
+			if hash == "" {
+				return a, nil
+			}
+			a.branchPicker.StartCreateAt(a.graphPane.RepoPath(), hash)
+			a.activeView = BranchPickerView
+			return a, nil
+		case key.Matches(msg, shared.Keys.CheckoutCommit) && a.graphPane.ActiveSection() == graphpane.GraphSection && !a.graphPane.ShowingIncoming():
+			hash := a.graphPane.SelectedHash()
+			if hash == "" {
+				return a, nil
+			}
+			a.askConfirm("Checkout "+hash+" (detached HEAD)?", checkoutCommitCmd(a.graphPane.RepoPath(), hash))
+			return a, nil
+		case key.Matches(msg, shared.Keys.FixupCommit) && a.graphPane.ActiveSection() == graphpane.GraphSection && !a.graphPane.ShowingIncoming():
+			hash := a.graphPane.SelectedHash()
+			if hash == "" {
+				return a, nil
+			}
+			item, ok := a.dashboard.SelectedItem()
+			if !ok || item.Repo == nil || item.Repo.Path != a.graphPane.RepoPath() || !a.dashboard.RepoHasStagedFiles(item.RepoIndex) {
+				a.setStatus("No staged files for a fixup")
+				return a, nil
+			}
+			return a, fixupCommitCmd(a.graphPane.RepoPath(), hash, a.commitView.Sign())
+		case key.Matches(msg, shared.Keys.Autosquash) && a.graphPane.ActiveSection() == graphpane.GraphSection && !a.graphPane.ShowingIncoming():
+			hash := a.graphPane.SelectedHash()
+			if hash == "" {
+				return a, nil
+			}
+			repoPath := a.graphPane.RepoPath()
+			return a, a.askConfirmPreview("Autosquash", "Autosquash fixups from "+hash+" onwards? This rewrites history",
+				autosquashCmd(repoPath, hash), func() (string, error) {
+					out, err := git.LogOneline(repoPath, "HEAD", "--not", hash+"^@")
+					return previewSection("Commits that will be rewritten:", "No commits to rewrite", out, err)
+				})
+		case key.Matches(msg, shared.Keys.RebaseEdit) && a.graphPane.ActiveSection() == graphpane.GraphSection && !a.graphPane.ShowingIncoming():
+			hash := a.graphPane.SelectedHash()
+			if hash == "" {
+				return a, nil
+			}
+			return a, editor.Rebase(a.graphPane.RepoPath(), hash)
+		case key.Matches(msg, shared.Keys.ExplainCommit) && a.graphPane.ActiveSection() == graphpane.GraphSection && !a.graphPane.ShowingIncoming():
+			hash := a.graphPane.SelectedHash()
+			if hash == "" || !a.graphPane.StartSummary(hash) {
+				// Already summarized (or in progress); the cached one is shown
+				return a, nil
+			}
+			return a, explainCommitCmd(a.graphPane.RepoPath(), hash)
+		case key.Matches(msg, shared.Keys.CopyPatch) && a.graphPane.ActiveSection() == graphpane.GraphSection && !a.graphPane.ShowingIncoming():
+			hash := a.graphPane.SelectedHash()
+			if hash == "" {
+				return a, nil
+			}
+			return a, copyPatchCmd(a.graphPane.RepoPath(), hash)
+		case key.Matches(msg, shared.Keys.JumpToRef) && a.graphPane.ActiveSection() == graphpane.GraphSection && !a.graphPane.ShowingIncoming():
+			a.refPicker.Open(a.graphPane.Refs())
+			a.activeView = RefPickerView
+			return a, nil
+		case key.Matches(msg, shared.Keys.GoToHash) && a.graphPane.ActiveSection() == graphpane.GraphSection && !a.graphPane.ShowingIncoming():
+			a.hashPrompt.Open()
+			a.activeView = HashPromptView
+			return a, nil
 		default:
 			// Pass j/k/ctrl+j/ctrl+k/enter/pgup/pgdn etc. to graph pane
 			prevHash := a.graphPane.SelectedHash()
@@ -639,7 +1780,7 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// Auto-fetch commit detail when cursor moves to new commit
 			newHash := a.graphPane.SelectedHash()
 			if newHash != "" && newHash != prevHash && newHash != a.lastDetailHash {
-				detailCmd := fetchCommitDetailCmd(a.graphPane.RepoPath(), newHash)
+				detailCmd := a.fetchCommitDetailCmd(a.graphPane.RepoPath(), newHash)
 				if cmd != nil {
 					return a, tea.Batch(cmd, detailCmd)
 				}
@@ -650,7 +1791,7 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// All-projects mode: limited key set
-	if a.dashboard.ActiveProject() == -1 && len(a.cfg.Projects) > 0 {
+	if a.dashboard.ShowingProjects() {
 		switch {
 		case key.Matches(msg, shared.Keys.Quit):
 			return a, tea.Quit
@@ -663,11 +1804,17 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			a.dashboard.MoveUp()
 			return a, a.maybeRefreshGraph()
 
+		case shared.IsPageKey(msg):
+			a.dashboard.PageMove(msg)
+			return a, a.maybeRefreshGraph()
+
 		case key.Matches(msg, shared.Keys.Open):
 			a.dashboard.EnterProject()
+			a.syncState()
+			a.applyPanes()
 			a.graphRepo = ""     // force refresh
 			a.conductorRepo = "" // force refresh
-			return a, a.maybeRefreshGraph()
+			return a, tea.Batch(a.maybeRefreshGraph(), a.maybeRefreshConductor())
 
 		case key.Matches(msg, shared.Keys.FocusRight):
 			if a.showGraph {
@@ -681,6 +1828,7 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			a.graphFocused = false
 			a.focusPanel = FocusDashboard
 			a.layoutSizes()
+			a.savePanes()
 			if a.showGraph {
 				a.graphRepo = ""
 				a.conductorRepo = ""
@@ -696,6 +1844,7 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				a.graphFocused = false
 			}
 			a.layoutSizes()
+			a.savePanes()
 			if a.showConductor {
 				a.conductorRepo = ""
 				return a, a.maybeRefreshConductor()
@@ -704,7 +1853,39 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, shared.Keys.ContextSummary):
 			spinCmd := a.startLoader(shared.OpExport, "Exporting context")
-			return a, tea.Batch(spinCmd, exportContextCmd(a.cfg, 7))
+			return a, tea.Batch(spinCmd, exportContextCmd(a.cfg.AllRepos(), "", a.contextDays, ""))
+
+		case key.Matches(msg, shared.Keys.ContextToFile):
+			spinCmd := a.startLoader(shared.OpExport, "Exporting context")
+			return a, tea.Batch(spinCmd, exportContextCmd(a.cfg.AllRepos(), "", a.contextDays, a.cfg.ResolvedContextFile()))
+
+		case key.Matches(msg, shared.Keys.ProjectContext):
+			return a, a.exportProjectContext()
+
+		case key.Matches(msg, shared.Keys.ContextRange):
+			a.cycleContextDays()
+			return a, nil
+
+		case key.Matches(msg, shared.Keys.HideClean):
+			return a, a.toggleHideClean()
+
+		case key.Matches(msg, shared.Keys.ByStatus):
+			return a, a.toggleByStatus()
+
+		case key.Matches(msg, shared.Keys.CopyPath):
+			return a, a.copySelectedPath()
+
+		case key.Matches(msg, shared.Keys.Pin):
+			return a, a.togglePin()
+
+		case key.Matches(msg, shared.Keys.Theme):
+			a.openThemePicker()
+			return a, nil
+
+		case key.Matches(msg, shared.Keys.Settings):
+			a.settings.Open(a.cfg.Display)
+			a.activeView = SettingsView
+			return a, nil
 
 		case key.Matches(msg, shared.Keys.ProjectManager):
 			a.projectManager.SetSize(a.width, a.height)
@@ -722,12 +1903,17 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, tea.Quit
 
 	case key.Matches(msg, shared.Keys.Escape):
+		if a.dashboard.ByStatus() {
+			return a, a.toggleByStatus()
+		}
 		// If inside a project, go back to all-projects view
 		if a.dashboard.ActiveProject() >= 0 {
 			a.dashboard.ExitProject()
+			a.syncState()
+			a.applyPanes()
 			a.graphRepo = ""     // force refresh
 			a.conductorRepo = "" // force refresh
-			return a, a.maybeRefreshGraph()
+			return a, tea.Batch(a.maybeRefreshGraph(), a.maybeRefreshConductor())
 		}
 		return a, nil
 
@@ -743,6 +1929,7 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		a.graphFocused = false
 		a.focusPanel = FocusDashboard
 		a.layoutSizes()
+		a.savePanes()
 		if a.showGraph {
 			a.graphRepo = ""     // force refresh
 			a.conductorRepo = "" // force refresh
@@ -759,6 +1946,7 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			a.graphFocused = false
 		}
 		a.layoutSizes()
+		a.savePanes()
 		if a.showConductor {
 			a.conductorRepo = "" // force refresh
 			return a, a.maybeRefreshConductor()
@@ -766,25 +1954,80 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	case key.Matches(msg, shared.Keys.Push):
-		item, ok := a.dashboard.SelectedItem()
+		repo, ok := a.dashboard.SelectedRepo()
 		if !ok {
 			return a, nil
 		}
-		repo := item.Repo
+		item, _ := a.dashboard.SelectedItem()
+		if repo.Detached {
+			a.setFeedback(shared.FeedbackWarning, "Can't push a detached HEAD", "Create a branch first (c in the graph pane)", "")
+			return a, nil
+		}
 		a.pushingRepoIdx = item.RepoIndex
 		spinCmd := a.startLoader(shared.OpPush, "Pushing "+repo.Branch+" to origin")
 		return a, tea.Batch(spinCmd, pushCmd(repo.Path, repo.Branch))
 
+	case key.Matches(msg, shared.Keys.AmendNoEdit):
+		repo, ok := a.dashboard.SelectedRepo()
+		if !ok {
+			return a, nil
+		}
+		return a, prepareAmendCmd(repo.Path, repo.Name)
+
 	case key.Matches(msg, shared.Keys.UndoCommit):
 		repo, ok := a.dashboard.SelectedRepo()
 		if !ok {
 			return a, nil
 		}
-		return a, undoCommitCmd(repo.Path)
+		return a, prepareUndoCommitCmd(repo.Path, repo.Name)
 
 	case key.Matches(msg, shared.Keys.ContextSummary):
 		spinCmd := a.startLoader(shared.OpExport, "Exporting context")
-		return a, tea.Batch(spinCmd, exportContextCmd(a.cfg, 7))
+		return a, tea.Batch(spinCmd, exportContextCmd(a.cfg.AllRepos(), "", a.contextDays, ""))
+
+	case key.Matches(msg, shared.Keys.ContextToFile):
+		spinCmd := a.startLoader(shared.OpExport, "Exporting context")
+		return a, tea.Batch(spinCmd, exportContextCmd(a.cfg.AllRepos(), "", a.contextDays, a.cfg.ResolvedContextFile()))
+
+	case key.Matches(msg, shared.Keys.ProjectContext):
+		return a, a.exportProjectContext()
+
+	case key.Matches(msg, shared.Keys.ContextRange):
+		a.cycleContextDays()
+		return a, nil
+
+	case key.Matches(msg, shared.Keys.HideClean):
+		return a, a.toggleHideClean()
+
+	case key.Matches(msg, shared.Keys.StagingFilter):
+		f := a.dashboard.CycleStagingFilter()
+		a.setFeedback(shared.FeedbackInfo, "Showing "+f.String(), "", "")
+		return a, a.maybeRefreshGraph()
+
+	case key.Matches(msg, shared.Keys.ByStatus):
+		return a, a.toggleByStatus()
+
+	case key.Matches(msg, shared.Keys.Terminal), key.Matches(msg, shared.Keys.FileManager):
+		repo, ok := a.dashboard.SelectedRepo()
+		if !ok {
+			return a, nil
+		}
+		return a, launchCmd(repo.Path, key.Matches(msg, shared.Keys.Terminal), a.cfg.Display)
+
+	case key.Matches(msg, shared.Keys.CopyPath):
+		return a, a.copySelectedPath()
+
+	case key.Matches(msg, shared.Keys.Pin):
+		return a, a.togglePin()
+
+	case key.Matches(msg, shared.Keys.Theme):
+		a.openThemePicker()
+		return a, nil
+
+	case key.Matches(msg, shared.Keys.Settings):
+		a.settings.Open(a.cfg.Display)
+		a.activeView = SettingsView
+		return a, nil
 
 	case key.Matches(msg, shared.Keys.ProjectManager):
 		a.projectManager.SetSize(a.width, a.height)
@@ -799,6 +2042,45 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return a, fetchBranchesCmd(repo.Path)
 
+	case key.Matches(msg, shared.Keys.Incoming):
+		repo, ok := a.dashboard.SelectedRepo()
+		if !ok {
+			return a, nil
+		}
+		return a, fetchIncomingCmd(repo.Path)
+
+	case key.Matches(msg, shared.Keys.Reflog):
+		repo, ok := a.dashboard.SelectedRepo()
+		if !ok {
+			return a, nil
+		}
+		return a, fetchReflogCmd(repo.Path)
+
+	case key.Matches(msg, shared.Keys.Changelog):
+		repo, ok := a.dashboard.SelectedRepo()
+		if !ok {
+			return a, nil
+		}
+		return a, fetchTagsCmd(repo.Path)
+
+	case key.Matches(msg, shared.Keys.ApplyPatch):
+		repo, ok := a.dashboard.SelectedRepo()
+		if !ok {
+			return a, nil
+		}
+		a.patchPrompt.Open(repo.Path, repo.Name)
+		a.activeView = PatchPromptView
+		return a, nil
+
+	case key.Matches(msg, shared.Keys.RepoActivity):
+		repo, ok := a.dashboard.SelectedRepo()
+		if !ok {
+			return a, nil
+		}
+		a.activityMenu.Open(repo.Path, repo.Name, a.contextDays, a.activityExportPath(repo.Name))
+		a.activeView = ActivityView
+		return a, nil
+
 	case key.Matches(msg, shared.Keys.Down):
 		a.dashboard.MoveDown()
 		return a, a.maybeRefreshGraph()
@@ -807,6 +2089,18 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		a.dashboard.MoveUp()
 		return a, a.maybeRefreshGraph()
 
+	case key.Matches(msg, shared.Keys.Left):
+		a.dashboard.MoveLeft()
+		return a, a.maybeRefreshGraph()
+
+	case key.Matches(msg, shared.Keys.Right):
+		a.dashboard.MoveRight()
+		return a, a.maybeRefreshGraph()
+
+	case shared.IsPageKey(msg):
+		a.dashboard.PageMove(msg)
+		return a, a.maybeRefreshGraph()
+
 	case key.Matches(msg, shared.Keys.NextRepo):
 		a.dashboard.NextRepo()
 		return a, a.maybeRefreshGraph()
@@ -821,7 +2115,13 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 		if item.Kind == dashboard.RepoHeader {
-			return a, stageAllCmd(item.Repo.Path)
+			return a, a.bulkStage(item.Repo, true)
+		}
+		if item.Kind == dashboard.FolderHeader {
+			return a, a.folderStage(item, true)
+		}
+		if item.Kind == dashboard.UntrackedDir {
+			return a, stageFileCmd(item.Repo.Path, item.Dir+"/")
 		}
 		if item.Kind != dashboard.File {
 			return a, nil
@@ -834,47 +2134,126 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 		if item.Kind == dashboard.RepoHeader {
-			return a, unstageAllCmd(item.Repo.Path)
+			return a, a.bulkStage(item.Repo, false)
+		}
+		if item.Kind == dashboard.FolderHeader {
+			return a, a.folderStage(item, false)
 		}
 		if item.Kind != dashboard.File {
 			return a, nil
 		}
 		return a, unstageFileCmd(item.Repo.Path, item.File.Path)
 
-	case key.Matches(msg, shared.Keys.StageAll):
+	case key.Matches(msg, shared.Keys.TakeOurs), key.Matches(msg, shared.Keys.TakeTheirs):
+		item, ok := a.dashboard.SelectedItem()
+		if !ok || item.Kind != dashboard.File {
+			return a, nil
+		}
+		if item.File.Status != git.StatusConflicted {
+			a.setStatus(item.File.Path + " has no merge conflict")
+			return a, nil
+		}
+		return a, resolveConflictCmd(item.Repo.Path, *item.File, key.Matches(msg, shared.Keys.TakeTheirs))
+
+	case key.Matches(msg, shared.Keys.AbortOp), key.Matches(msg, shared.Keys.ContinueOp):
+		repo, ok := a.dashboard.SelectedRepo()
+		if !ok || repo.InProgress == "" {
+			return a, nil
+		}
+		if repo.InProgress != "merging" && repo.InProgress != "rebasing" {
+			a.setFeedback(shared.FeedbackWarning, "Only merges and rebases can be aborted or continued here", "", "")
+			return a, nil
+		}
+		if key.Matches(msg, shared.Keys.ContinueOp) {
+			return a, operationStepCmd(repo.Path, repo.InProgress, true)
+		}
+		what := "merge"
+		if repo.InProgress == "rebasing" {
+			what = "rebase"
+		}
+		repoPath := repo.Path
+		return a, a.askConfirmPreview("Abort "+what, "Abort the "+what+" in "+repo.Name+"? Its changes will be discarded",
+			operationStepCmd(repoPath, repo.InProgress, false), func() (string, error) {
+				out, err := git.UncommittedStat(repoPath)
+				return previewSection("Changes that will be discarded:", "No uncommitted changes to discard", out, err)
+			})
+
+	case key.Matches(msg, shared.Keys.UndoStage):
+		if a.lastStage == nil {
+			a.setStatus("Nothing to undo")
+			return a, nil
+		}
+		undo := *a.lastStage
+		a.lastStage = nil
+		return a, undoStageCmd(undo)
+
+	case key.Matches(msg, shared.Keys.StageAll):
 		repo, ok := a.dashboard.SelectedRepo()
 		if !ok {
 			return a, nil
 		}
-		return a, stageAllCmd(repo.Path)
+		return a, a.bulkStage(repo, true)
 
 	case key.Matches(msg, shared.Keys.UnstageAll):
 		repo, ok := a.dashboard.SelectedRepo()
 		if !ok {
 			return a, nil
 		}
-		return a, unstageAllCmd(repo.Path)
+		return a, a.bulkStage(repo, false)
 
 	case key.Matches(msg, shared.Keys.Diff):
 		item, ok := a.dashboard.SelectedItem()
 		if !ok || item.Kind != dashboard.File {
 			return a, nil
 		}
-		return a, fetchDiffCmd(item.Repo.Path, item.File.Path, *item.File)
+		return a, a.fetchDiffCmd(item.Repo.Path, item.File.Path, *item.File, git.DefaultDiffMode(*item.File))
 
-	case key.Matches(msg, shared.Keys.Commit):
+	case key.Matches(msg, shared.Keys.QuickCommit):
 		item, ok := a.dashboard.SelectedItem()
 		if !ok {
 			return a, nil
 		}
-		if !a.dashboard.RepoHasStagedFiles(item.RepoIndex) {
+		staged := a.dashboard.StagedCount(item.RepoIndex)
+		if staged == 0 {
+			a.setStatus("No staged files to commit")
+			return a, nil
+		}
+		a.quickCommit.Open(item.Repo.Path, item.Repo.Name, item.Repo.BranchLabel(), staged)
+		a.activeView = QuickCommitView
+		return a, nil
+
+	case key.Matches(msg, shared.Keys.Squash):
+		repo, ok := a.dashboard.SelectedRepo()
+		if !ok {
+			return a, nil
+		}
+		a.squash.Open(repo.Path, repo.Name)
+		a.activeView = SquashView
+		return a, nil
+
+	case key.Matches(msg, shared.Keys.Commit), key.Matches(msg, shared.Keys.AllowEmpty):
+		item, ok := a.dashboard.SelectedItem()
+		if !ok {
+			return a, nil
+		}
+		allowEmpty := key.Matches(msg, shared.Keys.AllowEmpty)
+		if !allowEmpty && !a.dashboard.RepoHasStagedFiles(item.RepoIndex) {
 			a.setStatus("No staged files to commit")
 			return a, nil
 		}
 		a.activeView = CommitView
 		a.commitView.SetRepo(item.Repo)
+		a.commitView.SetAllowEmpty(allowEmpty)
+		if a.cfg.Commit.PrefillTicket {
+			a.commitView.Prefill(commitview.TicketPrefix(item.Repo.Branch,
+				a.cfg.ResolvedBranchTicketRegex(), a.cfg.ResolvedTicketTemplate()))
+		}
 		conductorPath := a.conductorPathForActiveProject(item.Repo.Path)
-		return a, fetchCommitViewContextCmd(item.Repo.Path, conductorPath)
+		cmds := []tea.Cmd{fetchCommitViewContextCmd(item.Repo.Path, conductorPath, a.cfg.Commit.Template)}
+		if a.cfg.Commit.IssueAutocomplete {
+			cmds = append(cmds, fetchIssuesCmd(item.Repo.Path))
+		}
+		return a, tea.Batch(cmds...)
 
 	case key.Matches(msg, shared.Keys.Open):
 		item, ok := a.dashboard.SelectedItem()
@@ -883,20 +2262,31 @@ func (a App) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		if item.Kind == dashboard.RepoHeader {
 			a.dashboard.ToggleCollapse()
+			a.syncState()
 			return a, a.maybeRefreshGraph()
 		}
 		if item.Kind == dashboard.DocHeader {
 			a.dashboard.ToggleDocsCollapse()
+			a.syncState()
 			return a, nil
 		}
 		if item.Kind == dashboard.FolderHeader {
 			a.dashboard.ToggleFolderCollapse()
+			a.syncState()
+			return a, nil
+		}
+		if item.Kind == dashboard.MoreFiles {
+			a.dashboard.ShowMoreFiles()
+			return a, nil
+		}
+		if item.Kind == dashboard.UntrackedDir {
+			a.dashboard.ToggleUntrackedDir()
 			return a, nil
 		}
 		if item.Kind != dashboard.File {
 			return a, nil
 		}
-		return a, nvim.OpenFile(item.Repo.Path, item.File.Path)
+		return a, editor.OpenFile(item.Repo.Path, item.File.Path)
 	}
 
 	return a, nil
@@ -907,19 +2297,44 @@ func (a App) handleDiffKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, shared.Keys.Quit), key.Matches(msg, shared.Keys.Escape):
 		return a, func() tea.Msg { return shared.CloseDiffMsg{} }
 
+	case key.Matches(msg, shared.Keys.DiffMode):
+		repoPath, file, entry, mode := a.diffView.Source()
+		return a, a.fetchDiffCmd(repoPath, file, entry, mode.Next())
+
+	// Staging re-fetches the diff afterwards so the current mode reflects it
 	case key.Matches(msg, shared.Keys.Stage):
-		item, ok := a.dashboard.SelectedItem()
-		if !ok || item.Kind != dashboard.File {
-			return a, nil
-		}
-		return a, stageFileCmd(item.Repo.Path, item.File.Path)
+		repoPath, file, entry, mode := a.diffView.Source()
+		return a, tea.Sequence(stageFileCmd(repoPath, file), a.fetchDiffCmd(repoPath, file, entry, mode))
 
 	case key.Matches(msg, shared.Keys.Unstage):
-		item, ok := a.dashboard.SelectedItem()
-		if !ok || item.Kind != dashboard.File {
-			return a, nil
+		repoPath, file, entry, mode := a.diffView.Source()
+		return a, tea.Sequence(unstageFileCmd(repoPath, file), a.fetchDiffCmd(repoPath, file, entry, mode))
+
+	case key.Matches(msg, shared.Keys.NextHunk):
+		a.diffView.NextHunk()
+		return a, nil
+
+	case key.Matches(msg, shared.Keys.PrevHunk):
+		a.diffView.PrevHunk()
+		return a, nil
+
+	case key.Matches(msg, shared.Keys.StageHunk), key.Matches(msg, shared.Keys.CommitHunk):
+		repoPath, file, entry, mode := a.diffView.Source()
+		hunk, ok := a.diffView.FocusedHunk()
+		commit := key.Matches(msg, shared.Keys.CommitHunk)
+		switch {
+		case !ok:
+			a.setStatus("No hunk to stage")
+		case entry.Status == git.StatusUntracked:
+			a.setStatus("Untracked file: stage it whole with s")
+		case mode == git.DiffHead:
+			a.setStatus("Switch to the unstaged or staged diff (m) to stage hunks")
+		case commit && mode != git.DiffUnstaged:
+			a.setStatus("Switch to the unstaged diff (m) to commit a hunk")
+		default:
+			return a, stageHunkCmd(repoPath, file, hunk, mode == git.DiffStaged, commit)
 		}
-		return a, unstageFileCmd(item.Repo.Path, item.File.Path)
+		return a, nil
 	}
 
 	// Pass through to viewport for scrolling
@@ -929,8 +2344,35 @@ func (a App) handleDiffKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (a App) handleCommitKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Issue popup takes priority while open
+	if a.commitView.Autocompleting() {
+		return a, a.commitView.HandleAutocompleteKey(msg)
+	}
+
 	switch {
-	case key.Matches(msg, shared.Keys.Escape):
+	case a.commitView.EditingTemplate() && (msg.String() == "enter" || key.Matches(msg, shared.Keys.Escape)),
+		key.Matches(msg, shared.Keys.LoadTemplate) && !a.commitView.EditingAuthor():
+		editing := a.commitView.EditingTemplate()
+		a.commitView.ToggleTemplateEdit()
+		if !editing || msg.String() != "enter" {
+			return a, nil
+		}
+		repo, ok := a.dashboard.SelectedRepo()
+		if !ok {
+			return a, nil
+		}
+		template, err := git.CommitTemplate(repo.Path, a.commitView.TemplatePath())
+		switch {
+		case err != nil:
+			a.setFeedback(shared.FeedbackError, "Template not loaded: "+err.Error(), err.Error(), "")
+		case strings.TrimSpace(template) == "":
+			a.setFeedback(shared.FeedbackWarning, "No template: enter a file, or set git's commit.template", "", "")
+		default:
+			a.commitView.LoadTemplate(template)
+		}
+		return a, nil
+
+	case key.Matches(msg, shared.Keys.Escape) && !a.commitView.EditingAuthor():
 		return a, func() tea.Msg { return shared.CloseCommitMsg{} }
 
 	case key.Matches(msg, shared.Keys.AmendToggle):
@@ -945,24 +2387,77 @@ func (a App) handleCommitKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if err == nil {
 				a.commitView.SetAmendMessage(lastMsg)
 			}
+			if author, err := git.LastCommitAuthor(repo.Path); err == nil {
+				a.commitView.SetAmendAuthor(author)
+			}
 		}
 		return a, nil
 
+	case key.Matches(msg, shared.Keys.AmendAuthor),
+		a.commitView.EditingAuthor() && (msg.String() == "enter" || key.Matches(msg, shared.Keys.Escape)):
+		a.commitView.ToggleAuthorEdit()
+		return a, nil
+
+	case key.Matches(msg, shared.Keys.AmendResetDate):
+		a.commitView.ToggleResetDate()
+		return a, nil
+
+	case key.Matches(msg, shared.Keys.AllowEmpty):
+		a.commitView.ToggleAllowEmpty()
+		return a, nil
+
+	case key.Matches(msg, shared.Keys.SignToggle):
+		a.commitView.ToggleSign()
+		return a, nil
+
 	case key.Matches(msg, shared.Keys.GenerateMsg):
 		repo, ok := a.dashboard.SelectedRepo()
-		if !ok {
+		if !ok || a.generatingMsg() {
 			return a, nil
 		}
 		a.commitView.SetGenerating(true)
 		spinCmd := a.startLoader(shared.OpGenerate, "Generating commit message")
-		return a, tea.Batch(spinCmd, generateCommitMsgCmd(repo.Path))
+		return a, tea.Batch(spinCmd, generateCommitMsgCmd(repo.Path, ""))
+
+	case key.Matches(msg, shared.Keys.RegenerateMsg):
+		repo, ok := a.dashboard.SelectedRepo()
+		if !ok || a.generatingMsg() {
+			return a, nil
+		}
+		a.commitView.SetGenerating(true)
+		spinCmd := a.startLoader(shared.OpGenerate, "Generating another commit message")
+		return a, tea.Batch(spinCmd, generateCommitMsgCmd(repo.Path, a.commitView.AIMessage()))
+
+	case key.Matches(msg, shared.Keys.PrevAIMsg):
+		a.commitView.SwapPreviousAI()
+		return a, nil
 
 	case key.Matches(msg, shared.Keys.CycleType):
 		a.commitView.CycleTypeForward()
 		return a, nil
 
+	case key.Matches(msg, shared.Keys.RecallMsg):
+		repo, ok := a.dashboard.SelectedRepo()
+		if !ok {
+			return a, nil
+		}
+		recent, ok := a.commitView.NextRecall()
+		if !ok {
+			return a, nil
+		}
+		// Recent commits only carry the subject; load the full message
+		message, err := git.CommitMessage(repo.Path, recent.Hash)
+		if err != nil {
+			message = recent.Message
+		}
+		a.commitView.SetRecalledMessage(message)
+		return a, nil
+
 	case key.Matches(msg, shared.Keys.SubmitCommit):
 		message := a.commitView.Value()
+		if a.commitView.Templated() {
+			message = commit.StripComments(message)
+		}
 		if message == "" {
 			return a, nil
 		}
@@ -970,18 +2465,53 @@ func (a App) handleCommitKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if !ok {
 			return a, nil
 		}
+		author, resetDate := a.commitView.AmendOverrides()
+		if !a.commitView.Warned(message) {
+			var violations []string
+			if a.cfg.Commit.Validate {
+				violations = commit.Validate(message, a.commitRules())
+			}
+			// Overriding author or date rewrites more than the message; make
+			// the second C-y the confirmation
+			if a.commitView.IsAmend() && author != "" {
+				violations = append(violations, "amend sets the author to "+author)
+			}
+			if a.commitView.IsAmend() && resetDate {
+				violations = append(violations, "amend resets the author date to now")
+			}
+			if len(violations) > 0 {
+				a.commitView.SetViolations(message, violations)
+				a.setFeedback(shared.FeedbackWarning,
+					fmt.Sprintf("Commit message: %s", strings.Join(violations, "; ")),
+					strings.Join(violations, "\n"), "")
+				return a, nil
+			}
+		}
 		if a.commitView.IsAmend() {
-			return a, amendCmd(repo.Path, message)
+			return a, amendCmd(repo.Path, message, author, resetDate, a.commitView.Sign())
 		}
-		return a, commitCmd(repo.Path, message)
+		return a, commitCmd(repo.Path, message, a.commitView.AllowEmpty(), a.commitView.Sign())
 	}
 
 	// Pass through to textarea (Enter inserts newlines)
 	var cmd tea.Cmd
 	a.commitView, cmd = a.commitView.Update(msg)
+	if msg.String() == "#" && a.commitView.HasIssues() {
+		a.commitView.StartAutocomplete()
+	}
 	return a, cmd
 }
 
+// commitRules builds the message validation rules from config.
+func (a App) commitRules() commit.Rules {
+	return commit.Rules{
+		MaxSubject:          a.cfg.ResolvedMaxSubjectLength(),
+		RequireType:         a.cfg.Commit.RequireType,
+		Imperative:          a.cfg.ResolvedImperativeMood(),
+		BlankLineBeforeBody: a.cfg.ResolvedBlankLineBeforeBody(),
+	}
+}
+
 func (a App) handleBranchPickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	result := a.branchPicker.HandleKey(msg)
 	switch result.Action {
@@ -994,11 +2524,339 @@ func (a App) handleBranchPickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return a, switchBranchCmd(repo.Path, result.BranchName)
 	case branchpicker.ActionCreate:
+		if result.StartPoint != "" {
+			return a, createBranchCmd(a.branchPicker.RepoPath(), result.BranchName, result.StartPoint)
+		}
 		repo, ok := a.dashboard.SelectedRepo()
 		if !ok {
 			return a, nil
 		}
-		return a, createBranchCmd(repo.Path, result.BranchName)
+		return a, createBranchCmd(repo.Path, result.BranchName, "")
+	case branchpicker.ActionSuggestName:
+		return a, suggestBranchNameCmd(a.branchPicker.RepoPath())
+	case branchpicker.ActionFindMerged:
+		return a, mergedBranchesCmd(a.branchPicker.RepoPath())
+	case branchpicker.ActionPrune:
+		return a, deleteBranchesCmd(a.branchPicker.RepoPath(), result.Branches)
+	}
+	return a, nil
+}
+
+func (a App) handleReflogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := a.reflogView.HandleKey(msg)
+	repoPath := a.reflogView.RepoPath()
+	switch result.Action {
+	case reflog.ActionNone:
+		if a.reflogView.InInputMode() {
+			var cmd tea.Cmd
+			a.reflogView, cmd = a.reflogView.Update(msg)
+			return a, cmd
+		}
+	case reflog.ActionClose:
+		a.activeView = DashboardView
+	case reflog.ActionReset:
+		e := result.Entry
+		return a, a.askConfirmPreview("Reset", fmt.Sprintf("Reset %s to %s (%s)? Local changes are kept", filepath.Base(repoPath), e.Selector, e.Hash),
+			reflogResetCmd(repoPath, e), func() (string, error) {
+				out, err := git.LogOneline(repoPath, e.Hash+"..HEAD")
+				return previewSection("Commits no longer on the branch:", "No commits are dropped: "+e.Hash+" already has everything on HEAD", out, err)
+			})
+	case reflog.ActionBranch:
+		a.activeView = DashboardView
+		e := result.Entry
+		a.askConfirm(fmt.Sprintf("Create branch %s at %s (%s)?", result.BranchName, e.Selector, e.Hash),
+			reflogBranchCmd(repoPath, result.BranchName, e))
+	}
+	return a, nil
+}
+
+func (a App) handleConductorExportKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := a.exportMenu.HandleKey(msg)
+	data := a.conductorData[a.conductorRepo]
+	switch result.Action {
+	case conductorexport.ActionClose:
+		a.activeView = DashboardView
+	case conductorexport.ActionCopy:
+		a.activeView = DashboardView
+		return a, exportConductorCmd(data, result.Sections, "")
+	case conductorexport.ActionWrite:
+		a.activeView = DashboardView
+		return a, exportConductorCmd(data, result.Sections, a.conductorExportPath())
+	}
+	return a, nil
+}
+
+func (a App) handlePaletteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := a.palette.HandleKey(msg)
+	switch result.Action {
+	case palette.ActionClose:
+		a.activeView = DashboardView
+	case palette.ActionRun:
+		a.activeView = DashboardView
+		// Replay the binding's key so the action runs exactly as if typed
+		return a.handleKey(shared.KeyMsgFor(result.Binding.Keys()[0]))
+	}
+	return a, nil
+}
+
+// paletteScope is where the command palette was opened from.
+func (a App) paletteScope() shared.Scope {
+	switch {
+	case a.focusPanel == FocusConductor:
+		return shared.ScopeConductor
+	case a.graphFocused || a.focusPanel == FocusGraph:
+		return shared.ScopeGraph
+	case a.dashboard.ShowingProjects():
+		return shared.ScopeProjects
+	}
+	return shared.ScopeRepos
+}
+
+// helpContext names where help was opened and lists the keys that work
+// there, so help can show those first. Overlays with their own key hints
+// get no context, and help shows every key.
+func (a App) helpContext() help.Context {
+	k := shared.Keys
+	// relabel is b with its description for this context
+	relabel := func(b key.Binding, desc string) key.Binding {
+		return key.NewBinding(key.WithKeys(b.Keys()...), key.WithHelp(b.Help().Key, desc))
+	}
+	switch a.activeView {
+	case DiffView:
+		return help.Context{Name: "Diff", Bindings: []key.Binding{
+			k.Up, k.Down, k.HalfPageDown, k.HalfPageUp, k.PageDown, k.PageUp,
+			k.NextHunk, k.PrevHunk, k.StageHunk, k.CommitHunk,
+			k.DiffMode, k.Stage, k.Unstage, k.Escape,
+		}}
+	case CommitView:
+		return help.Context{Name: "Commit", Bindings: []key.Binding{
+			k.SubmitCommit, k.CycleType, k.GenerateMsg, k.RegenerateMsg, k.PrevAIMsg, k.RecallMsg,
+			k.AmendToggle, k.AmendAuthor, k.AmendResetDate, k.AllowEmpty, k.SignToggle, k.LoadTemplate, relabel(k.Escape, "cancel"),
+		}}
+	}
+	if a.activeView != DashboardView {
+		return help.Context{}
+	}
+
+	var ctx help.Context
+	scope := a.paletteScope()
+	switch scope {
+	case shared.ScopeConductor:
+		ctx = help.Context{Name: "Conductor pane", Bindings: []key.Binding{
+			k.Up, k.Down, relabel(k.Open, "expand header / show item"),
+			relabel(k.NextRepo, "next section"), relabel(k.PrevRepo, "previous section"),
+			relabel(k.FocusDown, "focus details"), k.FocusLeft,
+			relabel(k.Escape, "back to list / dashboard"),
+		}}
+	case shared.ScopeGraph:
+		ctx = help.Context{Name: "Graph pane", Bindings: []key.Binding{
+			k.Up, k.Down, k.HalfPageDown, k.HalfPageUp, k.NextRef, k.PrevRef, k.GoToHash,
+			k.ExpandDetail, k.DetailDown, k.DetailUp,
+			relabel(k.Open, "show files / expand file diff"), k.FocusLeft, k.FocusRight,
+			relabel(k.Escape, "back to dashboard"),
+		}}
+	case shared.ScopeProjects:
+		ctx = help.Context{Name: "All projects", Bindings: []key.Binding{
+			k.Up, k.Down, k.HalfPageDown, k.HalfPageUp, k.Top, k.Bottom,
+			relabel(k.Open, "open project"), k.FocusRight,
+		}}
+	default:
+		ctx = help.Context{Name: "Repos", Bindings: []key.Binding{
+			k.Up, k.Down, k.NextRepo, k.PrevRepo, k.HalfPageDown, k.HalfPageUp, k.Top, k.Bottom,
+			k.Open, k.FocusRight,
+		}}
+		if a.cfg.Display.FileColumns > 1 {
+			ctx.Bindings = append(ctx.Bindings, k.Left, k.Right)
+		}
+	}
+	for _, c := range k.Commands() {
+		if c.Scope&scope != 0 {
+			ctx.Bindings = append(ctx.Bindings, c.Binding)
+		}
+	}
+	return ctx
+}
+
+func (a App) handlePatchPromptKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := a.patchPrompt.HandleKey(msg)
+	switch result.Action {
+	case patchprompt.ActionNone:
+		var cmd tea.Cmd
+		a.patchPrompt, cmd = a.patchPrompt.Update(msg)
+		return a, cmd
+	case patchprompt.ActionClose:
+		a.activeView = DashboardView
+	case patchprompt.ActionSubmit:
+		a.activeView = DashboardView
+		return a, applyPatchCmd(a.patchPrompt.RepoPath(), result.Path)
+	}
+	return a, nil
+}
+
+func (a App) handleRefPickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := a.refPicker.HandleKey(msg)
+	switch result.Action {
+	case refpicker.ActionClose:
+		a.activeView = DashboardView
+	case refpicker.ActionJump:
+		a.activeView = DashboardView
+		hash := result.Ref.Hash
+		if a.graphPane.SelectHash(hash) && hash != a.lastDetailHash {
+			return a, a.fetchCommitDetailCmd(a.graphPane.RepoPath(), hash)
+		}
+	}
+	return a, nil
+}
+
+func (a App) handleConfirmPreviewKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := a.confirmPreview.HandleKey(msg)
+	switch result.Action {
+	case confirmpreview.ActionCancel:
+		a.activeView = DashboardView
+		a.setFeedback(shared.FeedbackInfo, "Cancelled", "", "")
+	case confirmpreview.ActionConfirm:
+		a.activeView = DashboardView
+		return a, result.Cmd
+	}
+	return a, nil
+}
+
+func (a App) handleHashPromptKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := a.hashPrompt.HandleKey(msg)
+	switch result.Action {
+	case hashprompt.ActionNone:
+		var cmd tea.Cmd
+		a.hashPrompt, cmd = a.hashPrompt.Update(msg)
+		return a, cmd
+	case hashprompt.ActionClose:
+		a.activeView = DashboardView
+	case hashprompt.ActionSubmit:
+		a.activeView = DashboardView
+		switch hashes := a.graphPane.FindHash(result.Hash); len(hashes) {
+		case 0:
+			// Not in the loaded graph: ask git, it may be further back
+			return a, resolveCommitCmd(a.graphPane.RepoPath(), result.Hash)
+		case 1:
+			return a, a.selectGraphHash(hashes[0])
+		default:
+			a.setFeedback(shared.FeedbackWarning, fmt.Sprintf("%q matches %d commits, type more of the hash", result.Hash, len(hashes)), "", "")
+		}
+	}
+	return a, nil
+}
+
+// selectGraphHash moves the graph cursor to hash and loads its detail.
+func (a *App) selectGraphHash(hash string) tea.Cmd {
+	if a.graphPane.SelectHash(hash) && hash != a.lastDetailHash {
+		return a.fetchCommitDetailCmd(a.graphPane.RepoPath(), hash)
+	}
+	return nil
+}
+
+// graphDepth is how many commits to load in repoPath's graph: the
+// configured max, or more after jumping to an older commit.
+func (a *App) graphDepth(repoPath string) int {
+	n := a.cfg.ResolvedGraphMaxCommits()
+	if repoPath == a.deepGraphRepo && a.deepGraphDepth > n {
+		return a.deepGraphDepth
+	}
+	return n
+}
+
+func (a App) handleChangelogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := a.changelogMenu.HandleKey(msg)
+	repoPath := a.changelogMenu.RepoPath()
+	switch result.Action {
+	case rangepicker.ActionClose:
+		a.activeView = DashboardView
+	case rangepicker.ActionCopy:
+		a.activeView = DashboardView
+		return a, changelogCmd(repoPath, result.From, result.To, "")
+	case rangepicker.ActionWrite:
+		a.activeView = DashboardView
+		return a, changelogCmd(repoPath, result.From, result.To, filepath.Join(repoPath, "CHANGELOG.md"))
+	}
+	return a, nil
+}
+
+func (a App) handleActivityKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := a.activityMenu.HandleKey(msg)
+	repoPath, name := a.activityMenu.RepoPath(), a.activityMenu.RepoName()
+	switch result.Action {
+	case activitymenu.ActionClose:
+		a.activeView = DashboardView
+	case activitymenu.ActionCopy:
+		a.activeView = DashboardView
+		return a, exportActivityCmd(repoPath, name, result.Days, result.ByType, "")
+	case activitymenu.ActionWrite:
+		a.activeView = DashboardView
+		return a, exportActivityCmd(repoPath, name, result.Days, result.ByType, a.activityExportPath(name))
+	}
+	return a, nil
+}
+
+// activityExportPath is where a repo's activity export is written: next
+// to the context export, as gitdash-<repo>-activity.md.
+func (a App) activityExportPath(repoName string) string {
+	return filepath.Join(filepath.Dir(a.cfg.ResolvedContextFile()), "gitdash-"+repoName+"-activity.md")
+}
+
+// conductorExportPath is where the conductor export is written: next to
+// the context export, as gitdash-conductor.md.
+func (a App) conductorExportPath() string {
+	return filepath.Join(filepath.Dir(a.cfg.ResolvedContextFile()), "gitdash-conductor.md")
+}
+
+func (a App) handleQuickCommitKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := a.quickCommit.HandleKey(msg)
+	switch result.Action {
+	case quickcommit.ActionNone:
+		var cmd tea.Cmd
+		a.quickCommit, cmd = a.quickCommit.Update(msg)
+		return a, cmd
+	case quickcommit.ActionClose:
+		a.activeView = DashboardView
+		if hc := a.hunkCommit; hc != nil {
+			a.hunkCommit = nil
+			a.activeView = DiffView
+			_, file, _, _ := a.diffView.Source()
+			return a, stageHunkCmd(hc.repoPath, file, hc.hunk, true, false)
+		}
+	case quickcommit.ActionSubmit:
+		a.activeView = DashboardView
+		if a.hunkCommit != nil {
+			a.activeView = DiffView
+		}
+		return a, quickCommitCmd(a.quickCommit.RepoPath(), result.Message, a.commitView.Sign())
+	}
+	return a, nil
+}
+
+func (a App) handleSquashKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := a.squash.HandleKey(msg)
+	repoPath := a.squash.RepoPath()
+	switch result.Action {
+	case squash.ActionNone:
+		if msg.String() == "esc" {
+			return a, nil
+		}
+		var cmd tea.Cmd
+		a.squash, cmd = a.squash.Update(msg)
+		return a, cmd
+	case squash.ActionClose:
+		a.activeView = DashboardView
+	case squash.ActionPrepare:
+		return a, squashPrepareCmd(repoPath, result.N)
+	case squash.ActionSubmit:
+		prompt := fmt.Sprintf("Squash the last %d commits of %s into one? This rewrites history", result.N, filepath.Base(repoPath))
+		if result.Pushed {
+			prompt += " (some are already pushed)"
+		}
+		n := result.N
+		return a, a.askConfirmPreview("Squash", prompt, squashCmd(repoPath, n, result.Message, a.commitView.Sign()), func() (string, error) {
+			out, err := git.LogOneline(repoPath, fmt.Sprintf("-n%d", n))
+			return previewSection("Commits folded into one:", "", out, err)
+		})
 	}
 	return a, nil
 }
@@ -1050,7 +2908,113 @@ func (a App) processProjectManagerResult(result projectmanager.KeyResult) (tea.M
 	return a, refreshAllStatus(a.cfg)
 }
 
+// openThemePicker shows the theme picker docked under the dashboard.
+func (a *App) openThemePicker() {
+	a.themePicker.Open(a.cfg.ResolvedTheme().Preset)
+	a.activeView = ThemePickerView
+}
+
+// applyTheme re-initializes all styles with preset as the base theme,
+// keeping any per-field overrides from the config.
+func (a *App) applyTheme(preset string) {
+	cfg := a.cfg
+	cfg.Theme.Preset = preset
+	shared.InitStyles(cfg.ResolvedTheme(), cfg.ResolvedGraphColors())
+	// Resizing rebuilds pane content cached with the old styles
+	a.layoutSizes()
+}
+
+func (a App) handleThemePickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := a.themePicker.HandleKey(msg)
+	switch result.Action {
+	case themepicker.ActionPreview:
+		a.applyTheme(result.Preset)
+	case themepicker.ActionCancel:
+		a.applyTheme(result.Preset)
+		a.activeView = DashboardView
+	case themepicker.ActionApply:
+		a.applyTheme(result.Preset)
+		a.activeView = DashboardView
+		a.cfg.Theme.Preset = result.Preset
+		if err := config.Save(a.configPath, a.cfg); err != nil {
+			a.setFeedback(shared.FeedbackError, "Save failed: "+err.Error(), err.Error(), "")
+			return a, nil
+		}
+		a.setFeedback(shared.FeedbackSuccess, "Theme set to "+result.Preset, "", "")
+	}
+	return a, nil
+}
+
+func (a App) handleSettingsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := a.settings.HandleKey(msg)
+	switch result.Action {
+	case settings.ActionCancel:
+		a.activeView = DashboardView
+	case settings.ActionSave:
+		a.activeView = DashboardView
+		cfg := a.cfg
+		cfg.Display = result.Display
+		if err := config.Save(a.configPath, cfg); err != nil {
+			a.setFeedback(shared.FeedbackError, "Save failed: "+err.Error(), err.Error(), "")
+			return a, nil
+		}
+		a.cfg = cfg
+		a.applyDisplay()
+		a.setFeedback(shared.FeedbackSuccess, "Settings saved", "", "")
+		a.graphRepo = "" // max commits may have changed
+		return a, a.maybeRefreshGraph()
+	}
+	return a, nil
+}
+
+// applyDisplay re-applies the display options after they're edited.
+func (a *App) applyDisplay() {
+	shared.InitStyles(a.cfg.ResolvedTheme(), a.cfg.ResolvedGraphColors())
+	icons.SetNerdFonts(a.cfg.Display.NerdFonts)
+	a.graphPane.SetShowIcons(a.cfg.Display.Icons || a.cfg.Display.NerdFonts)
+	a.dashboard.SetDisplay(a.cfg.Display)
+	a.layoutSizes()
+}
+
+func (a App) handleSetupKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := a.setup.HandleKey(msg)
+	switch result.Action {
+	case setup.ActionNone:
+		if a.setup.InInputMode() {
+			var cmd tea.Cmd
+			a.setup, cmd = a.setup.Update(msg)
+			return a, cmd
+		}
+		return a, nil
+	case setup.ActionSkip:
+		a.activeView = DashboardView
+		a.setFeedback(shared.FeedbackInfo, "Setup skipped — press P to add projects", "", "")
+		return a, nil
+	}
+
+	// Drop into the dashboard either way so failures are visible
+	a.activeView = DashboardView
+	a.cfg.Projects = append(a.cfg.Projects, result.Project)
+	if err := config.Save(a.configPath, a.cfg); err != nil {
+		a.setFeedback(shared.FeedbackError, "Save failed: "+err.Error(), err.Error(), "")
+		return a, nil
+	}
+
+	newCfg, err := config.Load(a.configPath)
+	if err != nil {
+		a.setFeedback(shared.FeedbackError, "Reload failed: "+err.Error(), err.Error(), "")
+		return a, nil
+	}
+
+	a.cfg = newCfg
+	a.dashboard.SetProjects(a.cfg.Projects)
+	a.setFeedback(shared.FeedbackSuccess,
+		fmt.Sprintf("Created %s with %d repos — saved %s", result.Project.Name, len(result.Project.Repos), a.configPath), "", "")
+	return a, refreshAllStatus(a.cfg)
+}
+
 func (a App) View() string {
+	defer crashGuard()
 	if a.showHelp {
 		return a.helpView.View()
 	}
@@ -1084,6 +3048,64 @@ func (a App) View() string {
 		view = a.commitView.View()
 	case ProjectManagerView:
 		view = a.projectManager.View()
+	case SetupView:
+		view = a.setup.View()
+	case ThemePickerView:
+		view = a.renderDashboardLayout(contentH)
+		view += a.renderStatusBar()
+		view = a.themePicker.ViewOverlay(view, a.width, a.height)
+	case SettingsView:
+		view = a.renderDashboardLayout(contentH)
+		view += a.renderStatusBar()
+		view = a.settings.ViewOverlay(view, a.width, a.height)
+	case ReflogView:
+		view = a.renderDashboardLayout(contentH)
+		view += a.renderStatusBar()
+		view = a.reflogView.ViewOverlay(view, a.width, a.height)
+	case QuickCommitView:
+		if a.hunkCommit != nil {
+			view = a.diffView.View()
+		} else {
+			view = a.renderDashboardLayout(contentH)
+			view += a.renderStatusBar()
+		}
+		view = a.quickCommit.ViewOverlay(view, a.width, a.height)
+	case SquashView:
+		view = a.renderDashboardLayout(contentH)
+		view += a.renderStatusBar()
+		view = a.squash.ViewOverlay(view, a.width, a.height)
+	case ConductorExportView:
+		view = a.renderDashboardLayout(contentH)
+		view += a.renderStatusBar()
+		view = a.exportMenu.ViewOverlay(view, a.width, a.height)
+	case ChangelogView:
+		view = a.renderDashboardLayout(contentH)
+		view += a.renderStatusBar()
+		view = a.changelogMenu.ViewOverlay(view, a.width, a.height)
+	case PatchPromptView:
+		view = a.renderDashboardLayout(contentH)
+		view += a.renderStatusBar()
+		view = a.patchPrompt.ViewOverlay(view, a.width, a.height)
+	case RefPickerView:
+		view = a.renderDashboardLayout(contentH)
+		view += a.renderStatusBar()
+		view = a.refPicker.ViewOverlay(view, a.width, a.height)
+	case HashPromptView:
+		view = a.renderDashboardLayout(contentH)
+		view += a.renderStatusBar()
+		view = a.hashPrompt.ViewOverlay(view, a.width, a.height)
+	case ActivityView:
+		view = a.renderDashboardLayout(contentH)
+		view += a.renderStatusBar()
+		view = a.activityMenu.ViewOverlay(view, a.width, a.height)
+	case ConfirmPreviewView:
+		view = a.renderDashboardLayout(contentH)
+		view += a.renderStatusBar()
+		view = a.confirmPreview.ViewOverlay(view, a.width, a.height)
+	case PaletteView:
+		view = a.renderDashboardLayout(contentH)
+		view += a.renderStatusBar()
+		view = a.palette.ViewOverlay(view, a.width, a.height)
 	}
 
 	return view
@@ -1095,27 +3117,90 @@ func (a *App) layoutSizes() {
 		contentH = 3
 	}
 
+	dashW, graphW, conductorW := a.columnWidths()
+	a.dashboard.SetSize(dashW, contentH)
+	if graphW > 0 {
+		a.graphPane.SetSize(graphW-1, contentH) // -1 for left border
+	}
+	if conductorW > 0 {
+		a.conductorPane.SetSize(conductorW-1, contentH) // -1 for left border
+	}
+}
+
+// columnWidths returns the dashboard, graph, and conductor column widths for
+// the current layout. Hidden columns are 0.
+func (a App) columnWidths() (dashW, graphW, conductorW int) {
 	if a.showGraph && a.showConductor && a.width > 80 {
 		// 3-column layout: dashboard | graph | conductor
-		dashPct := a.cfg.ResolvedDashboardWidth()
-		dashW := a.width * dashPct / 100
-		conductorW := a.width * 30 / 100
-		graphW := a.width - dashW - conductorW
+		dashW = a.width * a.cfg.ResolvedDashboardWidth() / 100
+		conductorW = a.width * 30 / 100
+		graphW = a.width - dashW - conductorW
 		if graphW < 20 {
 			graphW = 20
 		}
-		a.dashboard.SetSize(dashW, contentH)
-		a.graphPane.SetSize(graphW-1, contentH)      // -1 for left border
-		a.conductorPane.SetSize(conductorW-1, contentH) // -1 for left border
-	} else if a.showGraph && a.width > 40 {
+		return dashW, graphW, conductorW
+	}
+	if a.showGraph && a.width > 40 {
 		// 2-column layout: dashboard | graph
-		graphW := a.width / 2
-		dashW := a.width - graphW
-		a.dashboard.SetSize(dashW, contentH)
-		a.graphPane.SetSize(graphW-1, contentH)
-	} else {
-		a.dashboard.SetSize(a.width, contentH)
+		dashW = a.width * a.cfg.ResolvedDashboardWidth2Col() / 100
+		graphW = a.width - dashW
+		if graphW < 20 {
+			graphW = 20
+			dashW = a.width - graphW
+		}
+		return dashW, graphW, 0
+	}
+	return a.width, 0, 0
+}
+
+// handleMouse routes left clicks on the dashboard layout. Clicking a dashboard
+// row selects it (and toggles collapse on headers); clicking the graph or
+// conductor column focuses that pane.
+func (a App) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if a.activeView != DashboardView || a.showHelp || a.confirm != nil || a.featureLinker.IsVisible() {
+		return a, nil
+	}
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return a, nil
+	}
+
+	dashW, graphW, conductorW := a.columnWidths()
+	switch {
+	case msg.X >= dashW+graphW && conductorW > 0:
+		a.focusPanel = FocusConductor
+		a.graphFocused = false
+		return a, nil
+	case msg.X >= dashW && graphW > 0:
+		a.focusPanel = FocusGraph
+		a.graphFocused = true
+		return a, nil
+	}
+
+	a.focusPanel = FocusDashboard
+	a.graphFocused = false
+	item, ok := a.dashboard.ClickRow(msg.X, msg.Y)
+	if !ok {
+		return a, nil
+	}
+	// Headers only expand inside a project; all-projects rows just select
+	if !a.dashboard.ShowingProjects() {
+		switch item.Kind {
+		case dashboard.RepoHeader:
+			a.dashboard.ToggleCollapse()
+			a.syncState()
+		case dashboard.DocHeader:
+			a.dashboard.ToggleDocsCollapse()
+			a.syncState()
+		case dashboard.FolderHeader:
+			a.dashboard.ToggleFolderCollapse()
+			a.syncState()
+		case dashboard.MoreFiles:
+			a.dashboard.ShowMoreFiles()
+		case dashboard.UntrackedDir:
+			a.dashboard.ToggleUntrackedDir()
+		}
 	}
+	return a, a.maybeRefreshGraph()
 }
 
 func (a *App) maybeRefreshGraph() tea.Cmd {
@@ -1131,18 +3216,25 @@ func (a *App) maybeRefreshGraph() tea.Cmd {
 	var cmds []tea.Cmd
 
 	// In all-projects mode: use first repo of highlighted project for graph
-	if a.dashboard.ActiveProject() == -1 && len(a.cfg.Projects) > 0 {
+	if a.dashboard.ShowingProjects() {
 		item, ok := a.dashboard.SelectedItem()
-		if !ok || item.Kind != dashboard.ProjectHeader {
+		if !ok {
 			return nil
 		}
-		repo, ok := a.dashboard.FirstRepoInProject(item.ProjectIndex)
+		var repo *git.RepoStatus
+		switch item.Kind {
+		case dashboard.ProjectHeader:
+			repo, ok = a.dashboard.FirstRepoInProject(item.ProjectIndex)
+		case dashboard.RepoHeader: // pinned repo
+			repo, ok = item.Repo, item.Repo != nil
+		default:
+			ok = false
+		}
 		if !ok {
 			return nil
 		}
 		a.graphRepo = repo.Path
-		maxCommits := a.cfg.ResolvedGraphMaxCommits()
-		cmds = append(cmds, fetchGraphCmd(repo.Path, maxCommits))
+		cmds = append(cmds, a.fetchGraphCmd(repo.Path, a.graphDepth(repo.Path)))
 		// Conductor: use project path if available
 		conductorPath := a.conductorPathForProject(item.ProjectIndex)
 		if conductorPath != a.conductorRepo {
@@ -1158,8 +3250,7 @@ func (a *App) maybeRefreshGraph() tea.Cmd {
 		return nil
 	}
 	a.graphRepo = repo.Path
-	maxCommits := a.cfg.ResolvedGraphMaxCommits()
-	cmds = append(cmds, fetchGraphCmd(repo.Path, maxCommits))
+	cmds = append(cmds, a.fetchGraphCmd(repo.Path, a.graphDepth(repo.Path)))
 
 	conductorPath := a.conductorPathForActiveProject(repo.Path)
 	if conductorPath != a.conductorRepo {
@@ -1203,13 +3294,19 @@ func (a App) renderStatusBar() string {
 
 	// Show project name when drilled into a project
 	parts := []string{name}
-	if projName := a.dashboard.ProjectName(); projName != "" {
+	if a.dashboard.ByStatus() {
+		parts = append(parts, "all repos by status")
+	} else if projName := a.dashboard.ProjectName(); projName != "" {
 		parts = append(parts, projName)
 	}
 
 	// Show current branch if available (only in project-detail mode)
 	if repo, ok := a.dashboard.SelectedRepo(); ok && repo.Branch != "" {
-		parts = append(parts, repo.Branch)
+		parts = append(parts, repo.BranchLabel())
+	}
+
+	if f := a.dashboard.StagingFilter(); f != dashboard.FilterAll && !a.dashboard.ShowingProjects() {
+		parts = append(parts, shared.FeedbackWarningStyle.Render(f.String()))
 	}
 
 	status := strings.Join(parts, " │ ")
@@ -1220,8 +3317,10 @@ func (a App) renderStatusBar() string {
 		status += " │ " + s.View() + " " + label
 	}
 
-	// Show feedback or legacy status
-	if a.feedback != nil {
+	// Show pending confirmation, feedback, or legacy status
+	if a.confirm != nil {
+		status += " │ " + shared.FeedbackWarningStyle.Render(a.confirm.prompt+" (y/n)")
+	} else if a.feedback != nil {
 		var styledMsg string
 		switch a.feedback.Level {
 		case shared.FeedbackSuccess:
@@ -1242,7 +3341,8 @@ func (a App) renderStatusBar() string {
 	conductorPath := a.conductorRepo
 	if conductorPath != "" {
 		if data, exists := a.conductorData[conductorPath]; exists && data != nil {
-			status += " │ " + shared.ConductorPassedBadge.Render(fmt.Sprintf("%d/%d", data.Passed, data.Total))
+			status += " │ " + shared.ProgressBar(shared.Fraction(data.Passed, data.Total), 8) +
+				" " + shared.ConductorPassedBadge.Render(fmt.Sprintf("%d/%d", data.Passed, data.Total))
 			if data.Session != nil {
 				status += " " + shared.CommitDetailDateStyle.Render(fmt.Sprintf("#%d", data.Session.Number))
 			}
@@ -1252,9 +3352,59 @@ func (a App) renderStatusBar() string {
 		}
 	}
 
-	status += " │ ? for help"
+	status += " │ ? for help"
+
+	return "\n" + shared.StatusBarStyle.Width(a.width).Render(a.fitStatusBar(status))
+}
+
+// fitStatusBar right-aligns the refresh age (and clock, with show_clock)
+// after status. On narrow terminals those go first, the clock before the
+// age, so they never push out feedback or the conductor summary; only
+// then is status itself cut.
+func (a App) fitStatusBar(status string) string {
+	inner := a.width - shared.StatusBarStyle.GetHorizontalFrameSize()
+	if inner < 1 {
+		return status
+	}
+
+	var rights []string
+	if !a.lastRefresh.IsZero() {
+		ago := shared.CommitDetailDateStyle.Render("refreshed " + agoLabel(time.Since(a.lastRefresh)))
+		if a.cfg.Display.ShowClock {
+			rights = append(rights, ago+" │ "+time.Now().Format("15:04"))
+		}
+		rights = append(rights, ago)
+	}
+	statusW := lipgloss.Width(status)
+	for _, right := range rights {
+		if gap := inner - statusW - lipgloss.Width(right); gap >= 1 {
+			return status + strings.Repeat(" ", gap) + right
+		}
+	}
+	return lipgloss.NewStyle().MaxWidth(inner).Render(status)
+}
+
+// sizeLabel formats n bytes as "812 B", "3.4 KB" or "1.2 MB".
+func sizeLabel(n int) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%d B", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	}
+	return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+}
 
-	return "\n" + shared.StatusBarStyle.Width(a.width).Render(status)
+// agoLabel is a short age like "5s ago" or "3m ago".
+func agoLabel(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	}
 }
 
 func (a App) renderFatalOverlay(base string) string {
@@ -1284,8 +3434,7 @@ func (a App) renderDashboardLayout(contentH int) string {
 
 	if a.showGraph && a.showConductor && a.width > 80 {
 		// 3-column layout: dashboard | graph | conductor
-		dashPct := a.cfg.ResolvedDashboardWidth()
-		dashW := a.width * dashPct / 100
+		dashW, _, _ := a.columnWidths()
 		dashView = lipgloss.NewStyle().Width(dashW).Height(contentH).MaxHeight(contentH).Render(dashView)
 
 		var graphView string
@@ -1307,7 +3456,7 @@ func (a App) renderDashboardLayout(contentH int) string {
 
 	if a.showGraph && a.width > 40 {
 		// 2-column layout: dashboard | graph
-		dashW := a.width - a.width/2
+		dashW, _, _ := a.columnWidths()
 		dashView = lipgloss.NewStyle().Width(dashW).Height(contentH).MaxHeight(contentH).Render(dashView)
 
 		var graphView string
@@ -1330,7 +3479,7 @@ func (a *App) maybeRefreshConductor() tea.Cmd {
 	var conductorPath string
 
 	// In all-projects mode: use project path
-	if a.dashboard.ActiveProject() == -1 && len(a.cfg.Projects) > 0 {
+	if a.dashboard.ShowingProjects() {
 		item, ok := a.dashboard.SelectedItem()
 		if !ok || item.Kind != dashboard.ProjectHeader {
 			return nil
@@ -1485,66 +3634,259 @@ func linkFeatureCmd(repoPath, featureID, commitHash, commitMsg string, files []s
 
 func refreshAllStatus(cfg config.Config) tea.Cmd {
 	return func() tea.Msg {
-		allRepos := cfg.AllRepos()
-		repos := make([]git.RepoStatus, len(allRepos))
-		for i, repo := range allRepos {
+		return shared.StatusRefreshedMsg{Repos: repoStatuses(cfg, cfg.AllRepos())}
+	}
+}
+
+// refreshReposCmd refreshes only the repos at paths.
+func refreshReposCmd(cfg config.Config, paths []string) tea.Cmd {
+	return func() tea.Msg {
+		want := make(map[string]bool, len(paths))
+		for _, p := range paths {
+			want[p] = true
+		}
+		var repos []config.RepoConfig
+		for _, repo := range cfg.AllRepos() {
+			if want[repo.Path] {
+				repos = append(repos, repo)
+			}
+		}
+		return reposRefreshedMsg(repoStatuses(cfg, repos))
+	}
+}
+
+func repoStatuses(cfg config.Config, allRepos []config.RepoConfig) []git.RepoStatus {
+	repos := make([]git.RepoStatus, len(allRepos))
+	var wg sync.WaitGroup
+	for i, repo := range allRepos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
 			name := filepath.Base(repo.Path)
 			repos[i] = git.GetRepoStatus(repo.Path, name, repo.IgnorePatterns)
+			if cfg.Display.FileStats && repos[i].Error == nil {
+				git.AttachLineStats(repo.Path, repos[i].Files)
+			}
+			if cfg.Display.ShowRemote && repos[i].Error == nil {
+				repos[i].RemoteURL = git.RemoteURL(repo.Path, "origin")
+			}
+			if cfg.Display.CollapseUntrackedDirs && repos[i].Error == nil {
+				repos[i].UntrackedDirs, _ = git.UntrackedDirs(repo.Path)
+			}
+			if repo.CompareRemote != "" && repos[i].Error == nil && !repos[i].Detached {
+				rs := &repos[i]
+				rs.CompareRef, rs.CompareAhead, rs.CompareBehind = git.CompareRemote(repo.Path, repo.CompareRemote, rs.Branch)
+			}
+		}()
+	}
+	wg.Wait()
+	return repos
+}
+
+// pollStatusCmd refreshes every repo the watcher isn't covering; with
+// watching off that's all of them.
+func (a App) pollStatusCmd() tea.Cmd {
+	if a.watcher == nil {
+		return refreshAllStatus(a.cfg)
+	}
+	var paths []string
+	for _, repo := range a.cfg.AllRepos() {
+		if !a.watcher.Watching(repo.Path) {
+			paths = append(paths, repo.Path)
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	return refreshReposCmd(a.cfg, paths)
+}
+
+func startWatchCmd(cfg config.Config) tea.Cmd {
+	return func() tea.Msg {
+		var paths []string
+		for _, repo := range cfg.AllRepos() {
+			paths = append(paths, repo.Path)
 		}
-		return shared.StatusRefreshedMsg{Repos: repos}
+		w, err := watch.New(paths)
+		return watchStartedMsg{watcher: w, err: err}
+	}
+}
+
+// waitForChangesCmd blocks until the watcher reports changed repos.
+func waitForChangesCmd(w *watch.Watcher) tea.Cmd {
+	return func() tea.Msg {
+		paths, ok := <-w.Changes()
+		if !ok {
+			return nil
+		}
+		return reposChangedMsg(paths)
 	}
 }
 
 func stageFileCmd(repoPath, filePath string) tea.Cmd {
 	return func() tea.Msg {
-		git.StageFile(repoPath, filePath)
-		return shared.FileStageToggledMsg{}
+		err := git.StageFile(repoPath, filePath)
+		return shared.FileStageToggledMsg{RepoPath: repoPath, Path: filePath, Staged: true, Err: err}
+	}
+}
+
+// stageHunkCmd stages hunk of file, or unstages it. With commit set it is
+// only staged when nothing else is, ready to be committed on its own.
+func stageHunkCmd(repoPath, file string, hunk git.Hunk, unstage, commit bool) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if commit {
+			err = git.StageHunkAlone(repoPath, hunk)
+		} else {
+			err = git.StageHunk(repoPath, hunk, unstage)
+		}
+		return shared.HunkStagedMsg{RepoPath: repoPath, File: file, Hunk: hunk, Unstaged: unstage, Commit: commit, Err: err}
+	}
+}
+
+func resolveConflictCmd(repoPath string, file git.FileEntry, theirs bool) tea.Cmd {
+	return func() tea.Msg {
+		err := git.ResolveConflict(repoPath, file, theirs)
+		return shared.ConflictResolvedMsg{Path: file.Path, Theirs: theirs, Err: err}
+	}
+}
+
+// launchCmd opens a terminal (or the file manager) at dir.
+func launchCmd(dir string, terminal bool, display config.DisplayConfig) tea.Cmd {
+	return func() tea.Msg {
+		if terminal {
+			return shared.LaunchedMsg{What: "terminal", Dir: dir, Err: launch.Terminal(dir, display.TerminalCmd)}
+		}
+		return shared.LaunchedMsg{What: "file manager", Dir: dir, Err: launch.FileManager(dir, display.FileManagerCmd)}
+	}
+}
+
+func fixupCommitCmd(repoPath, hash string, sign bool) tea.Cmd {
+	return func() tea.Msg {
+		return shared.FixupCommittedMsg{Target: hash, Err: git.CommitFixup(repoPath, hash, sign)}
+	}
+}
+
+func autosquashCmd(repoPath, hash string) tea.Cmd {
+	return func() tea.Msg {
+		err := git.RebaseAutosquash(repoPath, hash)
+		stopped := err != nil && git.DetectInProgress(git.GitDir(repoPath)) == "rebasing"
+		return shared.AutosquashCompleteMsg{Stopped: stopped, Err: err}
+	}
+}
+
+// operationStepCmd aborts or continues the merge or rebase in progress.
+func operationStepCmd(repoPath, op string, cont bool) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		switch {
+		case op == "merging" && cont:
+			err = git.MergeContinue(repoPath)
+		case op == "merging":
+			err = git.MergeAbort(repoPath)
+		case cont:
+			err = git.RebaseContinue(repoPath)
+		default:
+			err = git.RebaseAbort(repoPath)
+		}
+		stopped := cont && err == nil && git.DetectInProgress(git.GitDir(repoPath)) != ""
+		return shared.OperationStepMsg{Op: op, Continue: cont, Stopped: stopped, Err: err}
 	}
 }
 
 func unstageFileCmd(repoPath, filePath string) tea.Cmd {
 	return func() tea.Msg {
-		git.UnstageFile(repoPath, filePath)
-		return shared.FileStageToggledMsg{}
+		err := git.UnstageFile(repoPath, filePath)
+		return shared.FileStageToggledMsg{RepoPath: repoPath, Path: filePath, Err: err}
+	}
+}
+
+// stagePathCmd stages the directory dir; paths are the files it holds, for
+// undo.
+func stagePathCmd(repoPath, dir string, paths []string) tea.Cmd {
+	return func() tea.Msg {
+		err := git.StagePath(repoPath, dir)
+		return shared.AllStagedMsg{RepoPath: repoPath, Paths: paths, Err: err}
+	}
+}
+
+func unstagePathCmd(repoPath, dir string, paths []string) tea.Cmd {
+	return func() tea.Msg {
+		err := git.UnstagePath(repoPath, dir)
+		return shared.AllUnstagedMsg{RepoPath: repoPath, Paths: paths, Err: err}
+	}
+}
+
+func stageFilesCmd(repoPath string, paths []string) tea.Cmd {
+	return func() tea.Msg {
+		err := git.StageFiles(repoPath, paths)
+		return shared.AllStagedMsg{RepoPath: repoPath, Paths: paths, Err: err}
 	}
 }
 
-func stageAllCmd(repoPath string) tea.Cmd {
+func unstageFilesCmd(repoPath string, paths []string) tea.Cmd {
 	return func() tea.Msg {
-		git.StageAll(repoPath)
-		return shared.AllStagedMsg{}
+		err := git.UnstageFiles(repoPath, paths)
+		return shared.AllUnstagedMsg{RepoPath: repoPath, Paths: paths, Err: err}
 	}
 }
 
-func unstageAllCmd(repoPath string) tea.Cmd {
+// undoStageCmd reverses a staging operation on the same paths.
+func undoStageCmd(u stageUndo) tea.Cmd {
 	return func() tea.Msg {
-		git.UnstageAll(repoPath)
-		return shared.AllUnstagedMsg{}
+		what := fmt.Sprintf("%d files", len(u.paths))
+		if len(u.paths) == 1 {
+			what = u.paths[0]
+		}
+		if u.staged {
+			err := git.UnstageFiles(u.repoPath, u.paths)
+			return shared.StageUndoneMsg{Summary: "Unstaged " + what, Err: err}
+		}
+		err := git.StageFiles(u.repoPath, u.paths)
+		return shared.StageUndoneMsg{Summary: "Restaged " + what, Err: err}
 	}
 }
 
-func fetchDiffCmd(repoPath, filePath string, entry git.FileEntry) tea.Cmd {
+func (a *App) fetchDiffCmd(repoPath, filePath string, entry git.FileEntry, mode git.DiffMode) tea.Cmd {
+	ctx, gen := a.fetches.start(fetchDiff)
 	return func() tea.Msg {
-		content, err := git.GetDiffOrContent(repoPath, filePath, entry)
-		return shared.DiffFetchedMsg{Content: content, File: filePath, Err: err}
+		content, err := git.GetDiffOrContent(ctx, repoPath, filePath, entry, mode)
+		return shared.DiffFetchedMsg{Content: content, RepoPath: repoPath, File: filePath, Entry: entry, Mode: mode, Gen: gen, Err: err}
 	}
 }
 
-func commitCmd(repoPath, message string) tea.Cmd {
+func commitCmd(repoPath, message string, allowEmpty, sign bool) tea.Cmd {
 	return func() tea.Msg {
-		err := git.Commit(repoPath, message)
+		var err error
+		if allowEmpty {
+			err = git.CommitEmpty(repoPath, message, sign)
+		} else {
+			err = git.Commit(repoPath, message, sign)
+		}
 		if err != nil {
 			return shared.CommitCompleteMsg{Err: err}
 		}
 		hash, _ := git.GetHeadHash(repoPath)
-		return shared.CommitCompleteMsg{Hash: hash}
+		return shared.CommitCompleteMsg{Hash: hash, Message: message}
+	}
+}
+
+func (a *App) fetchGraphCmd(repoPath string, maxCount int) tea.Cmd {
+	ctx, gen := a.fetches.start(fetchGraph)
+	return func() tea.Msg {
+		lines, err := git.GetGraph(ctx, repoPath, maxCount)
+		return shared.GraphFetchedMsg{Lines: lines, RepoPath: repoPath, Gen: gen, Err: err}
 	}
 }
 
-func fetchGraphCmd(repoPath string, maxCount int) tea.Cmd {
+// maxGraphJump is how far back (in commits) jumping to a hash will load
+// the graph.
+const maxGraphJump = 5000
+
+func resolveCommitCmd(repoPath, rev string) tea.Cmd {
 	return func() tea.Msg {
-		lines, err := git.GetGraph(repoPath, maxCount)
-		return shared.GraphFetchedMsg{Lines: lines, RepoPath: repoPath, Err: err}
+		hash, pos, err := git.CommitPosition(context.Background(), repoPath, rev)
+		return shared.CommitResolvedMsg{RepoPath: repoPath, Rev: rev, Hash: hash, Pos: pos, Err: err}
 	}
 }
 
@@ -1562,28 +3904,273 @@ func switchBranchCmd(repoPath, branchName string) tea.Cmd {
 	}
 }
 
-func createBranchCmd(repoPath, branchName string) tea.Cmd {
+// maxAIDiff caps how much of a diff is sent to the AI provider; the start
+// of a diff says enough about it.
+const maxAIDiff = 20000
+
+func suggestBranchNameCmd(repoPath string) tea.Cmd {
+	return func() tea.Msg {
+		stat, err := git.RunGit(repoPath, "diff", "--cached", "--stat")
+		if err != nil {
+			return shared.BranchNameSuggestedMsg{RepoPath: repoPath, Err: fmt.Errorf("getting staged diff: %w", err)}
+		}
+		if strings.TrimSpace(stat) == "" {
+			return shared.BranchNameSuggestedMsg{RepoPath: repoPath, Err: fmt.Errorf("no staged changes to name the branch after")}
+		}
+		diff, _ := git.RunGit(repoPath, "diff", "--cached")
+		if len(diff) > maxAIDiff {
+			diff = diff[:maxAIDiff]
+		}
+		name, err := ai.SuggestBranchName(stat + "\n\n" + diff)
+		return shared.BranchNameSuggestedMsg{RepoPath: repoPath, Name: name, Err: err}
+	}
+}
+
+func explainCommitCmd(repoPath, hash string) tea.Cmd {
+	return func() tea.Msg {
+		show, err := git.RunGit(repoPath, "show", "--stat", "--patch", hash)
+		if err != nil {
+			return shared.CommitExplainedMsg{Hash: hash, Err: fmt.Errorf("git show: %w", err)}
+		}
+		if len(show) > maxAIDiff {
+			show = show[:maxAIDiff]
+		}
+		summary, err := ai.ExplainCommit(show)
+		return shared.CommitExplainedMsg{Hash: hash, Summary: summary, Err: err}
+	}
+}
+
+func copyPatchCmd(repoPath, hash string) tea.Cmd {
+	return func() tea.Msg {
+		patch, err := git.FormatPatch(repoPath, hash)
+		if err == nil {
+			err = ai.CopyToClipboard(patch)
+		}
+		return shared.PatchCopiedMsg{Hash: hash, Bytes: len(patch), Err: err}
+	}
+}
+
+// applyPatchCmd reads a patch from path, or the clipboard when it's empty,
+// and applies it to repoPath if a dry run passes. When it doesn't, the
+// patch comes back with the rejected hunks so a three-way merge can be
+// offered.
+func applyPatchCmd(repoPath, path string) tea.Cmd {
+	return func() tea.Msg {
+		source, patch, err := "the clipboard", "", error(nil)
+		if path != "" {
+			source = path
+			if strings.HasPrefix(path, "~/") {
+				if home, herr := os.UserHomeDir(); herr == nil {
+					path = filepath.Join(home, path[2:])
+				}
+			}
+			var data []byte
+			data, err = os.ReadFile(path)
+			patch = string(data)
+		} else {
+			patch, err = ai.ReadClipboard()
+		}
+		msg := shared.PatchAppliedMsg{RepoPath: repoPath, Source: source, Patch: patch, Err: err}
+		if err != nil {
+			return msg
+		}
+		if !strings.Contains(patch, "\n@@ ") {
+			msg.Err = errors.New("no patch found (expected git diff or format-patch output)")
+			return msg
+		}
+		msg.Files = strings.Count("\n"+patch, "\ndiff --git ")
+		if rejected, err := git.CheckPatch(repoPath, patch); err != nil {
+			msg.Rejected = append([]string{}, rejected...)
+			return msg
+		}
+		_, msg.Err = git.ApplyPatch(repoPath, patch, false)
+		return msg
+	}
+}
+
+func threeWayApplyCmd(repoPath, source, patch string) tea.Cmd {
+	return func() tea.Msg {
+		conflicts, err := git.ApplyPatch(repoPath, patch, true)
+		return shared.PatchAppliedMsg{
+			RepoPath: repoPath, Source: source, Patch: patch, ThreeWay: true,
+			Files: strings.Count("\n"+patch, "\ndiff --git "), Conflicts: conflicts, Err: err,
+		}
+	}
+}
+
+func mergedBranchesCmd(repoPath string) tea.Cmd {
+	return func() tea.Msg {
+		branches, err := git.MergedBranches(repoPath, "")
+		return shared.MergedBranchesMsg{RepoPath: repoPath, Branches: branches, Err: err}
+	}
+}
+
+func deleteBranchesCmd(repoPath string, names []string) tea.Cmd {
+	return func() tea.Msg {
+		var msg shared.BranchesDeletedMsg
+		for _, name := range names {
+			if err := git.DeleteBranch(repoPath, name); err != nil {
+				if msg.Err == nil {
+					msg.Err = err
+				}
+				continue
+			}
+			msg.Deleted = append(msg.Deleted, name)
+		}
+		return msg
+	}
+}
+
+func createBranchCmd(repoPath, branchName, startPoint string) tea.Cmd {
 	return func() tea.Msg {
-		err := git.CreateBranch(repoPath, branchName)
+		err := git.CreateBranch(repoPath, branchName, startPoint)
 		return shared.BranchCreatedMsg{Branch: branchName, Err: err}
 	}
 }
 
-func fetchCommitDetailCmd(repoPath, hash string) tea.Cmd {
+func checkoutCommitCmd(repoPath, hash string) tea.Cmd {
+	return func() tea.Msg {
+		err := git.CheckoutDetached(repoPath, hash)
+		return shared.CommitCheckedOutMsg{Hash: hash, Err: err}
+	}
+}
+
+func fetchIncomingCmd(repoPath string) tea.Cmd {
+	return func() tea.Msg {
+		upstream, err := git.Upstream(repoPath)
+		if err != nil {
+			return shared.IncomingFetchedMsg{RepoPath: repoPath, Err: err}
+		}
+		commits, err := git.IncomingCommits(repoPath)
+		return shared.IncomingFetchedMsg{Commits: commits, Upstream: upstream, RepoPath: repoPath, Err: err}
+	}
+}
+
+func fetchReflogCmd(repoPath string) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := git.Reflog(repoPath, 100)
+		return shared.ReflogFetchedMsg{Entries: entries, RepoPath: repoPath, Err: err}
+	}
+}
+
+func fetchTagsCmd(repoPath string) tea.Cmd {
+	return func() tea.Msg {
+		tags, err := git.ListTags(repoPath)
+		return shared.TagsFetchedMsg{RepoPath: repoPath, Tags: tags, Err: err}
+	}
+}
+
+// changelogCmd builds the changelog for from..to and copies it, or adds it
+// to the top of outPath when that's set. A range ending at HEAD is titled
+// Unreleased.
+func changelogCmd(repoPath, from, to, outPath string) tea.Cmd {
+	return func() tea.Msg {
+		commits, err := git.CommitsBetween(repoPath, from, to)
+		if err != nil {
+			return shared.ChangelogBuiltMsg{Err: err}
+		}
+		title := to
+		if to == "HEAD" {
+			title = "Unreleased"
+		}
+		entry := changelog.Entry(title, commits)
+		if outPath != "" {
+			existing, err := os.ReadFile(outPath)
+			if err != nil && !os.IsNotExist(err) {
+				return shared.ChangelogBuiltMsg{Err: err}
+			}
+			if err := os.WriteFile(outPath, []byte(changelog.Prepend(string(existing), entry)), 0o644); err != nil {
+				return shared.ChangelogBuiltMsg{Err: fmt.Errorf("writing changelog: %w", err)}
+			}
+		} else if err := ai.CopyToClipboard(entry); err != nil {
+			return shared.ChangelogBuiltMsg{Err: fmt.Errorf("clipboard: %w", err)}
+		}
+		return shared.ChangelogBuiltMsg{Path: outPath, Count: len(commits)}
+	}
+}
+
+func reflogResetCmd(repoPath string, e git.ReflogEntry) tea.Cmd {
+	return func() tea.Msg {
+		err := git.ResetKeep(repoPath, e.Hash)
+		return shared.ReflogRecoveredMsg{Summary: fmt.Sprintf("Reset to %s (%s)", e.Selector, e.Hash), Err: err}
+	}
+}
+
+func reflogBranchCmd(repoPath, branchName string, e git.ReflogEntry) tea.Cmd {
+	return func() tea.Msg {
+		err := git.CreateBranchAt(repoPath, branchName, e.Hash)
+		return shared.ReflogRecoveredMsg{Summary: fmt.Sprintf("Created %s at %s", branchName, e.Hash), Err: err}
+	}
+}
+
+func (a *App) fetchCommitDetailCmd(repoPath, hash string) tea.Cmd {
+	ctx, gen := a.fetches.start(fetchDetail)
+	signatures := a.cfg.Display.ShowSignatures
+	return func() tea.Msg {
+		detail, err := git.GetCommitDetail(ctx, repoPath, hash)
+		if err == nil && signatures {
+			detail.Signature, _ = git.SignatureStatus(ctx, repoPath, hash)
+		}
+		return shared.CommitDetailFetchedMsg{Detail: detail, RepoPath: repoPath, Hash: hash, Gen: gen, Err: err}
+	}
+}
+
+func quickCommitCmd(repoPath, message string, sign bool) tea.Cmd {
+	return func() tea.Msg {
+		if err := git.Commit(repoPath, message, sign); err != nil {
+			return shared.CommitCompleteMsg{Quick: true, Err: err}
+		}
+		hash, _ := git.GetHeadHash(repoPath)
+		return shared.CommitCompleteMsg{Hash: hash, Message: message, Quick: true}
+	}
+}
+
+func squashPrepareCmd(repoPath string, n int) tea.Cmd {
+	return func() tea.Msg {
+		if err := git.CheckSquash(repoPath, n); err != nil {
+			return shared.SquashPreparedMsg{RepoPath: repoPath, N: n, Err: err}
+		}
+		subjects, err := git.RecentSubjects(repoPath, n)
+		ahead, _ := git.AheadBehind(repoPath)
+		return shared.SquashPreparedMsg{RepoPath: repoPath, N: n, Subjects: subjects, Pushed: n > ahead, Err: err}
+	}
+}
+
+func squashCmd(repoPath string, n int, message string, sign bool) tea.Cmd {
 	return func() tea.Msg {
-		detail, err := git.GetCommitDetail(repoPath, hash)
-		return shared.CommitDetailFetchedMsg{Detail: detail, RepoPath: repoPath, Hash: hash, Err: err}
+		if err := git.SquashLast(repoPath, n, message, sign); err != nil {
+			return shared.SquashCompleteMsg{N: n, Err: err}
+		}
+		hash, _ := git.GetHeadHash(repoPath)
+		return shared.SquashCompleteMsg{N: n, Hash: hash}
 	}
 }
 
-func amendCmd(repoPath, message string) tea.Cmd {
+func amendCmd(repoPath, message, author string, resetDate, sign bool) tea.Cmd {
 	return func() tea.Msg {
-		err := git.CommitAmend(repoPath, message)
+		err := git.AmendWithAuthor(repoPath, message, author, resetDate, sign)
 		if err != nil {
 			return shared.CommitCompleteMsg{Err: err}
 		}
 		hash, _ := git.GetHeadHash(repoPath)
-		return shared.CommitCompleteMsg{Hash: hash}
+		return shared.CommitCompleteMsg{Hash: hash, Message: message}
+	}
+}
+
+// prepareAmendCmd checks there's a commit to amend before asking.
+func prepareAmendCmd(repoPath, name string) tea.Cmd {
+	return func() tea.Msg {
+		return shared.AmendPreparedMsg{RepoPath: repoPath, Name: name, NoCommits: !git.HasCommits(repoPath)}
+	}
+}
+
+func amendNoEditCmd(repoPath string, sign bool) tea.Cmd {
+	return func() tea.Msg {
+		if err := git.AmendNoEdit(repoPath, sign); err != nil {
+			return shared.AmendCompleteMsg{Err: err}
+		}
+		hash, _ := git.GetHeadHash(repoPath)
+		return shared.AmendCompleteMsg{Hash: hash}
 	}
 }
 
@@ -1594,6 +4181,26 @@ func pushCmd(repoPath, branch string) tea.Cmd {
 	}
 }
 
+// prepareUndoCommitCmd reads what undoing HEAD would take off the branch,
+// for the confirm prompt.
+func prepareUndoCommitCmd(repoPath, name string) tea.Cmd {
+	return func() tea.Msg {
+		msg := shared.UndoCommitPreparedMsg{RepoPath: repoPath, Name: name}
+		if !git.HasCommits(repoPath) {
+			msg.NoCommits = true
+			return msg
+		}
+		if git.IsRootCommit(repoPath) {
+			msg.Root = true
+			return msg
+		}
+		msg.Hash, msg.Subject, _ = git.HeadSubject(repoPath)
+		ahead, _ := git.AheadBehind(repoPath)
+		msg.Pushed = ahead == 0
+		return msg
+	}
+}
+
 func undoCommitCmd(repoPath string) tea.Cmd {
 	return func() tea.Msg {
 		hash, err := git.UndoLastCommit(repoPath)
@@ -1601,7 +4208,17 @@ func undoCommitCmd(repoPath string) tea.Cmd {
 	}
 }
 
-func generateCommitMsgCmd(repoPath string) tea.Cmd {
+// generatingMsg reports whether an AI commit message is on its way, so
+// a second request doesn't interleave with its stream.
+func (a App) generatingMsg() bool {
+	_, busy := a.spinners[shared.OpGenerate]
+	return busy
+}
+
+// generateCommitMsgCmd asks for a message for the staged diff. With
+// previous set it asks for a different variant than that one. The message
+// streams in as aiChunkMsgs before the final shared.AICommitMsgMsg.
+func generateCommitMsgCmd(repoPath, previous string) tea.Cmd {
 	return func() tea.Msg {
 		diff, err := git.RunGit(repoPath, "diff", "--cached")
 		if err != nil {
@@ -1610,15 +4227,29 @@ func generateCommitMsgCmd(repoPath string) tea.Cmd {
 		if strings.TrimSpace(diff) == "" {
 			return shared.AICommitMsgMsg{Err: fmt.Errorf("no staged changes")}
 		}
-		msg, err := ai.GenerateCommitMessage(diff)
-		return shared.AICommitMsgMsg{Message: msg, Err: err}
+		stream := make(chan tea.Msg, 16)
+		go func() {
+			msg, err := ai.StreamCommitMessage(diff, previous, func(chunk string) {
+				stream <- aiChunkMsg{text: chunk, stream: stream}
+			})
+			stream <- shared.AICommitMsgMsg{Message: msg, Err: err}
+		}()
+		return <-stream
+	}
+}
+
+// waitForAIStreamCmd delivers the next message of an AI stream.
+func waitForAIStreamCmd(stream <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-stream
 	}
 }
 
-func fetchCommitViewContextCmd(repoPath, conductorPath string) tea.Cmd {
+func fetchCommitViewContextCmd(repoPath, conductorPath, templatePath string) tea.Cmd {
 	return func() tea.Msg {
 		stats, _ := git.GetStagedDiffStats(repoPath)
 		recent, _ := git.GetRecentCommitsByCount(repoPath, 5)
+		template, templateErr := git.CommitTemplate(repoPath, templatePath)
 
 		var features []conductor.FeatureMatch
 		db, err := conductor.Open(conductorPath)
@@ -1639,15 +4270,18 @@ func fetchCommitViewContextCmd(repoPath, conductorPath string) tea.Cmd {
 			StagedStats:        stats,
 			RecentCommits:      recent,
 			FeatureSuggestions: features,
+			Template:           template,
+			TemplateErr:        templateErr,
 		}
 	}
 }
 
-func exportContextCmd(cfg config.Config, days int) tea.Cmd {
+// exportContextCmd builds the context summary for repos and copies it to the
+// clipboard, or writes it to outPath when set. scope names the project, if any.
+func exportContextCmd(repos []config.RepoConfig, scope string, days int, outPath string) tea.Cmd {
 	return func() tea.Msg {
-		allRepos := cfg.AllRepos()
-		contextRepos := make([]ai.ContextRepo, len(allRepos))
-		for i, repo := range allRepos {
+		contextRepos := make([]ai.ContextRepo, len(repos))
+		for i, repo := range repos {
 			name := filepath.Base(repo.Path)
 			branch, _ := git.RunGit(repo.Path, "rev-parse", "--abbrev-ref", "HEAD")
 			contextRepos[i] = ai.ContextRepo{Name: name, Path: repo.Path, Branch: strings.TrimSpace(branch)}
@@ -1662,10 +4296,89 @@ func exportContextCmd(cfg config.Config, days int) tea.Cmd {
 		var numCommits, numRepos int
 		fmt.Sscanf(summary, "<!-- %d commits across %d repos -->", &numCommits, &numRepos)
 
-		if err := ai.CopyToClipboard(summary); err != nil {
+		if outPath != "" {
+			if err := os.WriteFile(outPath, []byte(summary), 0o644); err != nil {
+				return shared.ContextSummaryCopiedMsg{Err: fmt.Errorf("writing context: %w", err)}
+			}
+		} else if err := ai.CopyToClipboard(summary); err != nil {
 			return shared.ContextSummaryCopiedMsg{Err: fmt.Errorf("clipboard: %w", err)}
 		}
 
-		return shared.ContextSummaryCopiedMsg{Summary: summary, NumCommits: numCommits, NumRepos: numRepos}
+		return shared.ContextSummaryCopiedMsg{Summary: summary, NumCommits: numCommits, NumRepos: numRepos, Days: days, Path: outPath, Scope: scope}
+	}
+}
+
+// notifyCmd rings the bell and sends a desktop notification, when
+// display.notify is on and the terminal isn't focused. Notifications are
+// best effort; failures are ignored.
+func (a App) notifyCmd(title, body string) tea.Cmd {
+	if !a.cfg.Display.Notify || (a.focusReported && a.focused) {
+		return nil
+	}
+	return func() tea.Msg {
+		notify.Bell()
+		notify.Send("gitdash: "+title, body)
+		return nil
+	}
+}
+
+// exportActivityCmd renders a repo's commits from the last days as
+// Markdown and copies it to the clipboard, or writes it to outPath when set.
+func exportActivityCmd(repoPath, repoName string, days int, byType bool, outPath string) tea.Cmd {
+	return func() tea.Msg {
+		branch, _ := git.GetBranch(repoPath)
+		commits, err := git.GetRecentCommits(repoPath, days)
+		if err != nil {
+			return shared.ActivityExportedMsg{Repo: repoName, Err: err}
+		}
+		if len(commits) == 0 {
+			return shared.ActivityExportedMsg{Repo: repoName, Err: fmt.Errorf("no commits in %s in the %s", repoName, daysLabel(days))}
+		}
+		md := activity.Build(repoName, branch, commits, days, byType)
+		if outPath != "" {
+			if err := os.WriteFile(outPath, []byte(md), 0o644); err != nil {
+				return shared.ActivityExportedMsg{Repo: repoName, Err: fmt.Errorf("writing activity: %w", err)}
+			}
+		} else if err := ai.CopyToClipboard(md); err != nil {
+			return shared.ActivityExportedMsg{Repo: repoName, Err: fmt.Errorf("clipboard: %w", err)}
+		}
+		return shared.ActivityExportedMsg{Repo: repoName, Path: outPath, NumCommits: len(commits), Days: days}
+	}
+}
+
+// exportConductorCmd renders data as Markdown and copies it to the
+// clipboard, or writes it to outPath when set.
+func exportConductorCmd(data *conductor.ConductorData, sections conductor.Sections, outPath string) tea.Cmd {
+	return func() tea.Msg {
+		md := conductor.RenderMarkdownSections(data, sections)
+		if outPath != "" {
+			if err := os.WriteFile(outPath, []byte(md), 0o644); err != nil {
+				return shared.ConductorExportedMsg{Err: fmt.Errorf("writing conductor status: %w", err)}
+			}
+		} else if err := ai.CopyToClipboard(md); err != nil {
+			return shared.ConductorExportedMsg{Err: fmt.Errorf("clipboard: %w", err)}
+		}
+		return shared.ConductorExportedMsg{Path: outPath}
+	}
+}
+
+// scanReposCmd finds git repos under root for the first-run wizard.
+func scanReposCmd(root string) tea.Cmd {
+	return func() tea.Msg {
+		var repos []string
+		for _, d := range projectmanager.WalkDirs(root, 3) {
+			if d.HasGit {
+				repos = append(repos, d.AbsPath)
+			}
+		}
+		return shared.SetupScannedMsg{Root: root, Repos: repos}
+	}
+}
+
+// fetchIssuesCmd lists open issues for "#" autocomplete in the commit view.
+func fetchIssuesCmd(repoPath string) tea.Cmd {
+	return func() tea.Msg {
+		issues, err := forge.ListIssues(repoPath)
+		return shared.IssuesFetchedMsg{RepoPath: repoPath, Issues: issues, Err: err}
 	}
 }
@@ -1,6 +1,8 @@
 package branchpicker
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -15,6 +17,7 @@ type Mode int
 const (
 	PickMode   Mode = iota
 	CreateMode
+	PruneMode // confirming deletion of merged branches
 )
 
 type ActionKind int
@@ -24,11 +27,16 @@ const (
 	ActionClose
 	ActionSwitch
 	ActionCreate
+	ActionFindMerged // list the branches merged into the current one
+	ActionPrune      // delete the confirmed merged branches
+	ActionSuggestName
 )
 
 type KeyResult struct {
 	Action     ActionKind
 	BranchName string
+	StartPoint string   // commit to branch from, "" for HEAD
+	Branches   []string // for ActionPrune
 }
 
 var branchPrefixes = []string{"feat/", "fix/", "chore/", "refactor/", ""}
@@ -44,6 +52,11 @@ type Model struct {
 	filterInput textinput.Model
 	createInput textinput.Model
 	prefixIdx   int
+	startPoint  string // set when opened from a graph commit
+	byName      bool   // sort by name instead of most recent commit
+	merged      []string // branches to prune, in PruneMode
+	suggesting  bool     // waiting on an AI branch name
+	suggestErr  string
 
 	width  int
 	height int
@@ -71,17 +84,51 @@ func (m *Model) SetSize(w, h int) {
 
 func (m *Model) SetBranches(branches []git.BranchInfo, repoPath string) {
 	m.branches = branches
+	m.sortBranches()
 	m.repoPath = repoPath
 	m.mode = PickMode
 	m.cursor = 0
 	m.scrollOffset = 0
 	m.prefixIdx = 0
+	m.startPoint = ""
+	m.suggesting = false
+	m.suggestErr = ""
 	m.filterInput.SetValue("")
 	m.filterInput.Focus()
 	m.createInput.SetValue("")
 	m.applyFilter()
 }
 
+// StartCreateAt opens straight into create mode for a branch at hash.
+// Escape closes the picker since no branch list was loaded.
+func (m *Model) StartCreateAt(repoPath, hash string) {
+	m.SetBranches(nil, repoPath)
+	m.startPoint = hash
+	m.mode = CreateMode
+	m.filterInput.Blur()
+	m.createInput.Focus()
+}
+
+// RepoPath returns the repo the picker was opened for.
+func (m Model) RepoPath() string {
+	return m.repoPath
+}
+
+// sortBranches puts the current branch first, then the rest by most recent
+// commit, or by name.
+func (m *Model) sortBranches() {
+	sort.SliceStable(m.branches, func(i, j int) bool {
+		a, b := m.branches[i], m.branches[j]
+		if a.IsCurrent != b.IsCurrent {
+			return a.IsCurrent
+		}
+		if m.byName {
+			return a.Name < b.Name
+		}
+		return a.CommitTime > b.CommitTime
+	})
+}
+
 func (m *Model) applyFilter() {
 	query := strings.ToLower(m.filterInput.Value())
 	if query == "" {
@@ -124,10 +171,13 @@ func (m *Model) ensureCursorVisible() {
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
-	if m.mode == PickMode {
+	switch m.mode {
+	case PruneMode:
+		return m, nil
+	case PickMode:
 		m.filterInput, cmd = m.filterInput.Update(msg)
 		m.applyFilter()
-	} else {
+	default:
 		m.createInput, cmd = m.createInput.Update(msg)
 	}
 	return m, cmd
@@ -139,10 +189,38 @@ func (m *Model) HandleKey(msg tea.KeyMsg) KeyResult {
 		return m.handlePickKey(msg)
 	case CreateMode:
 		return m.handleCreateKey(msg)
+	case PruneMode:
+		switch msg.String() {
+		case "esc", "n":
+			m.mode = PickMode
+			m.filterInput.Focus()
+		case "y", "enter":
+			return KeyResult{Action: ActionPrune, Branches: m.merged}
+		}
 	}
 	return KeyResult{Action: ActionNone}
 }
 
+// SetSuggestedName fills the name input with an AI suggestion, or shows
+// why there isn't one.
+func (m *Model) SetSuggestedName(name string, err error) {
+	m.suggesting = false
+	if err != nil {
+		m.suggestErr = err.Error()
+		return
+	}
+	m.suggestErr = ""
+	m.createInput.SetValue(name)
+	m.createInput.CursorEnd()
+}
+
+// SetMerged shows the merged branches and asks before deleting them.
+func (m *Model) SetMerged(names []string) {
+	m.merged = names
+	m.mode = PruneMode
+	m.filterInput.Blur()
+}
+
 func (m *Model) handlePickKey(msg tea.KeyMsg) KeyResult {
 	switch msg.String() {
 	case "esc", "q":
@@ -161,12 +239,21 @@ func (m *Model) handlePickKey(msg tea.KeyMsg) KeyResult {
 		if m.cursor < len(m.filtered) {
 			return KeyResult{Action: ActionSwitch, BranchName: m.filtered[m.cursor].Name}
 		}
+	case "tab":
+		m.byName = !m.byName
+		m.sortBranches()
+		m.cursor = 0
+		m.applyFilter()
+		m.scrollOffset = 0
+	case "ctrl+p":
+		return KeyResult{Action: ActionFindMerged}
 	case "n":
 		m.mode = CreateMode
 		m.filterInput.Blur()
 		m.createInput.SetValue("")
 		m.createInput.Focus()
 		m.prefixIdx = 0
+		m.suggestErr = ""
 	}
 	return KeyResult{Action: ActionNone}
 }
@@ -174,19 +261,29 @@ func (m *Model) handlePickKey(msg tea.KeyMsg) KeyResult {
 func (m *Model) handleCreateKey(msg tea.KeyMsg) KeyResult {
 	switch msg.String() {
 	case "esc":
+		if m.startPoint != "" {
+			return KeyResult{Action: ActionClose}
+		}
 		m.mode = PickMode
 		m.createInput.Blur()
 		m.filterInput.Focus()
 		return KeyResult{Action: ActionNone}
 	case "tab":
 		m.prefixIdx = (m.prefixIdx + 1) % len(branchPrefixes)
+	case "ctrl+g":
+		if m.suggesting {
+			return KeyResult{Action: ActionNone}
+		}
+		m.suggesting = true
+		m.suggestErr = ""
+		return KeyResult{Action: ActionSuggestName}
 	case "enter":
 		name := strings.TrimSpace(m.createInput.Value())
 		if name == "" {
 			return KeyResult{Action: ActionNone}
 		}
 		prefix := branchPrefixes[m.prefixIdx]
-		return KeyResult{Action: ActionCreate, BranchName: prefix + name}
+		return KeyResult{Action: ActionCreate, BranchName: prefix + name, StartPoint: m.startPoint}
 	}
 	return KeyResult{Action: ActionNone}
 }
@@ -203,15 +300,18 @@ func (m Model) ViewOverlay(background string, w, h int) string {
 func (m Model) renderContent() string {
 	var b strings.Builder
 
-	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("255")).Render("Branches")
+	title := shared.TitleStyle.Render("Branches")
 	b.WriteString(title)
 	b.WriteString(" ")
 	b.WriteString(shared.GraphHashStyle.Render(m.repoPath))
 	b.WriteString("\n\n")
 
-	if m.mode == PickMode {
+	switch m.mode {
+	case PickMode:
 		b.WriteString(m.renderPickMode())
-	} else {
+	case PruneMode:
+		b.WriteString(m.renderPruneMode())
+	default:
 		b.WriteString(m.renderCreateMode())
 	}
 
@@ -243,6 +343,9 @@ func (m Model) renderPickMode() string {
 		if branch.Upstream != "" {
 			line += " " + shared.GraphHashStyle.Render("→ "+branch.Upstream)
 		}
+		if info := branchStatus(branch); info != "" {
+			line += " " + shared.DimFileStyle.Render(info)
+		}
 
 		if i == m.cursor {
 			line = shared.CursorStyle.Render(line)
@@ -257,15 +360,70 @@ func (m Model) renderPickMode() string {
 	}
 
 	b.WriteString("\n")
-	b.WriteString(shared.HelpDescStyle.Render("j/k: navigate  enter: switch  n: new branch  esc: close"))
+	sortLabel := "tab: sort by name"
+	if m.byName {
+		sortLabel = "tab: sort by recent"
+	}
+	b.WriteString(shared.HelpDescStyle.Render("j/k: navigate  enter: switch  n: new branch  " + sortLabel))
+	b.WriteString("\n")
+	b.WriteString(shared.HelpDescStyle.Render("C-p: prune merged branches  esc: close"))
 
 	return b.String()
 }
 
+func (m Model) renderPruneMode() string {
+	var b strings.Builder
+
+	noun := "branches"
+	if len(m.merged) == 1 {
+		noun = "branch"
+	}
+	b.WriteString(shared.TitleStyle.Render(fmt.Sprintf("Delete %d merged %s?", len(m.merged), noun)))
+	b.WriteString("\n\n")
+	const maxShown = 15
+	for i, name := range m.merged {
+		if i == maxShown {
+			b.WriteString(shared.GraphHashStyle.Render(fmt.Sprintf("  … and %d more", len(m.merged)-i)))
+			b.WriteString("\n")
+			break
+		}
+		b.WriteString("  " + shared.BranchItemStyle.Render(name) + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(shared.DimFileStyle.Render("All are fully merged into the current branch; main, master and develop are kept"))
+	b.WriteString("\n\n")
+	b.WriteString(shared.HelpDescStyle.Render("y: delete  esc: back"))
+
+	return b.String()
+}
+
+// branchStatus is the dimmed detail after a branch: how it stands against
+// its upstream and when it last had a commit.
+func branchStatus(b git.BranchInfo) string {
+	var parts []string
+	switch {
+	case b.UpstreamGone:
+		parts = append(parts, "upstream gone")
+	case b.Ahead > 0 && b.Behind > 0:
+		parts = append(parts, fmt.Sprintf("↑%d ↓%d", b.Ahead, b.Behind))
+	case b.Ahead > 0:
+		parts = append(parts, fmt.Sprintf("↑%d", b.Ahead))
+	case b.Behind > 0:
+		parts = append(parts, fmt.Sprintf("↓%d", b.Behind))
+	}
+	if b.LastCommit != "" {
+		parts = append(parts, b.LastCommit)
+	}
+	return strings.Join(parts, " · ")
+}
+
 func (m Model) renderCreateMode() string {
 	var b strings.Builder
 
-	b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("255")).Render("New Branch"))
+	b.WriteString(shared.TitleStyle.Render("New Branch"))
+	if m.startPoint != "" {
+		b.WriteString(" from " + shared.GraphHashStyle.Render(m.startPoint))
+	}
 	b.WriteString("\n\n")
 
 	// Prefix selector
@@ -294,8 +452,16 @@ func (m Model) renderCreateMode() string {
 	}
 
 	b.WriteString(m.createInput.View())
+	switch {
+	case m.suggesting:
+		b.WriteString("\n")
+		b.WriteString(shared.DimFileStyle.Render("suggesting a name from the staged changes..."))
+	case m.suggestErr != "":
+		b.WriteString("\n")
+		b.WriteString(shared.ErrorStyle.Render(m.suggestErr))
+	}
 	b.WriteString("\n\n")
-	b.WriteString(shared.HelpDescStyle.Render("tab: cycle prefix  enter: create  esc: back"))
+	b.WriteString(shared.HelpDescStyle.Render("tab: cycle prefix  C-g: suggest name (AI)  enter: create  esc: back"))
 
 	return b.String()
 }
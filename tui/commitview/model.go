@@ -2,12 +2,16 @@ package commitview
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/dylan/gitdash/conductor"
+	"github.com/dylan/gitdash/forge"
 	"github.com/dylan/gitdash/git"
 	"github.com/dylan/gitdash/tui/shared"
 )
@@ -19,23 +23,85 @@ var conventionalTypes = []string{
 	"ci", "build",
 }
 
+// typeEmoji maps each conventional type to its gitmoji.
+var typeEmoji = map[string]string{
+	"feat":     "✨",
+	"fix":      "🐛",
+	"refactor": "♻️",
+	"docs":     "📝",
+	"test":     "✅",
+	"chore":    "🔧",
+	"perf":     "⚡️",
+	"style":    "🎨",
+	"ci":       "👷",
+	"build":    "📦",
+}
+
 type Model struct {
 	textArea    textarea.Model
 	repo        *git.RepoStatus
 	err         error
 	generating  bool
 	amend       bool
+	allowEmpty  bool
+	sign        bool // sign commits; kept across commits for the session
 	spinnerView string
 	width       int
 	height      int
 
 	// Type selector
-	selectedType int // index into conventionalTypes, -1 = none
+	selectedType int  // index into conventionalTypes, -1 = none
+	emoji        bool // prepend the type's gitmoji
+	emojiOnly    bool // with emoji, drop the text type
+	sortStaged   string
 
 	// Right panel context data
 	stagedStats        []git.CommitFileStat
 	recentCommits      []git.RecentCommitInfo
 	featureSuggestions []conductor.FeatureMatch
+
+	// Issue autocomplete, opened by typing "#"
+	issues    []forge.Issue
+	acActive  bool
+	acQuery   string
+	acMatches []forge.Issue
+	acCursor  int
+	acScroll  int
+
+	// Recent message recall: recallIdx indexes recentCommits (-1 = none yet),
+	// recalled is the text last loaded so it can be replaced without asking
+	recallIdx     int
+	recalled      string
+	recallPending bool // typed text would be replaced; next recall confirms
+
+	// AI suggestions: aiLast is the latest one, aiPrev what it replaced, so
+	// a regenerated message that's worse can be swapped back
+	aiLast string
+	aiPrev string
+
+	// While generating: the text before it started, and the message
+	// streamed in so far
+	preGen   string
+	streamed string
+
+	// Validation warnings for warnedMsg; submitting it again overrides them
+	violations []string
+	warnedMsg  string
+
+	// Amend-only overrides: author is pre-filled from HEAD and only passed
+	// on when edited
+	authorInput   textinput.Model
+	editingAuthor bool
+	origAuthor    string
+	resetDate     bool
+
+	// Templates: prefilled is what Prefill put in, so a template can still
+	// follow it; templated marks a message built from a template, whose
+	// "#" lines are instructions to strip on submit
+	templateInput   textinput.Model
+	editingTemplate bool
+	prefilled       string
+	templated       bool
 }
 
 func New() Model {
@@ -49,10 +115,23 @@ func New() Model {
 
 	// Disable ctrl+a (LineStart) so it falls through to AmendToggle
 	ta.KeyMap.LineStart.SetEnabled(false)
+	// Keep only "end" for LineEnd so ctrl+e falls through to AllowEmpty
+	ta.KeyMap.LineEnd.SetKeys("end")
+
+	ai := textinput.New()
+	ai.Placeholder = "Name <email>"
+	ai.CharLimit = 200
+
+	ti := textinput.New()
+	ti.Placeholder = "template file (empty: git's commit.template)"
+	ti.CharLimit = 300
 
 	return Model{
-		textArea:     ta,
-		selectedType: -1,
+		textArea:      ta,
+		authorInput:   ai,
+		templateInput: ti,
+		selectedType:  -1,
+		recallIdx:     -1,
 	}
 }
 
@@ -85,6 +164,12 @@ func (m *Model) recalcTextArea() {
 
 	// overhead: header(2) + type selector(3) + spacing(1) + info bar(1) + help(2) + padding(3) = 12
 	overhead := 12
+	if m.amend {
+		overhead += 2 // author/date line
+	}
+	if m.editingTemplate {
+		overhead += 2 // template path line
+	}
 	taH := m.height - overhead
 	if taH < 3 {
 		taH = 3
@@ -99,10 +184,28 @@ func (m *Model) SetRepo(repo *git.RepoStatus) {
 	m.repo = repo
 	m.err = nil
 	m.amend = false
+	m.allowEmpty = false
 	m.selectedType = -1
 	m.stagedStats = nil
 	m.recentCommits = nil
 	m.featureSuggestions = nil
+	m.issues = nil
+	m.acActive = false
+	m.violations = nil
+	m.recallIdx = -1
+	m.recalled = ""
+	m.recallPending = false
+	m.aiLast = ""
+	m.aiPrev = ""
+	m.editingAuthor = false
+	m.origAuthor = ""
+	m.resetDate = false
+	m.authorInput.SetValue("")
+	m.authorInput.Blur()
+	m.editingTemplate = false
+	m.templateInput.Blur()
+	m.prefilled = ""
+	m.templated = false
 	m.textArea.Reset()
 	m.textArea.Focus()
 	if m.width > 0 && m.height > 0 {
@@ -111,37 +214,392 @@ func (m *Model) SetRepo(repo *git.RepoStatus) {
 }
 
 func (m *Model) SetContextData(stats []git.CommitFileStat, recent []git.RecentCommitInfo, features []conductor.FeatureMatch) {
+	switch m.sortStaged {
+	case "changes":
+		sort.SliceStable(stats, func(i, j int) bool {
+			return stats[i].Added+stats[i].Deleted > stats[j].Added+stats[j].Deleted
+		})
+	case "path":
+		sort.SliceStable(stats, func(i, j int) bool {
+			return stats[i].Path < stats[j].Path
+		})
+	}
 	m.stagedStats = stats
 	m.recentCommits = recent
 	m.featureSuggestions = features
 }
 
+// SetIssues stores the open issues offered by "#" autocomplete.
+func (m *Model) SetIssues(issues []forge.Issue) {
+	m.issues = issues
+}
+
+// HasIssues reports whether "#" autocomplete has anything to offer.
+func (m Model) HasIssues() bool {
+	return len(m.issues) > 0
+}
+
+// Autocompleting reports whether the issue popup is open.
+func (m Model) Autocompleting() bool {
+	return m.acActive
+}
+
+// StartAutocomplete opens the issue popup after a "#" was typed.
+func (m *Model) StartAutocomplete() {
+	m.acActive = true
+	m.acQuery = ""
+	m.filterIssues()
+}
+
+func (m *Model) filterIssues() {
+	q := strings.ToLower(m.acQuery)
+	m.acMatches = nil
+	for _, is := range m.issues {
+		if q == "" || strings.HasPrefix(fmt.Sprint(is.Number), q) || strings.Contains(strings.ToLower(is.Title), q) {
+			m.acMatches = append(m.acMatches, is)
+		}
+	}
+	m.acCursor = 0
+	m.acScroll = 0
+}
+
+const issueMaxVisible = 6
+
+// HandleAutocompleteKey handles a key while the issue popup is open.
+// Typed characters still reach the textarea and narrow the list.
+func (m *Model) HandleAutocompleteKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.acActive = false
+		return nil
+	case "up", "ctrl+p":
+		if m.acCursor > 0 {
+			m.acCursor--
+			if m.acCursor < m.acScroll {
+				m.acScroll = m.acCursor
+			}
+		}
+		return nil
+	case "down", "ctrl+n":
+		if m.acCursor < len(m.acMatches)-1 {
+			m.acCursor++
+			if m.acCursor >= m.acScroll+issueMaxVisible {
+				m.acScroll = m.acCursor - issueMaxVisible + 1
+			}
+		}
+		return nil
+	case "enter", "tab":
+		if m.acCursor < len(m.acMatches) {
+			m.acceptIssue(m.acMatches[m.acCursor])
+		}
+		m.acActive = false
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.textArea, cmd = m.textArea.Update(msg)
+	switch msg.Type {
+	case tea.KeyRunes:
+		if strings.ContainsRune(string(msg.Runes), ' ') {
+			m.acActive = false
+			break
+		}
+		m.acQuery += string(msg.Runes)
+		m.filterIssues()
+	case tea.KeyBackspace:
+		if m.acQuery == "" {
+			// The "#" itself was deleted
+			m.acActive = false
+			break
+		}
+		r := []rune(m.acQuery)
+		m.acQuery = string(r[:len(r)-1])
+		m.filterIssues()
+	default:
+		m.acActive = false
+	}
+	return cmd
+}
+
+// acceptIssue replaces the typed "#query" with "#<number> <title-slug>".
+func (m *Model) acceptIssue(is forge.Issue) {
+	for range []rune(m.acQuery) {
+		m.textArea, _ = m.textArea.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	m.textArea.InsertString(fmt.Sprintf("%d %s", is.Number, forge.Slug(is.Title)))
+}
+
+// SetViolations shows validation warnings for message.
+func (m *Model) SetViolations(message string, violations []string) {
+	m.violations = violations
+	m.warnedMsg = m.submitKey(message)
+}
+
+// Warned reports whether message was already submitted once and warned
+// about, so a second submit commits it anyway. Editing the amend
+// overrides counts as a new submission.
+func (m Model) Warned(message string) bool {
+	return m.violations != nil && m.warnedMsg == m.submitKey(message)
+}
+
+func (m Model) submitKey(message string) string {
+	author, resetDate := m.AmendOverrides()
+	return fmt.Sprintf("%s\x00%s\x00%t", message, author, resetDate)
+}
+
+func (m Model) renderViolations() string {
+	if len(m.violations) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, v := range m.violations {
+		b.WriteString("  " + shared.CommitWarningStyle.Render("! "+v) + "\n")
+	}
+	b.WriteString("  " + shared.HelpDescStyle.Render("C-y again to commit anyway") + "\n")
+	return b.String()
+}
+
+func (m Model) renderRecallPrompt() string {
+	if !m.recallPending {
+		return ""
+	}
+	return "  " + shared.CommitWarningStyle.Render("C-r again to replace your message with a recent one") + "\n"
+}
+
 func (m *Model) SetError(err error) {
 	m.err = err
+	if m.streamed != "" {
+		// Drop the half-streamed message
+		m.textArea.SetValue(m.preGen)
+		m.streamed = ""
+	}
 }
 
 func (m *Model) SetGenerating(v bool) {
 	m.generating = v
-	if !v {
+	if v {
+		m.preGen = m.Value()
+		m.streamed = ""
+	} else {
 		m.spinnerView = ""
 	}
 }
 
+// AppendAIChunk shows the AI message streamed in so far in place of the
+// spinner.
+func (m *Model) AppendAIChunk(chunk string) {
+	m.streamed += chunk
+	m.textArea.SetValue(m.streamed)
+	m.textArea.CursorEnd()
+}
+
 func (m *Model) SetSpinnerView(view string) {
 	m.spinnerView = view
 }
 
+// NextRecall steps back to the next older recent commit, wrapping around.
+// If the textarea holds text the user typed, the first call only asks for
+// confirmation and returns false; calling again proceeds.
+func (m *Model) NextRecall() (git.RecentCommitInfo, bool) {
+	if len(m.recentCommits) == 0 {
+		return git.RecentCommitInfo{}, false
+	}
+	if val := m.Value(); val != "" && val != m.recalled && !m.recallPending {
+		m.recallPending = true
+		return git.RecentCommitInfo{}, false
+	}
+	m.recallPending = false
+	m.recallIdx = (m.recallIdx + 1) % len(m.recentCommits)
+	return m.recentCommits[m.recallIdx], true
+}
+
+// SetRecalledMessage loads a recalled commit message as the starting point.
+func (m *Model) SetRecalledMessage(msg string) {
+	msg = strings.TrimSpace(msg)
+	m.textArea.SetValue(msg)
+	m.textArea.CursorEnd()
+	m.recalled = m.Value()
+	m.templated = false
+	m.detectTypeFromMessage(msg)
+}
+
 func (m *Model) SetAIMessage(msg string) {
+	if m.preGen != "" {
+		m.aiPrev = m.preGen
+	}
+	m.aiLast = msg
+	m.streamed = ""
+	m.templated = false
 	m.textArea.SetValue(msg)
 	m.textArea.CursorStart()
 	m.detectTypeFromMessage(msg)
 }
 
+// AIMessage returns the latest AI suggestion, or "" if there's none yet.
+func (m Model) AIMessage() string {
+	return m.aiLast
+}
+
+// SwapPreviousAI puts back the message a regenerated suggestion replaced,
+// keeping the current text so swapping again returns to it. It reports
+// false when there's nothing to swap with.
+func (m *Model) SwapPreviousAI() bool {
+	if m.aiPrev == "" {
+		return false
+	}
+	prev := m.aiPrev
+	m.aiPrev = m.Value()
+	m.textArea.SetValue(prev)
+	m.textArea.CursorStart()
+	m.detectTypeFromMessage(prev)
+	return true
+}
+
+// Prefill seeds an empty message with a template (e.g. a ticket reference),
+// leaving the cursor after it. Existing text is never overwritten.
+func (m *Model) Prefill(text string) {
+	if text == "" || m.textArea.Value() != "" {
+		return
+	}
+	m.textArea.SetValue(text)
+	m.textArea.CursorEnd()
+	m.prefilled = text
+}
+
+// ApplyTemplate fills the message with a commit template (after any
+// Prefill text) unless something else has been put there already: typed
+// text, an AI suggestion or an amended message.
+func (m *Model) ApplyTemplate(template string) {
+	if strings.TrimSpace(template) == "" || m.amend || m.generating || m.aiLast != "" ||
+		m.textArea.Value() != m.prefilled {
+		return
+	}
+	m.setTemplate(m.prefilled + template)
+}
+
+// LoadTemplate replaces the message with a template the user picked.
+func (m *Model) LoadTemplate(template string) {
+	m.setTemplate(template)
+}
+
+// setTemplate puts template in the textarea with the cursor at the end of
+// its first line, where the subject goes.
+func (m *Model) setTemplate(template string) {
+	m.textArea.SetValue(template)
+	for m.textArea.Line() > 0 {
+		m.textArea.CursorUp()
+	}
+	m.textArea.CursorEnd()
+	m.templated = true
+	m.detectTypeFromMessage(template)
+}
+
+// Templated reports whether the message came from a template, so its
+// comment lines should be stripped before committing.
+func (m Model) Templated() bool {
+	return m.templated
+}
+
+// ToggleTemplateEdit moves focus between the message and the template
+// path field.
+func (m *Model) ToggleTemplateEdit() {
+	m.editingTemplate = !m.editingTemplate
+	if m.editingTemplate {
+		m.textArea.Blur()
+		m.templateInput.Focus()
+	} else {
+		m.templateInput.Blur()
+		m.textArea.Focus()
+	}
+	m.recalcTextArea()
+}
+
+// EditingTemplate returns true while the template path field has focus.
+func (m Model) EditingTemplate() bool {
+	return m.editingTemplate
+}
+
+// TemplatePath returns the template file typed in the path field.
+func (m Model) TemplatePath() string {
+	return strings.TrimSpace(m.templateInput.Value())
+}
+
+// TicketPrefix extracts a ticket from branch using pattern (first capture
+// group if present, else the whole match) and expands template with it.
+// Returns "" when nothing matches or the pattern is invalid.
+func TicketPrefix(branch, pattern, template string) string {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return ""
+	}
+	match := re.FindStringSubmatch(branch)
+	if match == nil {
+		return ""
+	}
+	ticket := match[0]
+	if len(match) > 1 && match[1] != "" {
+		ticket = match[1]
+	}
+	out := strings.ReplaceAll(template, "{{ticket}}", ticket)
+	return strings.ReplaceAll(out, "{{branch}}", branch)
+}
+
 func (m *Model) ToggleAmend() {
 	m.amend = !m.amend
+	if !m.amend {
+		m.editingAuthor = false
+		m.resetDate = false
+		m.authorInput.Blur()
+		m.textArea.Focus()
+	}
+	m.recalcTextArea()
+}
+
+// SetAmendAuthor pre-fills the author override with HEAD's author.
+func (m *Model) SetAmendAuthor(author string) {
+	m.origAuthor = author
+	m.authorInput.SetValue(author)
+}
+
+// ToggleAuthorEdit moves focus between the message and the author field.
+func (m *Model) ToggleAuthorEdit() {
+	if !m.amend {
+		return
+	}
+	m.editingAuthor = !m.editingAuthor
+	if m.editingAuthor {
+		m.textArea.Blur()
+		m.authorInput.Focus()
+	} else {
+		m.authorInput.Blur()
+		m.textArea.Focus()
+	}
+}
+
+// EditingAuthor returns true while the author field has focus.
+func (m Model) EditingAuthor() bool {
+	return m.editingAuthor
+}
+
+// ToggleResetDate flips whether amending resets the author date to now.
+func (m *Model) ToggleResetDate() {
+	if m.amend {
+		m.resetDate = !m.resetDate
+	}
+}
+
+// AmendOverrides returns the author to set (empty when unchanged) and
+// whether to reset the author date.
+func (m Model) AmendOverrides() (author string, resetDate bool) {
+	author = strings.TrimSpace(m.authorInput.Value())
+	if author == m.origAuthor {
+		author = ""
+	}
+	return author, m.resetDate
 }
 
 func (m *Model) SetAmendMessage(msg string) {
+	m.templated = false
 	m.textArea.SetValue(msg)
 	m.textArea.CursorStart()
 	m.detectTypeFromMessage(msg)
@@ -151,8 +609,45 @@ func (m Model) IsAmend() bool {
 	return m.amend
 }
 
+func (m *Model) ToggleAllowEmpty() {
+	m.allowEmpty = !m.allowEmpty
+}
+
+func (m *Model) SetAllowEmpty(v bool) {
+	m.allowEmpty = v
+}
+
+func (m Model) AllowEmpty() bool {
+	return m.allowEmpty
+}
+
+// SetSign sets whether commits are signed. Unlike the other options it
+// isn't reset when the view opens, so a toggle lasts the session.
+func (m *Model) SetSign(v bool) {
+	m.sign = v
+}
+
+func (m *Model) ToggleSign() {
+	m.sign = !m.sign
+}
+
+func (m Model) Sign() bool {
+	return m.sign
+}
+
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if _, ok := msg.(tea.KeyMsg); ok {
+		m.recallPending = false
+	}
 	var cmd tea.Cmd
+	if m.editingAuthor {
+		m.authorInput, cmd = m.authorInput.Update(msg)
+		return m, cmd
+	}
+	if m.editingTemplate {
+		m.templateInput, cmd = m.templateInput.Update(msg)
+		return m, cmd
+	}
 	m.textArea, cmd = m.textArea.Update(msg)
 	return m, cmd
 }
@@ -161,6 +656,19 @@ func (m Model) Value() string {
 	return strings.TrimSpace(m.textArea.Value())
 }
 
+// SetEmoji enables gitmoji prefixes. With only set, the emoji replaces the
+// text type ("✨ add x") instead of preceding it ("✨ feat: add x").
+func (m *Model) SetEmoji(enabled, only bool) {
+	m.emoji = enabled
+	m.emojiOnly = enabled && only
+}
+
+// SetSortStaged sets the staged files order: "changes" puts the largest
+// changes first, "path" sorts alphabetically, anything else keeps git's order.
+func (m *Model) SetSortStaged(mode string) {
+	m.sortStaged = mode
+}
+
 // CycleTypeForward cycles to the next conventional commit type.
 func (m *Model) CycleTypeForward() {
 	m.selectedType++
@@ -189,19 +697,42 @@ func (m *Model) applyTypePrefix() {
 		// No type selected — just use stripped message
 		m.textArea.SetValue(stripped)
 	} else {
-		typeName := conventionalTypes[m.selectedType]
-		if stripped == "" {
-			m.textArea.SetValue(typeName + ": ")
-		} else {
-			m.textArea.SetValue(typeName + ": " + stripped)
-		}
+		prefix := m.typePrefix(conventionalTypes[m.selectedType])
+		m.textArea.SetValue(prefix + stripped)
 	}
 	// Move cursor to end of first line
 	m.textArea.CursorEnd()
 }
 
-// detectTypeFromMessage auto-selects a type badge if the message starts with a conventional prefix.
+// typePrefix returns the message prefix for a type in the current emoji mode.
+func (m Model) typePrefix(typeName string) string {
+	switch {
+	case m.emojiOnly:
+		return typeEmoji[typeName] + " "
+	case m.emoji:
+		return typeEmoji[typeName] + " " + typeName + ": "
+	}
+	return typeName + ": "
+}
+
+// splitEmoji removes a leading gitmoji (and the space after it) from msg,
+// returning the type it stands for, or "" if msg doesn't start with one.
+func splitEmoji(msg string) (typeName, rest string) {
+	for _, t := range conventionalTypes {
+		// Match with or without the U+FE0F variation selector
+		base := strings.TrimSuffix(typeEmoji[t], "\ufe0f")
+		if strings.HasPrefix(msg, base) {
+			rest = strings.TrimPrefix(msg[len(base):], "\ufe0f")
+			return t, strings.TrimLeft(rest, " ")
+		}
+	}
+	return "", msg
+}
+
+// detectTypeFromMessage auto-selects a type badge if the message starts with a conventional prefix
+// or a gitmoji.
 func (m *Model) detectTypeFromMessage(msg string) {
+	emojiType, msg := splitEmoji(msg)
 	lower := strings.ToLower(msg)
 	for i, t := range conventionalTypes {
 		if strings.HasPrefix(lower, t+":") || strings.HasPrefix(lower, t+"(") {
@@ -209,11 +740,19 @@ func (m *Model) detectTypeFromMessage(msg string) {
 			return
 		}
 	}
+	for i, t := range conventionalTypes {
+		if t == emojiType {
+			m.selectedType = i
+			return
+		}
+	}
 	m.selectedType = -1
 }
 
-// stripConventionalPrefix removes a leading "type: " or "type(scope): " from a message.
+// stripConventionalPrefix removes a leading "type: " or "type(scope): " from a message,
+// along with any gitmoji before it.
 func stripConventionalPrefix(msg string) string {
+	_, msg = splitEmoji(msg)
 	lower := strings.ToLower(msg)
 	for _, t := range conventionalTypes {
 		if strings.HasPrefix(lower, t+":") {
@@ -259,10 +798,15 @@ func (m Model) renderSingleColumn() string {
 	b.WriteString("\n")
 	b.WriteString(m.renderHeader())
 	b.WriteString("\n\n")
+	b.WriteString(m.renderAmendOptions())
+	b.WriteString(m.renderTemplatePrompt())
 	b.WriteString(m.renderTypeSelector(m.width - 4))
 	b.WriteString("\n")
 	b.WriteString(m.renderTextAreaOrSpinner())
 	b.WriteString("\n")
+	b.WriteString(m.renderIssuePopup())
+	b.WriteString(m.renderViolations())
+	b.WriteString(m.renderRecallPrompt())
 
 	if m.err != nil {
 		b.WriteString("  " + shared.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
@@ -311,10 +855,15 @@ func (m Model) renderLeftPanel(w int) string {
 	b.WriteString("\n")
 	b.WriteString(m.renderHeader())
 	b.WriteString("\n\n")
+	b.WriteString(m.renderAmendOptions())
+	b.WriteString(m.renderTemplatePrompt())
 	b.WriteString(m.renderTypeSelector(w - 4))
 	b.WriteString("\n")
 	b.WriteString(m.renderTextAreaOrSpinner())
 	b.WriteString("\n")
+	b.WriteString(m.renderIssuePopup())
+	b.WriteString(m.renderViolations())
+	b.WriteString(m.renderRecallPrompt())
 
 	if m.err != nil {
 		b.WriteString("  " + shared.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
@@ -328,6 +877,39 @@ func (m Model) renderLeftPanel(w int) string {
 	return b.String()
 }
 
+// renderIssuePopup lists matching issues under the textarea while "#"
+// autocomplete is open.
+func (m Model) renderIssuePopup() string {
+	if !m.acActive {
+		return ""
+	}
+	if len(m.acMatches) == 0 {
+		return shared.HelpDescStyle.Render("  no matching issues") + "\n"
+	}
+
+	var b strings.Builder
+	end := m.acScroll + issueMaxVisible
+	if end > len(m.acMatches) {
+		end = len(m.acMatches)
+	}
+	for i := m.acScroll; i < end; i++ {
+		is := m.acMatches[i]
+		line := fmt.Sprintf("  #%d %s", is.Number, is.Title)
+		if i == m.acCursor {
+			line = shared.CursorStyle.Render(line)
+		} else {
+			line = shared.DimFileStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if remaining := len(m.acMatches) - end; remaining > 0 {
+		b.WriteString(shared.HelpDescStyle.Render(fmt.Sprintf("  %d more...", remaining)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
 func (m Model) renderHeader() string {
 	if m.repo == nil {
 		return ""
@@ -335,24 +917,69 @@ func (m Model) renderHeader() string {
 	action := "Commit to"
 	if m.amend {
 		action = "Amend on"
+	} else if m.allowEmpty {
+		action = "Empty commit to"
+	}
+	if m.sign {
+		action = "Signed " + strings.ToLower(action[:1]) + action[1:]
 	}
-	return shared.CommitHeaderStyle.Render(fmt.Sprintf("  %s: %s [%s]", action, m.repo.Name, m.repo.Branch))
+	return shared.CommitHeaderStyle.Render(fmt.Sprintf("  %s: %s [%s]", action, m.repo.Name, m.repo.BranchLabel()))
+}
+
+// renderAmendOptions shows the author and date overrides in amend mode.
+func (m Model) renderAmendOptions() string {
+	if !m.amend {
+		return ""
+	}
+	author := m.authorInput.View()
+	if !m.editingAuthor {
+		author = shared.HelpDescStyle.Render(m.authorInput.Value())
+		if a, _ := m.AmendOverrides(); a != "" {
+			author = shared.CommitWarningStyle.Render(a)
+		}
+	}
+	date := shared.HelpDescStyle.Render("kept")
+	if m.resetDate {
+		date = shared.CommitWarningStyle.Render("reset to now")
+	}
+	return "  Author: " + author + "   Date: " + date + "\n\n"
+}
+
+// renderTemplatePrompt shows the template path field while it's open.
+func (m Model) renderTemplatePrompt() string {
+	if !m.editingTemplate {
+		return ""
+	}
+	return "  Template: " + m.templateInput.View() + "\n\n"
 }
 
 func (m Model) renderTypeSelector(maxW int) string {
 	var badges []string
+	var widths []int
 	for i, t := range conventionalTypes {
+		label := t
+		if m.emoji {
+			label = typeEmoji[t] + " " + t
+		}
 		var badge string
 		if i == m.selectedType {
 			style, ok := shared.PrefixBadgeStyles[t]
 			if !ok {
 				style = shared.PrefixBadgeFallback
 			}
-			badge = style.Render(t)
+			badge = style.Render(label)
 		} else {
-			badge = shared.CommitTypeDimStyle.Render(t)
+			badge = shared.CommitTypeDimStyle.Render(label)
 		}
 		badges = append(badges, badge)
+
+		// Emoji render two cells wide, but variation-selector forms like
+		// ♻️ are measured as one, so count them explicitly.
+		bw := lipgloss.Width(badge)
+		if m.emoji {
+			bw += 2 - lipgloss.Width(typeEmoji[t])
+		}
+		widths = append(widths, bw)
 	}
 
 	// Flow badges into rows that fit within maxW
@@ -361,8 +988,8 @@ func (m Model) renderTypeSelector(maxW int) string {
 	currentW := 0
 	indent := "  "
 
-	for _, badge := range badges {
-		bw := lipgloss.Width(badge)
+	for i, badge := range badges {
+		bw := widths[i]
 		if currentW > 0 && currentW+bw+1 > maxW {
 			rows = append(rows, indent+strings.Join(currentRow, " "))
 			currentRow = nil
@@ -383,7 +1010,7 @@ func (m Model) renderTypeSelector(maxW int) string {
 }
 
 func (m Model) renderTextAreaOrSpinner() string {
-	if m.generating {
+	if m.generating && m.streamed == "" {
 		spinLabel := "Generating commit message..."
 		if m.spinnerView != "" {
 			spinLabel = m.spinnerView + " " + spinLabel
@@ -425,9 +1052,30 @@ func (m Model) renderInfoBar() string {
 func (m Model) renderHelp() string {
 	amendHint := "C-a: amend"
 	if m.amend {
-		amendHint = "C-a: new commit"
+		amendHint = "C-a: new commit  C-o: author  C-g: reset date"
+		if m.editingAuthor {
+			amendHint = "C-a: new commit  C-o/enter: back to message  C-g: reset date"
+		}
+	}
+	emptyHint := "C-e: allow empty"
+	if m.allowEmpty {
+		emptyHint = "C-e: require staged"
+	}
+	signHint := "C-s: sign"
+	if m.sign {
+		signHint = "C-s: don't sign"
 	}
-	return shared.HelpDescStyle.Render(fmt.Sprintf("  C-y: commit  tab: AI  C-t: type  %s  esc: cancel", amendHint))
+	if m.editingTemplate {
+		return shared.HelpDescStyle.Render("  enter: load template  C-l/esc: back to message")
+	}
+	aiHint := "tab: AI"
+	if m.aiLast != "" {
+		aiHint = "S-tab: AI again"
+		if m.aiPrev != "" {
+			aiHint += "  C-b: previous"
+		}
+	}
+	return shared.HelpDescStyle.Render(fmt.Sprintf("  C-y: commit  %s  C-t: type  C-r: recall  C-l: template  %s  %s  %s  esc: cancel", aiHint, amendHint, emptyHint, signHint))
 }
 
 // --- Right Panel ---
@@ -493,6 +1141,9 @@ func (m Model) renderStagedFilesSection(w int) string {
 			}
 			path := shared.RenderPath(s.Path)
 			stats := ""
+			if s.Binary {
+				stats = shared.MutedFileStyle.Render("binary")
+			}
 			if s.Added > 0 {
 				stats += shared.CommitStatAddStyle.Render(fmt.Sprintf("+%d", s.Added))
 			}
@@ -598,6 +1249,13 @@ func (m Model) renderFeatureSuggestionsSection(w int) string {
 
 // styleCommitMessage applies conventional commit badge styling to a message.
 func styleCommitMessage(msg string) string {
+	if t, rest := splitEmoji(msg); t != "" {
+		emoji := msg[:len(msg)-len(rest)]
+		if styled := styleCommitMessage(rest); styled != rest {
+			return emoji + styled
+		}
+		return msg
+	}
 	lower := strings.ToLower(msg)
 	for _, t := range conventionalTypes {
 		for _, suffix := range []string{":", "("} {
@@ -0,0 +1,107 @@
+package conductorexport
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dylan/gitdash/conductor"
+	"github.com/dylan/gitdash/tui/shared"
+)
+
+type ActionKind int
+
+const (
+	ActionNone ActionKind = iota
+	ActionClose
+	ActionCopy
+	ActionWrite
+)
+
+type KeyResult struct {
+	Action   ActionKind
+	Sections conductor.Sections
+}
+
+var labels = []string{"Session & handoff", "Features by phase", "Open quality issues", "Recent memories"}
+
+// Model is a small menu for picking which conductor sections go into the
+// Markdown export, and whether it's copied or written to a file.
+type Model struct {
+	cursor  int
+	checked [4]bool
+	outPath string
+}
+
+func New() Model {
+	return Model{checked: [4]bool{true, true, true, true}}
+}
+
+// Open shows the menu, keeping the sections picked last time. outPath is
+// where "write" puts the file.
+func (m *Model) Open(outPath string) {
+	m.cursor = 0
+	m.outPath = outPath
+}
+
+func (m Model) sections() conductor.Sections {
+	return conductor.Sections{
+		Session:  m.checked[0],
+		Features: m.checked[1],
+		Quality:  m.checked[2],
+		Memories: m.checked[3],
+	}
+}
+
+func (m *Model) HandleKey(msg tea.KeyMsg) KeyResult {
+	switch msg.String() {
+	case "esc", "q":
+		return KeyResult{Action: ActionClose}
+	case "j", "down":
+		if m.cursor < len(labels)-1 {
+			m.cursor++
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case " ", "x":
+		m.checked[m.cursor] = !m.checked[m.cursor]
+	case "enter", "y":
+		return KeyResult{Action: ActionCopy, Sections: m.sections()}
+	case "w":
+		return KeyResult{Action: ActionWrite, Sections: m.sections()}
+	}
+	return KeyResult{Action: ActionNone}
+}
+
+func (m Model) ViewOverlay(background string, w, h int) string {
+	overlay := shared.BranchPickerOverlayStyle.Render(m.renderContent())
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color("0")),
+	)
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+
+	b.WriteString(shared.TitleStyle.Render("Export Conductor Status"))
+	b.WriteString("\n\n")
+	for i, label := range labels {
+		box := "[ ]"
+		if m.checked[i] {
+			box = shared.StagedFileStyle.Render("[x]")
+		}
+		line := box + " " + label
+		if i == m.cursor {
+			line = shared.CursorStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(shared.HelpDescStyle.Render("space: toggle  enter: copy  w: write to " + m.outPath))
+	b.WriteString("\n")
+	b.WriteString(shared.HelpDescStyle.Render("esc: cancel"))
+	return b.String()
+}
@@ -2,6 +2,8 @@ package conductorpane
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -19,11 +21,18 @@ const (
 	DetailSection
 )
 
+// maxFeatureCommits is how many of a feature's commits its detail lists.
+const maxFeatureCommits = 5
+
+// progressWidth is the width of the feature progress bar in the header.
+const progressWidth = 8
+
 type ItemKind int
 
 const (
 	SectionSpacer ItemKind = iota
 	FeatureHeader
+	PhaseHeader // per-phase group inside Features, when there's more than one phase
 	FeatureItem
 	SessionHeader
 	HandoffItem
@@ -40,16 +49,19 @@ type FlatItem struct {
 	Handoff *conductor.Handoff
 	Quality *conductor.QualityReflection
 	Memory  *conductor.Memory
+	Phase   int    // for PhaseHeader
 	Label   string // suffix text for headers, or pre-built label for handoff/quality lines
 }
 
 type Model struct {
-	flatItems    []FlatItem
-	collapsed    map[ItemKind]bool
-	cursor       int
-	scrollOffset int
-	width        int
-	height       int
+	flatItems      []FlatItem
+	collapsed      map[ItemKind]bool
+	phaseCollapsed map[int]bool
+	memoryTag      string // when set, only memories with this tag are listed
+	cursor         int
+	scrollOffset   int
+	width          int
+	height         int
 
 	activeSection Section
 	detailVP      viewport.Model
@@ -63,6 +75,7 @@ func New() Model {
 		collapsed: map[ItemKind]bool{
 			MemoryHeader: true, // memories collapsed by default
 		},
+		phaseCollapsed: make(map[int]bool),
 	}
 }
 
@@ -75,6 +88,9 @@ func (m *Model) SetSize(w, h int) {
 func (m *Model) SetData(data *conductor.ConductorData) {
 	m.data = data
 	m.hasConductor = data != nil
+	if m.memoryTag != "" && !slices.Contains(m.memoryTags(), m.memoryTag) {
+		m.memoryTag = ""
+	}
 	m.rebuildFlatItems()
 	m.updateDetailContent()
 }
@@ -92,6 +108,9 @@ func (m *Model) ToggleCollapse() {
 	case FeatureHeader, SessionHeader, QualityHeader, MemoryHeader:
 		m.collapsed[item.Kind] = !m.collapsed[item.Kind]
 		m.rebuildFlatItems()
+	case PhaseHeader:
+		m.phaseCollapsed[item.Phase] = !m.phaseCollapsed[item.Phase]
+		m.rebuildFlatItems()
 	}
 }
 
@@ -119,6 +138,20 @@ func (m *Model) MoveUp() {
 	m.ensureCursorVisible()
 }
 
+// pageMove moves the list cursor by a paging key's delta.
+func (m *Model) pageMove(delta int) {
+	if len(m.flatItems) == 0 {
+		return
+	}
+	m.cursor = max(0, min(m.cursor+delta, len(m.flatItems)-1))
+	if delta > 0 {
+		m.skipNonSelectable(1)
+	} else {
+		m.skipNonSelectable(-1)
+	}
+	m.ensureCursorVisible()
+}
+
 func (m *Model) skipNonSelectable(dir int) {
 	for m.cursor >= 0 && m.cursor < len(m.flatItems) {
 		if m.flatItems[m.cursor].Kind != SectionSpacer {
@@ -165,7 +198,7 @@ func (m Model) ActiveSection() Section {
 
 // isHeader returns true if the item kind is a section header.
 func isHeader(k ItemKind) bool {
-	return k == FeatureHeader || k == SessionHeader || k == QualityHeader || k == MemoryHeader
+	return k == FeatureHeader || k == PhaseHeader || k == SessionHeader || k == QualityHeader || k == MemoryHeader
 }
 
 // NextSection jumps the cursor to the next section header.
@@ -235,6 +268,11 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch m.activeSection {
 		case ListSection:
+			if delta, ok := shared.PageDelta(msg, m.listHeight(), len(m.flatItems)); ok {
+				m.pageMove(delta)
+				m.updateDetailContent()
+				return m, nil
+			}
 			switch {
 			case key.Matches(msg, shared.Keys.Down):
 				m.MoveDown()
@@ -266,12 +304,20 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				m.NextSection()
 				m.updateDetailContent()
 				return m, nil
+			case key.Matches(msg, shared.Keys.MemoryTag):
+				m.CycleMemoryTag()
+				m.updateDetailContent()
+				return m, nil
 			case key.Matches(msg, shared.Keys.PrevRepo):
 				m.PrevSection()
 				m.updateDetailContent()
 				return m, nil
 			}
 		case DetailSection:
+			if delta, ok := shared.PageDelta(msg, m.detailVP.Height, m.detailVP.TotalLineCount()); ok {
+				m.detailVP.SetYOffset(m.detailVP.YOffset + delta)
+				return m, nil
+			}
 			switch {
 			case key.Matches(msg, shared.Keys.Down):
 				m.detailVP.LineDown(1)
@@ -300,21 +346,29 @@ func (m *Model) rebuildFlatItems() {
 		Label: fmt.Sprintf("%d/%d passed", m.data.Passed, m.data.Total),
 	})
 	if !m.collapsed[FeatureHeader] {
-		// Show non-passed features first (active/failed/blocked/pending), then passed
-		for i := range m.data.Features {
-			if m.data.Features[i].Status != "passed" {
+		phases := m.featurePhases()
+		for _, phase := range phases {
+			if len(phases) > 1 {
+				passed, total := m.phaseCounts(phase)
 				m.flatItems = append(m.flatItems, FlatItem{
-					Kind:    FeatureItem,
-					Feature: &m.data.Features[i],
+					Kind:  PhaseHeader,
+					Phase: phase,
+					Label: fmt.Sprintf("%d/%d", passed, total),
 				})
+				if m.phaseCollapsed[phase] {
+					continue
+				}
 			}
-		}
-		for i := range m.data.Features {
-			if m.data.Features[i].Status == "passed" {
-				m.flatItems = append(m.flatItems, FlatItem{
-					Kind:    FeatureItem,
-					Feature: &m.data.Features[i],
-				})
+			// Show non-passed features first (active/failed/blocked/pending), then passed
+			for i := range m.data.Features {
+				if f := &m.data.Features[i]; f.Phase == phase && f.Status != "passed" {
+					m.flatItems = append(m.flatItems, FlatItem{Kind: FeatureItem, Feature: f})
+				}
+			}
+			for i := range m.data.Features {
+				if f := &m.data.Features[i]; f.Phase == phase && f.Status == "passed" {
+					m.flatItems = append(m.flatItems, FlatItem{Kind: FeatureItem, Feature: f})
+				}
 			}
 		}
 	}
@@ -399,12 +453,19 @@ func (m *Model) rebuildFlatItems() {
 	// Memories section
 	if len(m.data.Memories) > 0 {
 		m.flatItems = append(m.flatItems, FlatItem{Kind: SectionSpacer})
+		label := fmt.Sprintf("%d", len(m.data.Memories))
+		if m.memoryTag != "" {
+			label = fmt.Sprintf("#%s %d/%d", m.memoryTag, len(m.filteredMemories()), len(m.data.Memories))
+		}
 		m.flatItems = append(m.flatItems, FlatItem{
 			Kind:  MemoryHeader,
-			Label: fmt.Sprintf("%d", len(m.data.Memories)),
+			Label: label,
 		})
 		if !m.collapsed[MemoryHeader] {
 			for i := range m.data.Memories {
+				if m.memoryTag != "" && !slices.Contains(m.data.Memories[i].Tags, m.memoryTag) {
+					continue
+				}
 				m.flatItems = append(m.flatItems, FlatItem{
 					Kind:   MemoryItem,
 					Memory: &m.data.Memories[i],
@@ -464,16 +525,18 @@ func (m Model) view(focused bool) string {
 		lines = append(lines, line)
 	}
 
+	bar := shared.Scrollbar(len(m.flatItems), listH, m.scrollOffset, listH)
+	list := shared.WithScrollbar(strings.Join(lines, "\n"), w, bar)
+
 	if detailH > 0 {
-		listContent := fixedHeight(strings.Join(lines, "\n"), listH)
+		listContent := fixedHeight(list, listH)
 		divider := shared.SectionDividerStyle.Render(strings.Repeat("─", w))
 		detail := fixedHeight(m.detailVP.View(), detailH)
 		content := listContent + "\n" + divider + "\n" + detail
 		return style.Render(content)
 	}
 
-	content := strings.Join(lines, "\n")
-	return style.Render(content)
+	return style.Render(list)
 }
 
 func (m Model) renderItem(item FlatItem, selected bool) string {
@@ -489,7 +552,11 @@ func (m Model) renderItem(item FlatItem, selected bool) string {
 		return ""
 
 	case FeatureHeader:
-		line = m.renderSectionHeader("Features", item.Label, shared.StagedSectionStyle)
+		bar := shared.ProgressBar(shared.Fraction(m.data.Passed, m.data.Total), progressWidth)
+		line = m.renderSectionHeader("Features", bar+" "+shared.DimFileStyle.Render(item.Label), shared.StagedSectionStyle)
+
+	case PhaseHeader:
+		line = m.renderPhaseHeader(item)
 
 	case FeatureItem:
 		line = m.renderFeature(item.Feature)
@@ -499,7 +566,7 @@ func (m Model) renderItem(item FlatItem, selected bool) string {
 		if item.Session != nil {
 			title = fmt.Sprintf("Session #%d", item.Session.Number)
 		}
-		line = m.renderSectionHeader(title, item.Label, shared.StagedSectionStyle)
+		line = m.renderSectionHeader(title, shared.DimFileStyle.Render(item.Label), shared.StagedSectionStyle)
 
 	case HandoffItem:
 		parts := strings.SplitN(item.Label, "  ", 2)
@@ -521,7 +588,7 @@ func (m Model) renderItem(item FlatItem, selected bool) string {
 		if item.Label != "" {
 			suffix = item.Label
 		}
-		line = m.renderSectionHeader("Memories", suffix, shared.DimFileStyle)
+		line = m.renderSectionHeader("Memories", shared.DimFileStyle.Render(suffix), shared.DimFileStyle)
 
 	case MemoryItem:
 		name := truncate(item.Memory.Name, w-4)
@@ -542,7 +609,7 @@ func (m Model) renderItem(item FlatItem, selected bool) string {
 	return line
 }
 
-// renderSectionHeader builds a section header: "▼ Title ──── suffix"
+// renderSectionHeader draws "▼ Title ──── suffix"; suffix comes styled.
 func (m Model) renderSectionHeader(title, suffix string, titleStyle lipgloss.Style) string {
 	w := m.width
 	collapsed := false
@@ -582,7 +649,117 @@ func (m Model) renderSectionHeader(title, suffix string, titleStyle lipgloss.Sty
 	return shared.DimFileStyle.Render(chevron) + " " +
 		titleStyle.Render(title) + " " +
 		shared.SectionDividerStyle.Render(divider) +
-		shared.DimFileStyle.Render(suffixText)
+		suffixText
+}
+
+// memoryTags returns the distinct tags across all memories, sorted.
+func (m Model) memoryTags() []string {
+	if m.data == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var tags []string
+	for _, mem := range m.data.Memories {
+		for _, t := range mem.Tags {
+			if !seen[t] {
+				seen[t] = true
+				tags = append(tags, t)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// filteredMemories returns the memories the tag filter lets through.
+func (m Model) filteredMemories() []conductor.Memory {
+	if m.memoryTag == "" {
+		return m.data.Memories
+	}
+	var mems []conductor.Memory
+	for _, mem := range m.data.Memories {
+		if slices.Contains(mem.Tags, m.memoryTag) {
+			mems = append(mems, mem)
+		}
+	}
+	return mems
+}
+
+// CycleMemoryTag moves the memory filter to the next tag, and from the
+// last tag back to showing everything. The memories section is expanded
+// and the cursor put on its header so the result is in view.
+func (m *Model) CycleMemoryTag() {
+	tags := m.memoryTags()
+	if len(tags) == 0 {
+		return
+	}
+	switch i := slices.Index(tags, m.memoryTag); {
+	case m.memoryTag == "":
+		m.memoryTag = tags[0]
+	case i+1 < len(tags):
+		m.memoryTag = tags[i+1]
+	default:
+		m.memoryTag = ""
+	}
+	m.collapsed[MemoryHeader] = false
+	m.rebuildFlatItems()
+	for i, item := range m.flatItems {
+		if item.Kind == MemoryHeader {
+			m.cursor = i
+			m.ensureCursorVisible()
+			break
+		}
+	}
+}
+
+// featurePhases returns the distinct feature phases, ascending.
+func (m Model) featurePhases() []int {
+	seen := make(map[int]bool)
+	var phases []int
+	for _, f := range m.data.Features {
+		if !seen[f.Phase] {
+			seen[f.Phase] = true
+			phases = append(phases, f.Phase)
+		}
+	}
+	sort.Ints(phases)
+	return phases
+}
+
+func (m Model) phaseCounts(phase int) (passed, total int) {
+	for _, f := range m.data.Features {
+		if f.Phase == phase {
+			total++
+			if f.Status == "passed" {
+				passed++
+			}
+		}
+	}
+	return passed, total
+}
+
+// renderPhaseHeader draws " ▼ Phase N ─── passed/total", indented under
+// the Features header.
+func (m Model) renderPhaseHeader(item FlatItem) string {
+	chevron := "▼"
+	if m.phaseCollapsed[item.Phase] {
+		chevron = "▶"
+	}
+	title := fmt.Sprintf("Phase %d", item.Phase)
+	suffix := " " + item.Label
+	dividerLen := m.width - lipgloss.Width(" "+chevron+" "+title+" ") - lipgloss.Width(suffix)
+	if dividerLen < 1 {
+		dividerLen = 1
+	}
+	// Finished phases go green, like passed features
+	titleStyle := shared.UnstagedFileStyle
+	if passed, total := m.phaseCounts(item.Phase); passed == total {
+		titleStyle = shared.StagedFileStyle
+	}
+	return " " + shared.DimFileStyle.Render(chevron) + " " +
+		titleStyle.Render(title) + " " +
+		shared.SectionDividerStyle.Render(strings.Repeat("─", dividerLen)) +
+		shared.DimFileStyle.Render(suffix)
 }
 
 func (m Model) renderFeature(f *conductor.Feature) string {
@@ -673,12 +850,23 @@ func (m Model) renderDetail(w, h int) string {
 		if f.AttemptCount > 1 {
 			b.WriteString(label.Render("  tries  ") + " " + shared.ErrorStyle.Render(fmt.Sprintf("%d", f.AttemptCount)) + "\n")
 		}
-		if f.CommitHash != "" {
-			hash := f.CommitHash
-			if len(hash) > 12 {
-				hash = hash[:12]
+		var commits []conductor.FeatureCommit
+		if m.data != nil {
+			commits = m.data.Commits[f.ID]
+		}
+		if len(commits) > 0 {
+			b.WriteString(label.Render("  commits") + " " + fmt.Sprintf("%d", len(commits)) + "\n")
+			for i, c := range commits {
+				if i == maxFeatureCommits {
+					b.WriteString("           " + shared.DimFileStyle.Render(fmt.Sprintf("… %d more", len(commits)-i)) + "\n")
+					break
+				}
+				subject, _, _ := strings.Cut(c.Message, "\n")
+				b.WriteString("           " + shared.CommitDetailHashStyle.Render(shortHash(c.Hash)) + " " +
+					shared.DimFileStyle.Render(truncate(subject, w-20)) + "\n")
 			}
-			b.WriteString(label.Render("  commit ") + " " + shared.CommitDetailHashStyle.Render(hash) + "\n")
+		} else if f.CommitHash != "" {
+			b.WriteString(label.Render("  commit ") + " " + shared.CommitDetailHashStyle.Render(shortHash(f.CommitHash)) + "\n")
 		}
 		if f.LastError != "" {
 			errLines := wordWrap(f.LastError, w-12)
@@ -756,19 +944,26 @@ func (m Model) renderDetail(w, h int) string {
 			}
 		}
 
-	case FeatureHeader:
+	case FeatureHeader, PhaseHeader:
 		if m.data != nil {
+			passed, total := m.data.Passed, m.data.Total
 			b.WriteString("\n")
-			b.WriteString(label.Render("  total  ") + " " + fmt.Sprintf("%d features", m.data.Total) + "\n")
-			b.WriteString(label.Render("  passed ") + " " + shared.StagedFileStyle.Render(fmt.Sprintf("%d", m.data.Passed)) + "\n")
-			remaining := m.data.Total - m.data.Passed
+			if item.Kind == PhaseHeader {
+				passed, total = m.phaseCounts(item.Phase)
+				b.WriteString(label.Render("  phase  ") + " " + fmt.Sprintf("%d", item.Phase) + "\n")
+			}
+			b.WriteString(label.Render("  total  ") + " " + fmt.Sprintf("%d features", total) + "\n")
+			b.WriteString(label.Render("  passed ") + " " + shared.StagedFileStyle.Render(fmt.Sprintf("%d", passed)) + "\n")
+			remaining := total - passed
 			if remaining > 0 {
 				b.WriteString(label.Render("  remain ") + " " + shared.UnstagedFileStyle.Render(fmt.Sprintf("%d", remaining)) + "\n")
 			}
 			// Show counts by status
 			counts := make(map[string]int)
 			for _, f := range m.data.Features {
-				counts[f.Status]++
+				if item.Kind == FeatureHeader || f.Phase == item.Phase {
+					counts[f.Status]++
+				}
 			}
 			if n := counts["in_progress"]; n > 0 {
 				b.WriteString(label.Render("  active ") + " " + shared.UnstagedFileStyle.Render(fmt.Sprintf("%d", n)) + "\n")
@@ -812,8 +1007,20 @@ func (m Model) renderDetail(w, h int) string {
 		if m.data != nil {
 			b.WriteString("\n")
 			b.WriteString(label.Render("  saved  ") + " " + fmt.Sprintf("%d memories", len(m.data.Memories)) + "\n")
+			if tags := m.memoryTags(); len(tags) > 0 {
+				styled := make([]string, len(tags))
+				for i, t := range tags {
+					if t == m.memoryTag {
+						styled[i] = shared.BranchCurrentStyle.Render("#" + t)
+					} else {
+						styled[i] = shared.DimFileStyle.Render("#" + t)
+					}
+				}
+				b.WriteString(label.Render("  tags   ") + " " + strings.Join(styled, " ") + "\n")
+				b.WriteString("           " + shared.HelpDescStyle.Render("t: filter by tag") + "\n")
+			}
 			// Show all memory names
-			for _, mem := range m.data.Memories {
+			for _, mem := range m.filteredMemories() {
 				tags := ""
 				if len(mem.Tags) > 0 {
 					tags = " " + shared.DimFileStyle.Render("["+strings.Join(mem.Tags, ",")+"]")
@@ -841,6 +1048,13 @@ func truncate(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
+
 // wordWrap breaks text into lines of at most width characters, splitting at spaces.
 func wordWrap(s string, width int) []string {
 	if width < 5 {
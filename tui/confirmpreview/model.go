@@ -0,0 +1,149 @@
+package confirmpreview
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dylan/gitdash/tui/shared"
+)
+
+type ActionKind int
+
+const (
+	ActionNone ActionKind = iota
+	ActionCancel
+	ActionConfirm
+)
+
+type KeyResult struct {
+	Action ActionKind
+	Cmd    tea.Cmd // the confirmed action, for ActionConfirm
+}
+
+// chrome is the overlay's border, padding, title, prompt and help lines.
+const chrome = 12
+
+// Model asks before a destructive action, showing what it will drop or
+// change (the commits a reset leaves behind, the changes an abort throws
+// away) so it's confirmed knowingly rather than with a bare y/n.
+type Model struct {
+	title  string
+	prompt string
+	cmd    tea.Cmd
+	lines  []string
+	loaded bool
+	seq    int // bumped per Open so a late preview can't fill a newer prompt
+	offset int
+	width  int
+	height int
+}
+
+func New() Model {
+	return Model{}
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// Open shows title and prompt while the preview loads; cmd runs if it's
+// confirmed. The returned sequence must be passed back to SetPreview.
+func (m *Model) Open(title, prompt string, cmd tea.Cmd) int {
+	m.seq++
+	m.title = title
+	m.prompt = prompt
+	m.cmd = cmd
+	m.lines = nil
+	m.loaded = false
+	m.offset = 0
+	return m.seq
+}
+
+// SetPreview fills in the preview for the prompt Open numbered seq; a
+// preview for an earlier prompt is dropped. When it couldn't be built the
+// error is shown instead, and the action can still be confirmed.
+func (m *Model) SetPreview(seq int, preview string, err error) {
+	if seq != m.seq {
+		return
+	}
+	m.loaded = true
+	if err != nil {
+		m.lines = []string{shared.ErrorStyle.Render("Couldn't build the preview: " + err.Error())}
+		return
+	}
+	preview = strings.TrimRight(preview, "\n")
+	if preview == "" {
+		m.lines = []string{shared.HelpDescStyle.Render("(nothing)")}
+		return
+	}
+	m.lines = strings.Split(preview, "\n")
+}
+
+func (m *Model) HandleKey(msg tea.KeyMsg) KeyResult {
+	switch msg.String() {
+	case "y":
+		// Only once there's something to look at
+		if !m.loaded {
+			return KeyResult{Action: ActionNone}
+		}
+		return KeyResult{Action: ActionConfirm, Cmd: m.cmd}
+	case "n", "esc", "q":
+		return KeyResult{Action: ActionCancel}
+	case "j", "down":
+		m.offset++
+	case "k", "up":
+		m.offset--
+	case "pgdown", "ctrl+d":
+		m.offset += m.pageSize()
+	case "pgup", "ctrl+u":
+		m.offset -= m.pageSize()
+	}
+	m.offset = max(0, min(m.offset, len(m.lines)-m.pageSize()))
+	return KeyResult{Action: ActionNone}
+}
+
+func (m Model) pageSize() int {
+	return max(m.height-chrome, 5)
+}
+
+func (m Model) ViewOverlay(background string, w, h int) string {
+	overlay := shared.BranchPickerOverlayStyle.Render(m.renderContent())
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color("0")),
+	)
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+
+	b.WriteString(shared.TitleStyle.Render(m.title))
+	b.WriteString("\n\n")
+	b.WriteString(shared.FeedbackWarningStyle.Render(m.prompt))
+	b.WriteString("\n\n")
+
+	if !m.loaded {
+		b.WriteString(shared.HelpDescStyle.Render("loading preview…"))
+		b.WriteString("\n\n")
+		b.WriteString(shared.HelpDescStyle.Render("n/esc: cancel"))
+		return b.String()
+	}
+
+	clip := lipgloss.NewStyle().MaxWidth(max(m.width-10, 20))
+	end := min(m.offset+m.pageSize(), len(m.lines))
+	for _, line := range m.lines[m.offset:end] {
+		b.WriteString(clip.Render(line))
+		b.WriteString("\n")
+	}
+	if len(m.lines) > m.pageSize() {
+		b.WriteString(shared.HelpDescStyle.Render(fmt.Sprintf("lines %d-%d of %d", m.offset+1, end, len(m.lines))))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(shared.HelpDescStyle.Render("y: confirm  n/esc: cancel  j/k: scroll"))
+	return b.String()
+}
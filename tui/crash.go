@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recentMsgLimit is how many handled messages a crash report includes.
+const recentMsgLimit = 50
+
+var (
+	crashMu    sync.Mutex
+	recentMsgs []string // ring of recent message descriptions, only kept with debug logging on
+	crashPath  string   // report written for the first panic, "" if none
+	crashDir   = os.TempDir()
+)
+
+// SetCrashDir sets where crash reports are written (default: the temp dir).
+func SetCrashDir(dir string) {
+	crashDir = dir
+}
+
+// CrashReportPath returns the crash report written during this run, or "".
+func CrashReportPath() string {
+	crashMu.Lock()
+	defer crashMu.Unlock()
+	return crashPath
+}
+
+func recordMsg(desc string) {
+	crashMu.Lock()
+	defer crashMu.Unlock()
+	recentMsgs = append(recentMsgs, time.Now().Format("15:04:05.000")+" "+desc)
+	if len(recentMsgs) > recentMsgLimit {
+		recentMsgs = recentMsgs[len(recentMsgs)-recentMsgLimit:]
+	}
+}
+
+// WriteCrashReport writes the panic value, stack and recent messages to a
+// file and returns its path. Only the first report of a run is kept.
+func WriteCrashReport(r any, stack []byte) string {
+	crashMu.Lock()
+	defer crashMu.Unlock()
+	if crashPath != "" {
+		return crashPath
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "gitdash crash report, %s\n\n", time.Now().Format(time.RFC3339))
+	if info, ok := debug.ReadBuildInfo(); ok {
+		fmt.Fprintf(&b, "version: %s\n", info.Main.Version)
+	}
+	fmt.Fprintf(&b, "go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "args: %s\n\n", strings.Join(os.Args, " "))
+	fmt.Fprintf(&b, "panic: %v\n\n%s\n", r, stack)
+	if len(recentMsgs) > 0 {
+		fmt.Fprintf(&b, "\nlast %d messages (oldest first):\n", len(recentMsgs))
+		for _, m := range recentMsgs {
+			b.WriteString("  " + m + "\n")
+		}
+	} else {
+		b.WriteString("\nrun with -log to include recent UI messages in crash reports\n")
+	}
+
+	path := filepath.Join(crashDir, fmt.Sprintf("gitdash-crash-%s.txt", time.Now().Format("20060102-150405")))
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return ""
+	}
+	crashPath = path
+	return path
+}
+
+// crashGuard writes a report for a panic in progress and re-panics so
+// bubbletea still restores the terminal. Use as `defer crashGuard()`.
+func crashGuard() {
+	if r := recover(); r != nil {
+		WriteCrashReport(r, debug.Stack())
+		panic(r)
+	}
+}
+
+// guardCmd wraps cmd, and any commands it batches, with crashGuard since
+// bubbletea runs commands on their own goroutines.
+func guardCmd(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		defer crashGuard()
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for i := range batch {
+				batch[i] = guardCmd(batch[i])
+			}
+		}
+		return msg
+	}
+}
@@ -3,9 +3,11 @@ package dashboard
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/dylan/gitdash/config"
 	"github.com/dylan/gitdash/git"
@@ -22,8 +24,29 @@ const (
 	DocHeader
 	FolderHeader
 	File
+	MoreFiles    // stands in for the files past the display cap
+	UntrackedDir // stands in for the files of a wholly untracked directory
 )
 
+// StagingFilter limits which file sections the project view shows.
+type StagingFilter int
+
+const (
+	FilterAll StagingFilter = iota
+	FilterStaged
+	FilterUnstaged
+)
+
+func (f StagingFilter) String() string {
+	switch f {
+	case FilterStaged:
+		return "staged only"
+	case FilterUnstaged:
+		return "unstaged only"
+	}
+	return "all files"
+}
+
 type FlatItem struct {
 	Kind         ItemKind
 	RepoIndex    int
@@ -34,15 +57,22 @@ type FlatItem struct {
 	Section      string // "staged", "unstaged", or "docs"
 	Tier         int    // 1=bright, 2=normal, 3=dim
 	Dir          string // directory path for folder grouping
+	Hidden       int    // MoreFiles: how many files the cap left out
+	Count        int    // UntrackedDir: how many files it holds
 }
 
 type Model struct {
 	repos            []git.RepoStatus
 	flatItems        []FlatItem
-	repoHeaders      []int // indices into flatItems for repo headers
+	rows             [][]int // screen rows, as indices into flatItems (see layoutRows)
+	rowOf            []int   // flatItems index -> its row
+	repoHeaders      []int   // indices into flatItems for repo headers
 	collapsed        map[int]bool
 	docsCollapsed    map[int]bool
 	foldersCollapsed map[string]bool // "repoIndex:dir" -> collapsed
+	untrackedOpen    map[string]bool // "repoIndex:dir" -> untracked dir expanded
+	showAll          map[string]bool // "repoIndex:section" -> file cap lifted
+	fileLimit        int             // files shown per section before "more"; 0 = no cap
 	pushingRepos     map[int]string  // repoIndex -> spinner view string
 	priorityRules    []config.PriorityRule
 	display          config.DisplayConfig
@@ -54,6 +84,15 @@ type Model struct {
 	// Conductor summary per project (for all-projects view)
 	projectConductor map[int]string // projectIndex -> summary string
 
+	hideClean bool // hide repos (and projects) with no changes
+
+	stagingFilter StagingFilter // docs are shown regardless
+	byStatus      bool          // every repo's files in one staged and one unstaged list
+
+	saved *config.State // collapse state to apply on first SetRepos
+
+	pinned map[string]bool // repo path -> pinned to top
+
 	cursor           int
 	scrollOffset     int
 	width            int
@@ -65,6 +104,9 @@ func New(rules []config.PriorityRule, display config.DisplayConfig) Model {
 		collapsed:        make(map[int]bool),
 		docsCollapsed:    make(map[int]bool),
 		foldersCollapsed: make(map[string]bool),
+		untrackedOpen:    make(map[string]bool),
+		showAll:          make(map[string]bool),
+		pinned:           make(map[string]bool),
 		pushingRepos:     make(map[int]string),
 		projectConductor: make(map[int]string),
 		priorityRules:    rules,
@@ -91,19 +133,201 @@ func (m *Model) ClearRepoPushing(repoIndex int) {
 func (m *Model) SetSize(w, h int) {
 	m.width = w
 	m.height = h
+	m.layoutRows()
+	m.ensureCursorVisible()
 }
 
 func (m *Model) SetRepos(repos []git.RepoStatus) {
+	unchanged := len(m.collapsed) > 0 && sameLayout(m.repos, repos)
 	m.repos = repos
-	// Auto-collapse repos on first load
+	// Auto-collapse repos on first load, unless saved state says otherwise
 	if len(m.collapsed) == 0 {
 		for i := range repos {
 			m.collapsed[i] = true
 		}
+		m.applySavedState()
+	}
+	// Most refreshes only change counts; skip re-sorting every file then
+	if unchanged {
+		m.repointItems()
+		return
+	}
+	// Keep the cursor on the same file or header, not the same row, so
+	// files coming and going above it don't move the selection
+	sel, ok := m.SelectedItem()
+	m.rebuildFlatItems()
+	if ok {
+		m.reselect(keyOf(sel))
+	}
+}
+
+// itemKey identifies a row across rebuilds. Files are keyed by section
+// too, so a file staged from under the cursor leaves it in place.
+type itemKey struct {
+	kind    ItemKind
+	project int
+	repo    string
+	section string
+	path    string // file path, or directory for folder rows
+}
+
+func keyOf(item FlatItem) itemKey {
+	k := itemKey{kind: item.Kind, project: item.ProjectIndex, section: item.Section, path: item.Dir}
+	if item.Repo != nil {
+		k.repo = item.Repo.Path
+	}
+	if item.File != nil {
+		k.path = item.File.Path
+	}
+	return k
+}
+
+// reselect moves the cursor to the row matching key, when there still is
+// one. Otherwise the cursor stays at the (clamped) row it was on.
+func (m *Model) reselect(key itemKey) {
+	for i, item := range m.flatItems {
+		if keyOf(item) == key {
+			m.cursor = i
+			m.ensureCursorVisible()
+			return
+		}
+	}
+}
+
+// UpdateRepos replaces the repos in updated (matched by path), leaving the
+// others as they were.
+func (m *Model) UpdateRepos(updated []git.RepoStatus) {
+	byPath := make(map[string]git.RepoStatus, len(updated))
+	for _, r := range updated {
+		byPath[r.Path] = r
+	}
+	repos := make([]git.RepoStatus, len(m.repos))
+	for i, r := range m.repos {
+		if u, ok := byPath[r.Path]; ok {
+			r = u
+		}
+		repos[i] = r
 	}
+	m.SetRepos(repos)
+}
+
+// SetFileLimit caps how many files each staged/unstaged section lists
+// before collapsing the rest into a "more" row. 0 disables the cap.
+func (m *Model) SetFileLimit(n int) {
+	m.fileLimit = n
+}
+
+// SetDisplay swaps in edited display options and rebuilds the list.
+func (m *Model) SetDisplay(display config.DisplayConfig) {
+	m.display = display
 	m.rebuildFlatItems()
 }
 
+// sameLayout reports whether b would produce the same flat list as a: the
+// same repos with the same files, in the same order and sections, and the
+// same untracked directories to group them under.
+func sameLayout(a, b []git.RepoStatus) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		ra, rb := &a[i], &b[i]
+		if ra.Path != rb.Path || (ra.Error == nil) != (rb.Error == nil) ||
+			isClean(*ra) != isClean(*rb) || len(ra.Files) != len(rb.Files) ||
+			!slices.Equal(ra.UntrackedDirs, rb.UntrackedDirs) {
+			return false
+		}
+		for fi := range ra.Files {
+			fa, fb := &ra.Files[fi], &rb.Files[fi]
+			if fa.Path != fb.Path || fa.StagingState != fb.StagingState {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// repointItems moves the flat list's pointers onto the current repos slice.
+func (m *Model) repointItems() {
+	for i := range m.flatItems {
+		item := &m.flatItems[i]
+		if item.Repo != nil {
+			item.Repo = &m.repos[item.RepoIndex]
+		}
+		if item.File != nil {
+			item.File = &m.repos[item.RepoIndex].Files[item.FileIndex]
+		}
+	}
+}
+
+// RestoreState queues saved collapse flags for the first SetRepos and
+// re-enters the saved active project if it still exists.
+func (m *Model) RestoreState(st config.State) {
+	m.saved = &st
+	if st.ActiveProject == "" {
+		return
+	}
+	for pi, p := range m.projects {
+		if p.Name == st.ActiveProject {
+			m.activeProject = pi
+			m.cursor = 0
+			m.scrollOffset = 0
+			return
+		}
+	}
+}
+
+func (m *Model) applySavedState() {
+	if m.saved == nil {
+		return
+	}
+	for i, repo := range m.repos {
+		if c, ok := m.saved.Collapsed[repo.Path]; ok {
+			m.collapsed[i] = c
+		}
+		if c, ok := m.saved.DocsCollapsed[repo.Path]; ok {
+			m.docsCollapsed[i] = c
+		}
+		prefix := repo.Path + ":"
+		for key, c := range m.saved.FoldersCollapsed {
+			if dir, ok := strings.CutPrefix(key, prefix); ok {
+				m.foldersCollapsed[folderKey(i, dir)] = c
+			}
+		}
+	}
+	m.saved = nil
+}
+
+// SaveState writes the collapse flags and active project into st,
+// keyed by repo path and project name.
+func (m Model) SaveState(st *config.State) {
+	// Repos not loaded yet: keep whatever was restored
+	if len(m.repos) == 0 {
+		return
+	}
+	st.ActiveProject = m.ProjectName()
+	st.Collapsed = make(map[string]bool)
+	st.DocsCollapsed = make(map[string]bool)
+	st.FoldersCollapsed = make(map[string]bool)
+	for i, repo := range m.repos {
+		st.Collapsed[repo.Path] = m.collapsed[i]
+		if c, ok := m.docsCollapsed[i]; ok {
+			st.DocsCollapsed[repo.Path] = c
+		}
+	}
+	for key, c := range m.foldersCollapsed {
+		idx, dir, ok := strings.Cut(key, ":")
+		if !ok || !c {
+			continue
+		}
+		var ri int
+		if _, err := fmt.Sscanf(idx, "%d", &ri); err != nil || ri >= len(m.repos) {
+			continue
+		}
+		st.FoldersCollapsed[m.repos[ri].Path+":"+dir] = c
+	}
+}
+
 // SetProjects sets the project list and starts in all-projects mode.
 func (m *Model) SetProjects(projects []config.ProjectConfig) {
 	m.projects = projects
@@ -130,16 +354,26 @@ func (m Model) SelectedProject() (*config.ProjectConfig, bool) {
 	return &m.projects[item.ProjectIndex], true
 }
 
-// EnterProject drills into the project at the cursor.
+// EnterProject drills into the project at the cursor. On a pinned repo in
+// all-projects mode, it enters that repo's project with the repo selected.
 func (m *Model) EnterProject() {
 	item, ok := m.SelectedItem()
-	if !ok || item.Kind != ProjectHeader {
+	if !ok || (item.Kind != ProjectHeader && item.Kind != RepoHeader) || item.ProjectIndex < 0 {
 		return
 	}
 	m.activeProject = item.ProjectIndex
 	m.cursor = 0
 	m.scrollOffset = 0
 	m.rebuildFlatItems()
+	if item.Kind == RepoHeader {
+		for i, it := range m.flatItems {
+			if it.Kind == RepoHeader && it.RepoIndex == item.RepoIndex {
+				m.cursor = i
+				m.ensureCursorVisible()
+				break
+			}
+		}
+	}
 }
 
 // ExitProject returns to the all-projects view.
@@ -192,6 +426,121 @@ func (m Model) FirstRepoInProject(projectIndex int) (*git.RepoStatus, bool) {
 	return nil, false
 }
 
+// SetPinned sets the pinned repo paths.
+func (m *Model) SetPinned(paths []string) {
+	m.pinned = make(map[string]bool, len(paths))
+	for _, p := range paths {
+		m.pinned[p] = true
+	}
+	m.rebuildFlatItems()
+}
+
+// PinnedPaths returns the pinned repo paths in sorted order.
+func (m Model) PinnedPaths() []string {
+	var paths []string
+	for p := range m.pinned {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// TogglePin pins or unpins the repo at the cursor, keeping the cursor on it.
+// Returns the repo name and whether it is now pinned.
+func (m *Model) TogglePin() (string, bool, bool) {
+	repo, ok := m.SelectedRepo()
+	if !ok {
+		return "", false, false
+	}
+	path, name := repo.Path, repo.Name
+	if m.pinned[path] {
+		delete(m.pinned, path)
+	} else {
+		m.pinned[path] = true
+	}
+	m.rebuildFlatItems()
+	for i, item := range m.flatItems {
+		if item.Kind == RepoHeader && item.Repo.Path == path {
+			m.cursor = i
+			m.ensureCursorVisible()
+			break
+		}
+	}
+	return name, m.pinned[path], true
+}
+
+// projectOfRepo returns the project index containing a global repo index.
+func (m Model) projectOfRepo(repoIndex int) int {
+	offset := 0
+	for pi, p := range m.projects {
+		if repoIndex < offset+len(p.Repos) {
+			return pi
+		}
+		offset += len(p.Repos)
+	}
+	return -1
+}
+
+// SetHideClean sets whether clean repos and projects are hidden.
+func (m *Model) SetHideClean(hide bool) {
+	m.hideClean = hide
+	m.rebuildFlatItems()
+}
+
+// HideClean reports whether clean repos and projects are hidden.
+func (m Model) HideClean() bool {
+	return m.hideClean
+}
+
+// CycleStagingFilter steps through all → staged only → unstaged only.
+func (m *Model) CycleStagingFilter() StagingFilter {
+	m.stagingFilter = (m.stagingFilter + 1) % 3
+	m.rebuildFlatItems()
+	return m.stagingFilter
+}
+
+// StagingFilter returns the active staging-state filter.
+func (m Model) StagingFilter() StagingFilter {
+	return m.stagingFilter
+}
+
+// ToggleByStatus switches between the normal per-repo layout and one list
+// of every repo's files grouped only by staging state.
+func (m *Model) ToggleByStatus() bool {
+	m.byStatus = !m.byStatus
+	m.cursor = 0
+	m.scrollOffset = 0
+	m.rebuildFlatItems()
+	return m.byStatus
+}
+
+// ByStatus reports whether the unified by-status list is shown.
+func (m Model) ByStatus() bool {
+	return m.byStatus
+}
+
+// ShowingProjects reports whether the list shows project headers (the
+// all-projects view) rather than repos and files.
+func (m Model) ShowingProjects() bool {
+	return m.activeProject == -1 && len(m.projects) > 0 && !m.byStatus
+}
+
+// isClean reports whether a repo has nothing worth showing.
+func isClean(repo git.RepoStatus) bool {
+	return repo.Error == nil && len(repo.Files) == 0 && repo.InProgress == ""
+}
+
+// projectIsClean reports whether every repo in a project is clean.
+func (m Model) projectIsClean(projectIndex int) bool {
+	offset := m.projectRepoOffset(projectIndex)
+	for i := range m.projects[projectIndex].Repos {
+		if ri := offset + i; ri < len(m.repos) && !isClean(m.repos[ri]) {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *Model) ToggleCollapse() {
 	item, ok := m.SelectedItem()
 	if !ok {
@@ -222,6 +571,44 @@ func (m *Model) ToggleFolderCollapse() {
 	m.rebuildFlatItems()
 }
 
+// ToggleUntrackedDir expands or collapses the selected untracked directory.
+func (m *Model) ToggleUntrackedDir() {
+	item, ok := m.SelectedItem()
+	if !ok || item.Kind != UntrackedDir {
+		return
+	}
+	key := folderKey(item.RepoIndex, item.Dir)
+	m.untrackedOpen[key] = !m.untrackedOpen[key]
+	m.rebuildFlatItems()
+}
+
+// untrackedDir returns the wholly untracked directory file is in, or "".
+func untrackedDir(repo *git.RepoStatus, file git.FileEntry) string {
+	if file.Status != git.StatusUntracked {
+		return ""
+	}
+	for _, dir := range repo.UntrackedDirs {
+		if strings.HasPrefix(file.Path, dir+"/") {
+			return dir
+		}
+	}
+	return ""
+}
+
+// ShowMoreFiles lifts the file cap for the selected "more" row's section.
+func (m *Model) ShowMoreFiles() {
+	item, ok := m.SelectedItem()
+	if !ok || item.Kind != MoreFiles {
+		return
+	}
+	m.showAll[sectionKey(item.RepoIndex, item.Section)] = true
+	m.rebuildFlatItems()
+}
+
+func sectionKey(repoIndex int, section string) string {
+	return fmt.Sprintf("%d:%s", repoIndex, section)
+}
+
 func folderKey(repoIndex int, dir string) string {
 	return fmt.Sprintf("%d:%s", repoIndex, dir)
 }
@@ -230,6 +617,57 @@ func (m *Model) isFolderCollapsed(repoIndex int, dir string) bool {
 	return m.foldersCollapsed[folderKey(repoIndex, dir)]
 }
 
+// FolderFiles returns the paths of item's folder's files that are in
+// state. nested is true when the folder is a tree level: it holds every
+// file below it (docs included, as staging the directory takes them too).
+// Otherwise it only holds the files listed under it.
+func (m Model) FolderFiles(item FlatItem, state git.StagingState) (paths []string, nested bool) {
+	if item.Kind != FolderHeader || item.Repo == nil {
+		return nil, false
+	}
+	nested = m.folderTree()
+	for _, f := range item.Repo.Files {
+		if f.StagingState != state {
+			continue
+		}
+		if nested {
+			if strings.HasPrefix(f.Path, item.Dir+"/") {
+				paths = append(paths, f.Path)
+			}
+		} else if filepath.Dir(f.Path) == item.Dir && !(m.display.GroupDocs && isDocFile(f.Path)) {
+			paths = append(paths, f.Path)
+		}
+	}
+	return paths, nested
+}
+
+// folderTree reports whether folders are grouped as a tree, with a header
+// for every level of a path rather than one per directory.
+func (m Model) folderTree() bool {
+	return m.display.GroupFolders && m.display.FolderTree
+}
+
+// dirSegments splits a file's dir into its path levels; the repo root has
+// none.
+func dirSegments(dir string) []string {
+	if dir == "." || dir == "" {
+		return nil
+	}
+	return strings.Split(dir, "/")
+}
+
+// dirLess orders dirs level by level, so every dir comes straight after its
+// parent's files ("a", "a/b", "a-b" rather than "a", "a-b", "a/b").
+func dirLess(a, b string) bool {
+	sa, sb := dirSegments(a), dirSegments(b)
+	for i := 0; i < len(sa) && i < len(sb); i++ {
+		if sa[i] != sb[i] {
+			return sa[i] < sb[i]
+		}
+	}
+	return len(sa) < len(sb)
+}
+
 func (m *Model) IsCollapsed(repoIndex int) bool {
 	return m.collapsed[repoIndex]
 }
@@ -242,6 +680,10 @@ func (m *Model) isDocsCollapsed(repoIndex int) bool {
 	return collapsed
 }
 
+func (m Model) isPinned(repoIndex int) bool {
+	return repoIndex < len(m.repos) && m.pinned[m.repos[repoIndex].Path]
+}
+
 func isDocFile(path string) bool {
 	return strings.HasSuffix(strings.ToLower(path), ".md")
 }
@@ -250,9 +692,34 @@ func (m *Model) rebuildFlatItems() {
 	m.flatItems = nil
 	m.repoHeaders = nil
 
-	if m.activeProject == -1 && len(m.projects) > 0 {
-		// All-projects mode: show project headers only
+	if m.byStatus {
+		m.buildByStatusItems()
+	} else if m.activeProject == -1 && len(m.projects) > 0 {
+		// All-projects mode: pinned repos first, then project headers
+		var pinned []FlatItem
+		for ri := range m.repos {
+			repo := &m.repos[ri]
+			if !m.pinned[repo.Path] || (m.hideClean && isClean(*repo)) {
+				continue
+			}
+			pinned = append(pinned, FlatItem{
+				Kind:         RepoHeader,
+				RepoIndex:    ri,
+				ProjectIndex: m.projectOfRepo(ri),
+				Repo:         repo,
+			})
+		}
+		if len(pinned) > 0 {
+			m.flatItems = append(m.flatItems, FlatItem{Kind: SectionHeader, Section: "pinned"})
+			for _, item := range pinned {
+				m.repoHeaders = append(m.repoHeaders, len(m.flatItems))
+				m.flatItems = append(m.flatItems, item)
+			}
+		}
 		for pi := range m.projects {
+			if m.hideClean && m.projectIsClean(pi) {
+				continue
+			}
 			m.flatItems = append(m.flatItems, FlatItem{
 				Kind:         ProjectHeader,
 				ProjectIndex: pi,
@@ -276,11 +743,19 @@ func (m *Model) rebuildFlatItems() {
 			}
 		}
 
+		// Pinned repos float to the top, otherwise config order
+		sort.SliceStable(reposToShow, func(a, b int) bool {
+			return m.isPinned(reposToShow[a]) && !m.isPinned(reposToShow[b])
+		})
+
 		for _, ri := range reposToShow {
 			if ri >= len(m.repos) {
 				continue
 			}
 			repo := &m.repos[ri]
+			if m.hideClean && isClean(*repo) {
+				continue
+			}
 
 			// Repo header
 			m.repoHeaders = append(m.repoHeaders, len(m.flatItems))
@@ -295,9 +770,14 @@ func (m *Model) rebuildFlatItems() {
 				continue
 			}
 
-			// Collect file indices, optionally separating docs
+			// Collect file indices, optionally separating docs. Tiers and
+			// dirs are worked out once here rather than in every comparison.
 			var staged, unstaged, docFiles []int
+			tiers := make([]int, len(repo.Files))
+			dirs := make([]string, len(repo.Files))
 			for fi := range repo.Files {
+				tiers[fi] = resolveTier(repo.Files[fi].Path, m.priorityRules)
+				dirs[fi] = filepath.Dir(repo.Files[fi].Path)
 				if m.display.GroupDocs && isDocFile(repo.Files[fi].Path) {
 					docFiles = append(docFiles, fi)
 				} else if repo.Files[fi].StagingState == git.Staged {
@@ -310,21 +790,17 @@ func (m *Model) rebuildFlatItems() {
 			// Sort each group by dir (if grouping), then tier, then path
 			sortFiles := func(indices []int) {
 				sort.SliceStable(indices, func(i, j int) bool {
-					pi := repo.Files[indices[i]].Path
-					pj := repo.Files[indices[j]].Path
-					if m.display.GroupFolders {
-						di := filepath.Dir(pi)
-						dj := filepath.Dir(pj)
-						if di != dj {
-							return di < dj
+					fi, fj := indices[i], indices[j]
+					if m.display.GroupFolders && dirs[fi] != dirs[fj] {
+						if m.folderTree() {
+							return dirLess(dirs[fi], dirs[fj])
 						}
+						return dirs[fi] < dirs[fj]
 					}
-					ti := resolveTier(pi, m.priorityRules)
-					tj := resolveTier(pj, m.priorityRules)
-					if ti != tj {
-						return ti < tj
+					if tiers[fi] != tiers[fj] {
+						return tiers[fi] < tiers[fj]
 					}
-					return pi < pj
+					return repo.Files[fi].Path < repo.Files[fj].Path
 				})
 			}
 			sortFiles(staged)
@@ -332,11 +808,98 @@ func (m *Model) rebuildFlatItems() {
 
 			// appendFilesWithFolders adds file items, inserting FolderHeaders when dir changes
 			appendFilesWithFolders := func(indices []int, section string) {
+				// Files of a wholly untracked directory go under one row,
+				// listed (in path order) only when it's expanded
+				inDir := map[int]string{}
+				members := map[string][]int{}
+				if m.display.CollapseUntrackedDirs && section == "unstaged" {
+					for _, fi := range indices {
+						if dir := untrackedDir(repo, repo.Files[fi]); dir != "" {
+							inDir[fi] = dir
+							members[dir] = append(members[dir], fi)
+						}
+					}
+					for _, fis := range members {
+						sort.Slice(fis, func(i, j int) bool { return repo.Files[fis[i]].Path < repo.Files[fis[j]].Path })
+					}
+				}
+				shownDirs := map[string]bool{}
+
+				hidden := 0
+				if m.fileLimit > 0 && len(indices) > m.fileLimit && !m.showAll[sectionKey(ri, section)] {
+					hidden = len(indices) - m.fileLimit
+					indices = indices[:m.fileLimit]
+				}
 				lastDir := ""
+				var openDirs []string // folder tree: path segments of the last file's dir
 				for _, fi := range indices {
+					if udir, ok := inDir[fi]; ok {
+						if shownDirs[udir] {
+							continue
+						}
+						shownDirs[udir] = true
+						m.flatItems = append(m.flatItems, FlatItem{
+							Kind:         UntrackedDir,
+							RepoIndex:    ri,
+							ProjectIndex: projectIndex,
+							Repo:         repo,
+							Section:      section,
+							Dir:          udir,
+							Count:        len(members[udir]),
+						})
+						if !m.untrackedOpen[folderKey(ri, udir)] {
+							continue
+						}
+						for _, mi := range members[udir] {
+							m.flatItems = append(m.flatItems, FlatItem{
+								Kind:         File,
+								RepoIndex:    ri,
+								FileIndex:    mi,
+								ProjectIndex: projectIndex,
+								File:         &repo.Files[mi],
+								Repo:         repo,
+								Section:      section,
+								Tier:         tiers[mi],
+								Dir:          dirs[mi],
+							})
+						}
+						lastDir = ""
+						openDirs = nil
+						continue
+					}
 					file := &repo.Files[fi]
-					dir := filepath.Dir(file.Path)
-					if m.display.GroupFolders && dir != "." && dir != lastDir {
+					dir := dirs[fi]
+					if m.folderTree() {
+						// One header per level not already open, stopping
+						// under the first collapsed one
+						segs := dirSegments(dir)
+						common := 0
+						for common < len(segs) && common < len(openDirs) && segs[common] == openDirs[common] {
+							common++
+						}
+						openDirs = segs
+						folded := false
+						for depth := range segs {
+							sub := strings.Join(segs[:depth+1], "/")
+							if depth >= common {
+								m.flatItems = append(m.flatItems, FlatItem{
+									Kind:         FolderHeader,
+									RepoIndex:    ri,
+									ProjectIndex: projectIndex,
+									Repo:         repo,
+									Section:      section,
+									Dir:          sub,
+								})
+							}
+							if m.isFolderCollapsed(ri, sub) {
+								folded = true
+								break
+							}
+						}
+						if folded {
+							continue
+						}
+					} else if m.display.GroupFolders && dir != "." && dir != lastDir {
 						m.flatItems = append(m.flatItems, FlatItem{
 							Kind:         FolderHeader,
 							RepoIndex:    ri,
@@ -348,7 +911,7 @@ func (m *Model) rebuildFlatItems() {
 						lastDir = dir
 					}
 					// Skip files under collapsed folder
-					if m.display.GroupFolders && dir != "." && m.isFolderCollapsed(ri, dir) {
+					if m.display.GroupFolders && !m.folderTree() && dir != "." && m.isFolderCollapsed(ri, dir) {
 						continue
 					}
 					m.flatItems = append(m.flatItems, FlatItem{
@@ -359,14 +922,24 @@ func (m *Model) rebuildFlatItems() {
 						File:         file,
 						Repo:         repo,
 						Section:      section,
-						Tier:         resolveTier(file.Path, m.priorityRules),
+						Tier:         tiers[fi],
 						Dir:          dir,
 					})
 				}
+				if hidden > 0 {
+					m.flatItems = append(m.flatItems, FlatItem{
+						Kind:         MoreFiles,
+						RepoIndex:    ri,
+						ProjectIndex: projectIndex,
+						Repo:         repo,
+						Section:      section,
+						Hidden:       hidden,
+					})
+				}
 			}
 
 			// Staged section
-			if len(staged) > 0 {
+			if len(staged) > 0 && m.stagingFilter != FilterUnstaged {
 				m.flatItems = append(m.flatItems, FlatItem{
 					Kind:         SectionHeader,
 					RepoIndex:    ri,
@@ -378,7 +951,7 @@ func (m *Model) rebuildFlatItems() {
 			}
 
 			// Unstaged section
-			if len(unstaged) > 0 {
+			if len(unstaged) > 0 && m.stagingFilter != FilterStaged {
 				m.flatItems = append(m.flatItems, FlatItem{
 					Kind:         SectionHeader,
 					RepoIndex:    ri,
@@ -422,6 +995,8 @@ func (m *Model) rebuildFlatItems() {
 		}
 	}
 
+	m.layoutRows()
+
 	// Clamp cursor
 	if m.cursor >= len(m.flatItems) {
 		m.cursor = len(m.flatItems) - 1
@@ -436,6 +1011,62 @@ func (m *Model) rebuildFlatItems() {
 }
 
 // resolveTier determines a file's priority tier from rules. Default is tier 2.
+// buildByStatusItems lists the files of every repo in a staged and an
+// unstaged section, sorted by tier, then repo, then path.
+func (m *Model) buildByStatusItems() {
+	var staged, unstaged []FlatItem
+	for ri := range m.repos {
+		repo := &m.repos[ri]
+		if repo.Error != nil {
+			continue
+		}
+		for fi := range repo.Files {
+			file := &repo.Files[fi]
+			item := FlatItem{
+				Kind:         File,
+				RepoIndex:    ri,
+				FileIndex:    fi,
+				ProjectIndex: m.projectOfRepo(ri),
+				File:         file,
+				Repo:         repo,
+				Tier:         resolveTier(file.Path, m.priorityRules),
+				Dir:          filepath.Dir(file.Path),
+			}
+			if file.StagingState == git.Staged {
+				item.Section = "staged"
+				staged = append(staged, item)
+			} else {
+				item.Section = "unstaged"
+				unstaged = append(unstaged, item)
+			}
+		}
+	}
+
+	sortItems := func(items []FlatItem) {
+		sort.SliceStable(items, func(i, j int) bool {
+			a, b := items[i], items[j]
+			if a.Tier != b.Tier {
+				return a.Tier < b.Tier
+			}
+			if a.Repo.Name != b.Repo.Name {
+				return a.Repo.Name < b.Repo.Name
+			}
+			return a.File.Path < b.File.Path
+		})
+	}
+	sortItems(staged)
+	sortItems(unstaged)
+
+	if len(staged) > 0 && m.stagingFilter != FilterUnstaged {
+		m.flatItems = append(m.flatItems, FlatItem{Kind: SectionHeader, Section: "staged"})
+		m.flatItems = append(m.flatItems, staged...)
+	}
+	if len(unstaged) > 0 && m.stagingFilter != FilterStaged {
+		m.flatItems = append(m.flatItems, FlatItem{Kind: SectionHeader, Section: "unstaged"})
+		m.flatItems = append(m.flatItems, unstaged...)
+	}
+}
+
 func resolveTier(filePath string, rules []config.PriorityRule) int {
 	ext := filepath.Ext(filePath)
 	dir := filepath.Dir(filePath)
@@ -475,6 +1106,116 @@ func isNonSelectable(kind ItemKind) bool {
 	return kind == SectionHeader
 }
 
+// minColumnWidth is the narrowest a file column may be; a dashboard too
+// narrow for display.file_columns of them gets fewer.
+const minColumnWidth = 36
+
+// fileColumns returns how many columns file rows flow into at the current
+// width.
+func (m Model) fileColumns() int {
+	if m.display.FileColumns < 2 {
+		return 1
+	}
+	return max(1, min(m.display.FileColumns, m.width/minColumnWidth))
+}
+
+// layoutRows groups flatItems into screen rows. Headers get a row to
+// themselves; consecutive files of the same group flow left to right into
+// rows of fileColumns() cells.
+func (m *Model) layoutRows() {
+	cols := m.fileColumns()
+	m.rows = nil
+	m.rowOf = make([]int, len(m.flatItems))
+	for i, item := range m.flatItems {
+		last := len(m.rows) - 1
+		if cols > 1 && last >= 0 && len(m.rows[last]) < cols && m.sameRun(m.flatItems[m.rows[last][0]], item) {
+			m.rows[last] = append(m.rows[last], i)
+		} else {
+			m.rows = append(m.rows, []int{i})
+		}
+		m.rowOf[i] = len(m.rows) - 1
+	}
+}
+
+// inColumns reports whether rows of kind flow into file columns.
+func inColumns(kind ItemKind) bool {
+	return kind == File || kind == UntrackedDir
+}
+
+// sameRun reports whether b can share a row with a: both are file rows of
+// the same repo, section and (with folder grouping) folder.
+func (m Model) sameRun(a, b FlatItem) bool {
+	if !inColumns(a.Kind) || !inColumns(b.Kind) || a.Section != b.Section {
+		return false
+	}
+	if m.byStatus {
+		return true
+	}
+	return a.RepoIndex == b.RepoIndex && (!m.display.GroupFolders || a.Dir == b.Dir)
+}
+
+// cursorCell returns the cursor's row and its column within the row.
+func (m Model) cursorCell() (int, int) {
+	if m.cursor < 0 || m.cursor >= len(m.rowOf) {
+		return 0, 0
+	}
+	r := m.rowOf[m.cursor]
+	for c, i := range m.rows[r] {
+		if i == m.cursor {
+			return r, c
+		}
+	}
+	return r, 0
+}
+
+// moveRows moves the cursor delta rows down (up when negative), staying in
+// its column where the row is wide enough. Rows with nothing selectable
+// are skipped; past the end it settles on the last selectable row.
+func (m *Model) moveRows(delta int) {
+	if len(m.rows) == 0 {
+		return
+	}
+	r, col := m.cursorCell()
+	dir := 1
+	if delta < 0 {
+		dir = -1
+	}
+	selectable := func(r int) bool { return !isNonSelectable(m.flatItems[m.rows[r][0]].Kind) }
+	target := max(0, min(r+delta, len(m.rows)-1))
+	for target >= 0 && target < len(m.rows) && !selectable(target) {
+		target += dir
+	}
+	if target < 0 || target >= len(m.rows) {
+		// Ran off the end: take the nearest selectable row back the other way
+		target = max(0, min(target, len(m.rows)-1))
+		for target >= 0 && target < len(m.rows) && !selectable(target) {
+			target -= dir
+		}
+		if target < 0 || target >= len(m.rows) {
+			return
+		}
+	}
+	row := m.rows[target]
+	m.cursor = row[min(col, len(row)-1)]
+	m.ensureCursorVisible()
+}
+
+// MoveLeft moves the cursor to the previous file in its row.
+func (m *Model) MoveLeft() {
+	r, col := m.cursorCell()
+	if col > 0 {
+		m.cursor = m.rows[r][col-1]
+	}
+}
+
+// MoveRight moves the cursor to the next file in its row.
+func (m *Model) MoveRight() {
+	r, col := m.cursorCell()
+	if r < len(m.rows) && col < len(m.rows[r])-1 {
+		m.cursor = m.rows[r][col+1]
+	}
+}
+
 // projectRepoOffset returns the global repo index offset for repos in a given project.
 func (m Model) projectRepoOffset(projectIndex int) int {
 	offset := 0
@@ -499,7 +1240,11 @@ func (m *Model) skipNonSelectable(dir int) {
 		}
 	}
 	if m.cursor < 0 {
+		// Ran off the top: take the first selectable item instead
 		m.cursor = 0
+		for m.cursor < len(m.flatItems)-1 && isNonSelectable(m.flatItems[m.cursor].Kind) {
+			m.cursor++
+		}
 	}
 }
 
@@ -514,27 +1259,29 @@ func (m Model) listHeight() int {
 
 func (m *Model) ensureCursorVisible() {
 	h := m.listHeight()
-	if m.cursor < m.scrollOffset {
-		m.scrollOffset = m.cursor
-	} else if m.cursor >= m.scrollOffset+h {
-		m.scrollOffset = m.cursor - h + 1
+	row, _ := m.cursorCell()
+	if row < m.scrollOffset {
+		m.scrollOffset = row
+	} else if row >= m.scrollOffset+h {
+		m.scrollOffset = row - h + 1
 	}
 }
 
 func (m *Model) MoveDown() {
-	if m.cursor < len(m.flatItems)-1 {
-		m.cursor++
-		m.skipNonSelectable(1)
-		m.ensureCursorVisible()
-	}
+	m.moveRows(1)
 }
 
 func (m *Model) MoveUp() {
-	if m.cursor > 0 {
-		m.cursor--
-		m.skipNonSelectable(-1)
-		m.ensureCursorVisible()
+	m.moveRows(-1)
+}
+
+// PageMove moves the cursor for a paging key (half/full page, top, bottom).
+func (m *Model) PageMove(msg tea.KeyMsg) {
+	delta, ok := shared.PageDelta(msg, m.listHeight(), len(m.rows))
+	if !ok {
+		return
 	}
+	m.moveRows(delta)
 }
 
 func (m *Model) NextRepo() {
@@ -569,6 +1316,27 @@ func (m *Model) PrevRepo() {
 	m.ensureCursorVisible()
 }
 
+// ClickRow moves the cursor to the item at column x of visible row y (0 =
+// top of the list) and returns it. Section headers and rows past the list
+// are ignored.
+func (m *Model) ClickRow(x, y int) (FlatItem, bool) {
+	if y < 0 || y >= m.listHeight() || m.scrollOffset+y >= len(m.rows) {
+		return FlatItem{}, false
+	}
+	row := m.rows[m.scrollOffset+y]
+	idx := row[min(max(x, 0)/m.cellWidth(), len(row)-1)]
+	if isNonSelectable(m.flatItems[idx].Kind) {
+		return FlatItem{}, false
+	}
+	m.cursor = idx
+	return m.flatItems[idx], true
+}
+
+// cellWidth is the width of one file column.
+func (m Model) cellWidth() int {
+	return max(m.width/m.fileColumns(), 1)
+}
+
 func (m Model) SelectedItem() (FlatItem, bool) {
 	if m.cursor < 0 || m.cursor >= len(m.flatItems) {
 		return FlatItem{}, false
@@ -585,41 +1353,77 @@ func (m Model) SelectedRepo() (*git.RepoStatus, bool) {
 }
 
 func (m Model) RepoHasStagedFiles(repoIndex int) bool {
+	return m.StagedCount(repoIndex) > 0
+}
+
+// StagedCount returns how many files in the repo are staged.
+func (m Model) StagedCount(repoIndex int) int {
 	if repoIndex < 0 || repoIndex >= len(m.repos) {
-		return false
+		return 0
 	}
+	n := 0
 	for _, f := range m.repos[repoIndex].Files {
 		if f.StagingState == git.Staged {
-			return true
+			n++
 		}
 	}
-	return false
+	return n
 }
 
 func (m Model) View() string {
 	if len(m.flatItems) == 0 {
+		if m.hideClean && len(m.repos) > 0 {
+			return "\n  Everything is clean. Press H to show clean repos.\n"
+		}
 		return "\n  No repos configured or no changes found.\n"
 	}
 
 	visibleHeight := m.listHeight()
 
+	end := m.scrollOffset + visibleHeight
+	if end > len(m.rows) {
+		end = len(m.rows)
+	}
+
+	// Only the visible window is rendered, however long the list is
 	var b strings.Builder
-	for i, item := range m.flatItems {
-		if i < m.scrollOffset {
-			continue
-		}
-		if i >= m.scrollOffset+visibleHeight {
-			break
+	for r := m.scrollOffset; r < end; r++ {
+		row := m.rows[r]
+		if m.fileColumns() == 1 || !inColumns(m.flatItems[row[0]].Kind) {
+			line := m.renderItem(m.flatItems[row[0]])
+			if row[0] == m.cursor {
+				line = shared.CursorStyle.Width(m.width).Render(line)
+			}
+			b.WriteString(line)
+		} else {
+			b.WriteString(m.renderCells(row))
 		}
+		b.WriteString("\n")
+	}
+
+	bar := shared.Scrollbar(len(m.rows), visibleHeight, m.scrollOffset, visibleHeight)
+	return shared.WithScrollbar(b.String(), m.width, bar)
+}
 
-		line := m.renderItem(item)
+// renderCells renders a row of file columns, each clipped to its cell.
+func (m Model) renderCells(row []int) string {
+	w := m.cellWidth()
+	clip := lipgloss.NewStyle().MaxWidth(w - 1)
+	var b strings.Builder
+	for _, i := range row {
+		item := m.flatItems[i]
+		var cell string
+		if item.Kind == File {
+			cell = clip.Render(m.renderFile(item, w-1))
+		} else {
+			cell = clip.Render(m.renderItem(item))
+		}
 		if i == m.cursor {
-			line = shared.CursorStyle.Width(m.width).Render(line)
+			cell = shared.CursorStyle.Width(w - 1).Render(cell)
 		}
-		b.WriteString(line)
-		b.WriteString("\n")
+		b.WriteString(cell)
+		b.WriteString(strings.Repeat(" ", max(w-lipgloss.Width(cell), 0)))
 	}
-
 	return b.String()
 }
 
@@ -636,7 +1440,11 @@ func (m Model) renderItem(item FlatItem) string {
 	case FolderHeader:
 		return m.renderFolderHeader(item)
 	case File:
-		return m.renderFile(item)
+		return m.renderFile(item, m.width)
+	case MoreFiles:
+		return "      " + shared.MutedFileStyle.Render(fmt.Sprintf("… %d more files (enter to show)", item.Hidden))
+	case UntrackedDir:
+		return m.renderUntrackedDir(item)
 	}
 	return ""
 }
@@ -657,11 +1465,12 @@ func (m Model) renderProjectHeader(item FlatItem) string {
 
 	// Count total changes across project repos
 	offset := m.projectRepoOffset(item.ProjectIndex)
-	var totalChanges int
+	var totalChanges, ahead int
 	allClean := true
 	for i := 0; i < len(proj.Repos); i++ {
 		ri := offset + i
 		if ri < len(m.repos) {
+			ahead += m.repos[ri].Ahead
 			if m.repos[ri].Error != nil {
 				allClean = false
 			} else if len(m.repos[ri].Files) > 0 {
@@ -678,6 +1487,10 @@ func (m Model) renderProjectHeader(item FlatItem) string {
 	} else if totalChanges > 0 {
 		left += " " + shared.HelpDescStyle.Render(fmt.Sprintf("%d changes", totalChanges))
 	}
+	// Unpushed commits anywhere in the project
+	if ahead > 0 {
+		left += " " + shared.SyncPushBadge.Render(fmt.Sprintf("↑ %d", ahead))
+	}
 
 	// Conductor summary badge (if set)
 	if summary, ok := m.projectConductor[item.ProjectIndex]; ok && summary != "" {
@@ -697,6 +1510,9 @@ func (m Model) renderProjectHeader(item FlatItem) string {
 func (m Model) renderRepoHeader(item FlatItem) string {
 	repo := item.Repo
 	name := shared.RepoHeaderStyle.Render(repo.Name)
+	if m.pinned[repo.Path] {
+		name = shared.PinnedStyle.Render("★") + " " + name
+	}
 	branch := shared.BranchStyle.Render(repo.Branch)
 
 	chevron := "▼"
@@ -722,10 +1538,40 @@ func (m Model) renderRepoHeader(item FlatItem) string {
 		syncBadge = shared.SyncPullBadge.Render(fmt.Sprintf("↓ %d to pull", repo.Behind))
 	}
 
+	// The compare_remote counts, when they differ from the upstream's
+	if repo.CompareRef != "" && (repo.CompareAhead != repo.Ahead || repo.CompareBehind != repo.Behind) {
+		cmp := shared.MutedFileStyle.Render(repo.CompareRef)
+		if repo.CompareAhead > 0 {
+			cmp += " " + shared.SyncPushBadge.Render(fmt.Sprintf("↑ %d", repo.CompareAhead))
+		}
+		if repo.CompareBehind > 0 {
+			cmp += " " + shared.SyncPullBadge.Render(fmt.Sprintf("↓ %d", repo.CompareBehind))
+		}
+		if repo.CompareAhead == 0 && repo.CompareBehind == 0 {
+			cmp += " " + shared.MutedFileStyle.Render("even")
+		}
+		if syncBadge != "" {
+			syncBadge += " "
+		}
+		syncBadge += cmp
+	}
+
+	// Prominent badge while a merge/rebase is underway
+	branchLabel := "[" + branch + "]"
+	if repo.Detached {
+		branchLabel = shared.DetachedStyle.Render(repo.BranchLabel())
+	}
+	if repo.InProgress != "" {
+		branchLabel += " " + shared.InProgressBadge.Render(strings.ToUpper(repo.InProgress))
+	}
+	if repo.StashCount > 0 {
+		branchLabel += " " + shared.StashBadge.Render(fmt.Sprintf("⚑%d", repo.StashCount))
+	}
+
 	fileCount := len(repo.Files)
 	var left string
 	if fileCount == 0 {
-		left = fmt.Sprintf("  %s %s [%s] — clean", chevron, name, branch)
+		left = fmt.Sprintf("  %s %s %s — clean", chevron, name, branchLabel)
 	} else {
 		// Count staged vs unstaged
 		var stagedCount, unstagedCount int
@@ -737,7 +1583,15 @@ func (m Model) renderRepoHeader(item FlatItem) string {
 			}
 		}
 		summary := shared.HelpDescStyle.Render(fmt.Sprintf("%d staged, %d unstaged", stagedCount, unstagedCount))
-		left = fmt.Sprintf("  %s %s [%s] %s", chevron, name, branch, summary)
+		left = fmt.Sprintf("  %s %s %s %s", chevron, name, branchLabel, summary)
+	}
+
+	// Dimmed origin owner/repo, dropped first when the row is too narrow
+	if m.display.ShowRemote && repo.RemoteURL != "" {
+		remote := " " + shared.MutedFileStyle.Render(git.RemoteSlug(repo.RemoteURL))
+		if lipgloss.Width(left)+lipgloss.Width(remote)+lipgloss.Width(syncBadge)+2 <= m.width {
+			left += remote
+		}
 	}
 
 	if syncBadge == "" || m.width < 20 {
@@ -755,6 +1609,9 @@ func (m Model) renderRepoHeader(item FlatItem) string {
 }
 
 func (m Model) renderSectionHeader(item FlatItem) string {
+	if item.Section == "pinned" {
+		return "  " + shared.PinnedSectionStyle.Render("Pinned:")
+	}
 	if item.Section == "staged" {
 		return "    " + shared.StagedSectionStyle.Render("Staged Changes:")
 	}
@@ -779,6 +1636,21 @@ func (m Model) renderDocHeader(item FlatItem) string {
 	return "    " + chevron + " " + shared.DimFileStyle.Render(label)
 }
 
+func (m Model) renderUntrackedDir(item FlatItem) string {
+	chevron := "▶"
+	if m.untrackedOpen[folderKey(item.RepoIndex, item.Dir)] {
+		chevron = "▼"
+	}
+	noun := "files"
+	if item.Count == 1 {
+		noun = "file"
+	}
+	status := shared.DimFileStyle.Render(fmt.Sprintf("[%s]", git.StatusUntracked))
+	dir := shared.FolderStyle(filepath.Base(item.Dir)).Render(chevron + " " + item.Dir + "/")
+	return fmt.Sprintf("      %s %s %s %s", shared.UnstagedIndicator, status, dir,
+		shared.MutedFileStyle.Render(fmt.Sprintf("(%d %s)", item.Count, noun)))
+}
+
 func (m Model) renderFolderHeader(item FlatItem) string {
 	dirName := filepath.Base(item.Dir)
 	icon := icons.ForDir(dirName)
@@ -790,10 +1662,17 @@ func (m Model) renderFolderHeader(item FlatItem) string {
 
 	style := shared.FolderStyle(dirName)
 
+	if m.folderTree() {
+		// Nested: indented by depth, named by its own level only
+		indent := strings.Repeat("  ", strings.Count(item.Dir, "/"))
+		return "      " + indent + chevron + " " + style.Render(icon+" "+dirName+"/")
+	}
 	return "      " + chevron + " " + style.Render(icon+" "+item.Dir+"/")
 }
 
-func (m Model) renderFile(item FlatItem) string {
+// renderFile renders a file row width columns wide (line stats are
+// right-aligned to it).
+func (m Model) renderFile(item FlatItem, width int) string {
 	file := item.File
 	var indicator string
 	var style lipgloss.Style
@@ -820,13 +1699,20 @@ func (m Model) renderFile(item FlatItem) string {
 		}
 	}
 
-	status := fmt.Sprintf("[%s]", file.Status)
+	status := style.Render(fmt.Sprintf("[%s]", file.Status))
+	if file.Status == git.StatusConflicted {
+		indicator = shared.ConflictIndicator
+		status = shared.ErrorStyle.Render(fmt.Sprintf("[conflict: %s]", file.ConflictDescription()))
+	}
 
 	// Show basename when grouped under a folder header
 	indent := "      "
-	underFolder := m.display.GroupFolders && item.Dir != "." && item.Dir != ""
+	underFolder := m.display.GroupFolders && item.Dir != "." && item.Dir != "" && !m.byStatus
 	if underFolder {
 		indent = "        " // extra indent under folder header
+		if m.folderTree() {
+			indent += strings.Repeat("  ", strings.Count(item.Dir, "/"))
+		}
 	}
 
 	showIcons := m.display.Icons || m.display.NerdFonts
@@ -858,5 +1744,34 @@ func (m Model) renderFile(item FlatItem) string {
 		pathStr = shared.RenderPathWithStyle(file.Path, style)
 	}
 
-	return fmt.Sprintf("%s%s %s%s %s", indent, indicator, iconStr, style.Render(status), pathStr)
+	if m.byStatus {
+		pathStr = shared.RepoHeaderStyle.Render(item.Repo.Name) + " " + pathStr
+	}
+
+	line := fmt.Sprintf("%s%s %s%s %s", indent, indicator, iconStr, status, pathStr)
+	if m.display.FileStats {
+		line = m.withLineStats(line, file, width)
+	}
+	return line
+}
+
+// withLineStats right-aligns the file's +added/-deleted counts after line,
+// dropping them when the row is too narrow.
+func (m Model) withLineStats(line string, file *git.FileEntry, width int) string {
+	var parts []string
+	if file.Added > 0 {
+		parts = append(parts, shared.CommitStatAddStyle.Render(fmt.Sprintf("+%d", file.Added)))
+	}
+	if file.Deleted > 0 {
+		parts = append(parts, shared.CommitStatDelStyle.Render(fmt.Sprintf("-%d", file.Deleted)))
+	}
+	if len(parts) == 0 {
+		return line
+	}
+	stats := strings.Join(parts, " ")
+	gap := width - lipgloss.Width(line) - lipgloss.Width(stats) - 1
+	if gap < 2 {
+		return line
+	}
+	return line + strings.Repeat(" ", gap) + stats
 }
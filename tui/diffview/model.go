@@ -6,6 +6,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dylan/gitdash/git"
 	"github.com/dylan/gitdash/tui/shared"
 )
 
@@ -13,6 +14,14 @@ type Model struct {
 	viewport viewport.Model
 	file     string
 	repoPath string
+	entry    git.FileEntry
+	mode     git.DiffMode
+	lines    []string   // styled diff lines
+	hunks    []git.Hunk // the diff's hunks, in order
+	hunkAt   []int      // the line each hunk's "@@" header is on
+	hunk     int        // focused hunk
+	added    int        // lines added across the diff
+	deleted  int        // lines removed across the diff
 	ready    bool
 	width    int
 	height   int
@@ -36,12 +45,73 @@ func (m *Model) SetSize(w, h int) {
 	m.ready = true
 }
 
-func (m *Model) SetContent(rawDiff, file, repoPath string) {
+// SetContent shows rawDiff for file. Reloading the same file (after a mode
+// change or staging) keeps the scroll position.
+func (m *Model) SetContent(rawDiff, file, repoPath string, entry git.FileEntry, mode git.DiffMode) {
+	same := file == m.file && repoPath == m.repoPath
 	m.file = file
 	m.repoPath = repoPath
-	styled := styleDiff(rawDiff)
-	m.viewport.SetContent(styled)
-	m.viewport.GotoTop()
+	m.entry = entry
+	m.mode = mode
+	if strings.TrimSpace(rawDiff) == "" {
+		rawDiff = "No " + mode.String() + " changes"
+	}
+	m.lines, m.hunkAt = styleDiff(rawDiff)
+	m.hunks = git.SplitHunks(rawDiff)
+	m.added, m.deleted = countLines(rawDiff)
+	if !same || m.hunk >= len(m.hunks) {
+		m.hunk = 0
+	}
+	m.render()
+	if !same {
+		m.viewport.GotoTop()
+	}
+}
+
+func (m *Model) render() {
+	lines := m.lines
+	if m.hunk < len(m.hunkAt) {
+		lines = append([]string(nil), m.lines...)
+		at := m.hunkAt[m.hunk]
+		lines[at] = shared.CursorStyle.Render("▸ ") + lines[at]
+	}
+	m.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+// NextHunk focuses the next hunk and scrolls to it.
+func (m *Model) NextHunk() {
+	if m.hunk < len(m.hunks)-1 {
+		m.hunk++
+	}
+	m.showHunk()
+}
+
+// PrevHunk focuses the previous hunk and scrolls to it.
+func (m *Model) PrevHunk() {
+	if m.hunk > 0 {
+		m.hunk--
+	}
+	m.showHunk()
+}
+
+func (m *Model) showHunk() {
+	m.render()
+	if m.hunk < len(m.hunkAt) {
+		m.viewport.SetYOffset(m.hunkAt[m.hunk])
+	}
+}
+
+// FocusedHunk returns the hunk marked with ▸, false when the diff has none.
+func (m Model) FocusedHunk() (git.Hunk, bool) {
+	if m.hunk >= len(m.hunks) {
+		return git.Hunk{}, false
+	}
+	return m.hunks[m.hunk], true
+}
+
+// Source returns what the view is showing, so the diff can be re-fetched.
+func (m Model) Source() (repoPath, file string, entry git.FileEntry, mode git.DiffMode) {
+	return m.repoPath, m.file, m.entry, m.mode
 }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
@@ -55,30 +125,67 @@ func (m Model) View() string {
 		return "Loading..."
 	}
 
-	header := shared.DiffHeaderStyle.Width(m.width).Render(fmt.Sprintf(" Diff: %s", m.file))
-	footer := shared.DiffFooterStyle.Width(m.width).Render("j/k: scroll  s: stage  u: unstage  q/esc: close")
+	title := fmt.Sprintf(" Diff: %s", m.file)
+	if len(m.hunkAt) > 0 {
+		hunks := "hunks"
+		if len(m.hunkAt) == 1 {
+			hunks = "hunk"
+		}
+		title += "  " + shared.StatAddBadge.Render(fmt.Sprintf("+%d", m.added)) +
+			" " + shared.StatDelBadge.Render(fmt.Sprintf("-%d", m.deleted)) +
+			fmt.Sprintf(", %d %s", len(m.hunkAt), hunks)
+	}
+	header := shared.DiffHeaderStyle.Width(m.width).Render(title)
+	hunk := "h: stage hunk  H: commit hunk"
+	if m.mode == git.DiffStaged {
+		hunk = "h: unstage hunk"
+	}
+	footer := shared.DiffFooterStyle.Width(m.width).Render(
+		fmt.Sprintf("[%s]  j/k: scroll  [/]: hunk  %s  m: cycle mode  s: stage  u: unstage  q/esc: close", m.mode, hunk))
 
 	return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), footer)
 }
 
-func styleDiff(raw string) string {
-	var b strings.Builder
+// styleDiff colours raw line by line, also returning the lines its hunk
+// headers ended up on.
+func styleDiff(raw string) (lines []string, hunkAt []int) {
+	var hdr shared.DiffHeader
 	for _, line := range strings.Split(raw, "\n") {
+		if note, ok := hdr.Annotate(line); ok {
+			if note != "" {
+				lines = append(lines, note)
+			}
+			continue
+		}
 		switch {
 		case strings.HasPrefix(line, "+++ ") || strings.HasPrefix(line, "--- "):
-			b.WriteString(shared.DiffMetaStyle.Render(line))
+			line = shared.DiffMetaStyle.Render(line)
 		case strings.HasPrefix(line, "@@"):
-			b.WriteString(shared.DiffHunkStyle.Render(line))
+			hunkAt = append(hunkAt, len(lines))
+			line = shared.DiffHunkStyle.Render(line)
 		case strings.HasPrefix(line, "+"):
-			b.WriteString(shared.DiffAddStyle.Render(line))
+			line = shared.DiffAddStyle.Render(line)
 		case strings.HasPrefix(line, "-"):
-			b.WriteString(shared.DiffRemoveStyle.Render(line))
+			line = shared.DiffRemoveStyle.Render(line)
 		case strings.HasPrefix(line, "diff ") || strings.HasPrefix(line, "index "):
-			b.WriteString(shared.DiffMetaStyle.Render(line))
-		default:
-			b.WriteString(line)
+			line = shared.DiffMetaStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return lines, hunkAt
+}
+
+// countLines counts the added and removed lines in raw, leaving out the
+// "+++"/"---" file headers.
+func countLines(raw string) (added, deleted int) {
+	for _, line := range strings.Split(raw, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---"):
+		case strings.HasPrefix(line, "+"):
+			added++
+		case strings.HasPrefix(line, "-"):
+			deleted++
 		}
-		b.WriteString("\n")
 	}
-	return b.String()
+	return added, deleted
 }
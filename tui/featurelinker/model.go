@@ -332,6 +332,16 @@ func (m Model) ViewOverlay(background string, w, h int) string {
 	)
 }
 
+// maxContentWidth caps the overlay's width on wide terminals so lines stay
+// readable.
+const maxContentWidth = 100
+
+// contentWidth is how wide a line inside the overlay may be: the terminal
+// minus the border and padding, within reason.
+func (m Model) contentWidth() int {
+	return min(max(m.width-6, 40), maxContentWidth)
+}
+
 func (m Model) maxVisibleItems() int {
 	// Reserve lines for: title(1) + blank(1) + [search(2)] + help(1) + blank(1) + skip(1) + detail(5) = ~12
 	maxH := m.height - 16
@@ -386,7 +396,7 @@ func (m Model) renderContent() string {
 	var b strings.Builder
 
 	// Title with AI status
-	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("255")).Render("Link commit to feature?")
+	title := shared.TitleStyle.Render("Link commit to feature?")
 	if m.aiPending {
 		title += " " + m.aiSpinner + " " + shared.DimFileStyle.Render("analyzing...")
 	} else if len(m.aiRankedIDs) > 0 {
@@ -425,12 +435,6 @@ func (m Model) renderContent() string {
 
 			icon := statusIconStyle(match.Feature.Status).Render(statusIcon(match.Feature.Status))
 
-			desc := match.Feature.Description
-			maxDesc := 40
-			if len(desc) > maxDesc {
-				desc = desc[:maxDesc-3] + "..."
-			}
-
 			cat := shared.DimFileStyle.Render("[" + match.Feature.Category + "]")
 
 			var aiTag string
@@ -443,6 +447,10 @@ func (m Model) renderContent() string {
 				score = " " + shared.DimFileStyle.Render(fmt.Sprintf("(%d%%)", int(match.Score*100)))
 			}
 
+			// The description gets whatever the rest of the line leaves
+			rest := lipgloss.Width(prefix+icon) + 2 + lipgloss.Width(cat+aiTag+score)
+			desc := truncate(match.Feature.Description, m.contentWidth()-rest)
+
 			line := prefix + icon + " " + desc + " " + cat + aiTag + score
 
 			if i == m.cursor {
@@ -494,7 +502,7 @@ func (m Model) renderDetail(fm conductor.FeatureMatch) string {
 	b.WriteString("\n")
 
 	// Full description
-	b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("255")).Render(fm.Feature.Description))
+	b.WriteString(shared.CommitDetailMsgStyle.Render(fm.Feature.Description))
 	b.WriteString("\n")
 
 	// Phase + category
@@ -519,10 +527,7 @@ func (m Model) renderDetail(fm conductor.FeatureMatch) string {
 				related = related[:2]
 			}
 			for _, mem := range related {
-				name := mem.Name
-				if len(name) > 30 {
-					name = name[:27] + "..."
-				}
+				name := truncate(mem.Name, m.contentWidth()-5)
 				b.WriteString(shared.DimFileStyle.Render("  💡 " + name))
 				b.WriteString("\n")
 			}
@@ -531,3 +536,13 @@ func (m Model) renderDetail(fm conductor.FeatureMatch) string {
 
 	return b.String()
 }
+
+// truncate shortens s to at most maxW runes, marking the cut with "...".
+func truncate(s string, maxW int) string {
+	maxW = max(maxW, 10)
+	r := []rune(s)
+	if len(r) <= maxW {
+		return s
+	}
+	return string(r[:maxW-3]) + "..."
+}
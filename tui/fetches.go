@@ -0,0 +1,58 @@
+package tui
+
+import "context"
+
+// fetchOp is a kind of read-only fetch whose result is only wanted from
+// the most recent request: a newer one makes the older one stale.
+type fetchOp int
+
+const (
+	fetchDiff fetchOp = iota
+	fetchGraph
+	fetchDetail
+)
+
+// fetches hands out a generation number per fetchOp. Starting a fetch
+// cancels the previous one of the same kind (killing its git process), and
+// results carrying an older generation are dropped when they arrive.
+type fetches struct {
+	gen    map[fetchOp]int
+	cancel map[fetchOp]context.CancelFunc
+}
+
+func newFetches() *fetches {
+	return &fetches{
+		gen:    make(map[fetchOp]int),
+		cancel: make(map[fetchOp]context.CancelFunc),
+	}
+}
+
+// start begins a new fetch of op, superseding any in flight.
+func (f *fetches) start(op fetchOp) (context.Context, int) {
+	f.stop(op)
+	ctx, cancel := context.WithCancel(context.Background())
+	f.cancel[op] = cancel
+	return ctx, f.gen[op]
+}
+
+// stop cancels the fetch of op in flight, if any, so its result is ignored.
+func (f *fetches) stop(op fetchOp) {
+	if cancel := f.cancel[op]; cancel != nil {
+		cancel()
+		delete(f.cancel, op)
+	}
+	f.gen[op]++
+}
+
+// current reports whether gen is the latest fetch of op. A current result
+// also releases the fetch's context.
+func (f *fetches) current(op fetchOp, gen int) bool {
+	if gen != f.gen[op] {
+		return false
+	}
+	if cancel := f.cancel[op]; cancel != nil {
+		cancel()
+		delete(f.cancel, op)
+	}
+	return true
+}
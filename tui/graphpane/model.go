@@ -2,12 +2,14 @@ package graphpane
 
 import (
 	"fmt"
+	"hash/fnv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/dylan/gitdash/commit"
 	"github.com/dylan/gitdash/conductor"
 	"github.com/dylan/gitdash/git"
 	"github.com/dylan/gitdash/tui/icons"
@@ -35,9 +37,12 @@ type Model struct {
 	cursor        int   // index into commitIndices
 	commitIndices []int // line indices where IsCommit == true
 
-	// Commit detail (middle section, display-only)
-	detail     *git.CommitDetail
-	detailHash string
+	// Commit detail (middle section, display-only). Expanded adds emails,
+	// full dates, the committer and parents, scrolled in detailVP.
+	detail         *git.CommitDetail
+	detailHash     string
+	detailVP       viewport.Model
+	expandedDetail bool
 
 	// Files section (bottom)
 	fileCursor   int
@@ -56,7 +61,19 @@ type Model struct {
 	// Conductor commit context (enriched detail)
 	commitContext *conductor.CommitContext
 
-	showIcons bool
+	// AI summaries by commit hash, kept across repos and refreshes so a
+	// commit is only summarized once
+	summaries   map[string]string
+	summarizing map[string]bool
+
+	// Incoming commits preview, shown in place of the graph until cleared
+	showIncoming bool
+	incoming     []git.RecentCommitInfo
+	upstream     string
+	incomingRepo string
+
+	showIcons   bool
+	showAuthors bool
 
 	ready  bool
 	width  int
@@ -68,11 +85,18 @@ func (m *Model) SetShowIcons(show bool) {
 	m.showIcons = show
 }
 
+// SetShowAuthors enables author initials before each commit message.
+func (m *Model) SetShowAuthors(show bool) {
+	m.showAuthors = show
+}
+
 func New() Model {
 	return Model{
 		fileExpanded:   make(map[string]bool),
 		fileDiffs:      make(map[string]string),
 		linkedFeatures: make(map[string]string),
+		summaries:      make(map[string]string),
+		summarizing:    make(map[string]bool),
 	}
 }
 
@@ -100,29 +124,35 @@ func (m *Model) rebuildViewports() {
 		return
 	}
 
-	graphH, _, filesH := m.sectionHeights()
+	graphH, detailH, filesH := m.sectionHeights()
 
 	// Save scroll positions before rebuilding
 	savedGraphY := m.graphVP.YOffset
+	savedDetailY := m.detailVP.YOffset
 	savedFilesY := m.filesVP.YOffset
 
 	m.graphVP = viewport.New(m.width, graphH)
+	m.detailVP = viewport.New(m.width, detailH)
 	m.filesVP = viewport.New(m.width, filesH)
 
-	if len(m.renderedLines) > 0 {
+	if m.showIncoming {
+		m.graphVP.SetContent(m.composeIncoming())
+	} else if len(m.renderedLines) > 0 {
 		m.graphVP.SetContent(m.composeGraph())
 		m.ensureGraphCursorVisible()
 	}
+	m.detailVP.SetContent(m.renderDetail())
 	m.filesVP.SetContent(m.renderFiles())
 
 	// Restore scroll positions
 	m.graphVP.SetYOffset(savedGraphY)
+	m.detailVP.SetYOffset(savedDetailY)
 	m.filesVP.SetYOffset(savedFilesY)
 }
 
 func (m Model) sectionHeights() (graphH, detailH, filesH int) {
 	h := m.height
-	if m.detail != nil {
+	if m.detail != nil && !m.showIncoming {
 		// Reserve 2 lines for newline separators between sections
 		usable := h - 2
 		if usable < 6 {
@@ -156,6 +186,10 @@ func (m *Model) SetGraph(lines []git.GraphLine, repoPath string) {
 	m.repoPath = repoPath
 
 	// Only reset detail/file state when switching repos
+	if repoPath != m.incomingRepo {
+		m.showIncoming = false
+		m.incoming = nil
+	}
 	if !sameRepo {
 		m.detail = nil
 		m.detailHash = ""
@@ -174,9 +208,10 @@ func (m *Model) SetGraph(lines []git.GraphLine, repoPath string) {
 		}
 	}
 
-	// Preserve cursor position on same-repo refresh (polling)
+	// Preserve cursor position on same-repo refresh (polling); otherwise
+	// start on the checked-out commit
 	if !sameRepo || len(m.commitIndices) != oldCount {
-		m.cursor = 0
+		m.cursor = m.headCursor()
 	} else if m.cursor >= len(m.commitIndices) {
 		m.cursor = max(0, len(m.commitIndices)-1)
 	}
@@ -188,8 +223,20 @@ func (m *Model) SetGraph(lines []git.GraphLine, repoPath string) {
 		m.rebuildViewports()
 		if !sameRepo || len(m.commitIndices) != oldCount {
 			m.graphVP.GotoTop()
+			m.ensureGraphCursorVisible()
+		}
+	}
+}
+
+// headCursor returns the cursor position of the HEAD commit, or 0 when
+// it's not in the graph.
+func (m Model) headCursor() int {
+	for c, i := range m.commitIndices {
+		if m.lines[i].IsHead() {
+			return c
 		}
 	}
+	return 0
 }
 
 // buildRenderedLines pre-renders all graph lines. Called once on SetGraph
@@ -198,7 +245,7 @@ func (m *Model) SetGraph(lines []git.GraphLine, repoPath string) {
 func (m *Model) buildRenderedLines() {
 	m.renderedLines = make([]string, len(m.lines))
 	for i, line := range m.lines {
-		m.renderedLines[i] = renderLine(line)
+		m.renderedLines[i] = renderLine(line, m.showAuthors)
 	}
 }
 
@@ -209,15 +256,66 @@ func (m *Model) SetCommitDetail(detail git.CommitDetail) {
 	m.fileCursor = 0
 	m.fileExpanded = make(map[string]bool)
 	m.fileDiffs = make(map[string]string)
+	m.detailVP.GotoTop()
 	m.rebuildViewports()
 }
 
+// ToggleExpandedDetail switches the commit detail between the compact and
+// expanded views.
+func (m *Model) ToggleExpandedDetail() {
+	m.expandedDetail = !m.expandedDetail
+	m.detailVP.GotoTop()
+	m.detailVP.SetContent(m.renderDetail())
+}
+
+// ExpandedDetail returns true while the expanded commit detail is shown.
+func (m Model) ExpandedDetail() bool {
+	return m.expandedDetail
+}
+
+// scrollDetail scrolls the commit detail by delta lines. The content is
+// refreshed first since summaries and context arrive after the detail.
+func (m *Model) scrollDetail(delta int) {
+	m.detailVP.SetContent(m.renderDetail())
+	m.detailVP.SetYOffset(m.detailVP.YOffset + delta)
+}
+
 // SetCommitContext sets the conductor context for the current commit detail.
 func (m *Model) SetCommitContext(ctx *conductor.CommitContext) {
 	m.commitContext = ctx
 	m.rebuildViewports()
 }
 
+// StartSummary marks hash as being summarized. It returns false if a
+// summary is already cached or on its way, so the caller needn't ask again.
+func (m *Model) StartSummary(hash string) bool {
+	if _, ok := m.summaries[hash]; ok || m.summarizing[hash] {
+		return false
+	}
+	m.summarizing[hash] = true
+	return true
+}
+
+// SetSummary stores the AI summary of hash. On error nothing is cached,
+// so the summary can be asked for again.
+func (m *Model) SetSummary(hash, summary string, err error) {
+	delete(m.summarizing, hash)
+	if err == nil {
+		m.summaries[hash] = summary
+	}
+}
+
+// summaryFor returns the cached summary for the commit shown in the
+// detail, and whether one is being generated. Graph hashes are
+// abbreviated, so they're matched by prefix.
+func (m Model) summaryFor(fullHash string) (string, bool) {
+	hash := m.SelectedHash()
+	if hash == "" || !strings.HasPrefix(fullHash, hash) {
+		return "", false
+	}
+	return m.summaries[hash], m.summarizing[hash]
+}
+
 func (m *Model) SetFileDiff(path, diff string) {
 	m.fileDiffs[path] = diff
 	m.filesVP.SetContent(m.renderFiles())
@@ -246,6 +344,74 @@ func (m *Model) MoveUp() {
 	}
 }
 
+// pageMove moves the commit cursor by a paging key's delta.
+func (m *Model) pageMove(delta int) {
+	if len(m.commitIndices) == 0 {
+		return
+	}
+	m.cursor = max(0, min(m.cursor+delta, len(m.commitIndices)-1))
+	m.graphVP.SetContent(m.composeGraph())
+	m.ensureGraphCursorVisible()
+}
+
+// NextRef moves the cursor to the next commit with a branch or tag,
+// wrapping around at the end.
+func (m *Model) NextRef() {
+	m.stepToRef(1)
+}
+
+// PrevRef moves the cursor to the previous commit with a branch or tag,
+// wrapping around at the start.
+func (m *Model) PrevRef() {
+	m.stepToRef(-1)
+}
+
+func (m *Model) stepToRef(dir int) {
+	n := len(m.commitIndices)
+	for i := 1; i < n; i++ {
+		c := (m.cursor + dir*i + n) % n
+		if m.lines[m.commitIndices[c]].Refs != "" {
+			m.pageMove(c - m.cursor)
+			return
+		}
+	}
+}
+
+// Refs lists the graph's branches and tags, newest commit first.
+func (m Model) Refs() []git.Ref {
+	var refs []git.Ref
+	for _, i := range m.commitIndices {
+		refs = append(refs, m.lines[i].RefList()...)
+	}
+	return refs
+}
+
+// SelectHash moves the cursor to hash's commit, reporting whether it's in
+// the graph.
+func (m *Model) SelectHash(hash string) bool {
+	for c, i := range m.commitIndices {
+		if m.lines[i].Hash == hash {
+			m.pageMove(c - m.cursor)
+			return true
+		}
+	}
+	return false
+}
+
+// FindHash lists the graph's commits whose hash starts with prefix, or
+// that prefix (a full hash) starts with.
+func (m Model) FindHash(prefix string) []string {
+	prefix = strings.ToLower(prefix)
+	var hashes []string
+	for _, i := range m.commitIndices {
+		h := m.lines[i].Hash
+		if h != "" && (strings.HasPrefix(h, prefix) || strings.HasPrefix(prefix, h)) {
+			hashes = append(hashes, h)
+		}
+	}
+	return hashes
+}
+
 func (m *Model) ensureGraphCursorVisible() {
 	if len(m.commitIndices) == 0 {
 		return
@@ -393,11 +559,51 @@ func (m Model) RepoPath() string {
 	return m.repoPath
 }
 
+// SetIncoming replaces the graph with the commits HEAD is behind upstream by.
+func (m *Model) SetIncoming(commits []git.RecentCommitInfo, upstream, repoPath string) {
+	m.showIncoming = true
+	m.incomingRepo = repoPath
+	m.incoming = commits
+	m.upstream = upstream
+	m.rebuildViewports()
+	m.graphVP.GotoTop()
+}
+
+// ClearIncoming returns to the normal graph.
+func (m *Model) ClearIncoming() {
+	m.showIncoming = false
+	m.incoming = nil
+	m.rebuildViewports()
+}
+
+// ShowingIncoming returns true while the incoming commits preview is shown.
+func (m Model) ShowingIncoming() bool {
+	return m.showIncoming
+}
+
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.showIncoming {
+			if delta, ok := shared.PageDelta(msg, m.graphVP.Height, m.graphVP.TotalLineCount()); ok {
+				m.graphVP.SetYOffset(m.graphVP.YOffset + delta)
+				return m, nil
+			}
+			switch {
+			case key.Matches(msg, shared.Keys.Down):
+				m.graphVP.LineDown(1)
+			case key.Matches(msg, shared.Keys.Up):
+				m.graphVP.LineUp(1)
+			}
+			return m, nil
+		}
 		switch m.activeSection {
 		case GraphSection:
+			graphH, _, _ := m.sectionHeights()
+			if delta, ok := shared.PageDelta(msg, graphH, len(m.commitIndices)); ok {
+				m.pageMove(delta)
+				return m, nil
+			}
 			switch {
 			case key.Matches(msg, shared.Keys.Down):
 				m.MoveDown()
@@ -405,6 +611,23 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			case key.Matches(msg, shared.Keys.Up):
 				m.MoveUp()
 				return m, nil
+			case key.Matches(msg, shared.Keys.NextRef):
+				m.NextRef()
+				return m, nil
+			case key.Matches(msg, shared.Keys.PrevRef):
+				m.PrevRef()
+				return m, nil
+			case key.Matches(msg, shared.Keys.ExpandDetail):
+				if m.detail != nil {
+					m.ToggleExpandedDetail()
+				}
+				return m, nil
+			case key.Matches(msg, shared.Keys.DetailDown):
+				m.scrollDetail(1)
+				return m, nil
+			case key.Matches(msg, shared.Keys.DetailUp):
+				m.scrollDetail(-1)
+				return m, nil
 			case key.Matches(msg, shared.Keys.Open), key.Matches(msg, shared.Keys.FocusDown):
 				if m.detail != nil && len(m.detail.Files) > 0 {
 					m.activeSection = FilesSection
@@ -428,8 +651,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				if path != "" {
 					hash := m.detailHash
 					repoPath := m.repoPath
+					oldPath := m.detail.Files[m.fileCursor].OldPath
 					return m, func() tea.Msg {
-						diff, err := git.GetCommitFileDiff(repoPath, hash, path)
+						diff, err := git.GetCommitFileDiff(repoPath, hash, path, oldPath)
 						return shared.CommitFileDiffFetchedMsg{
 							FilePath: path,
 							Diff:     diff,
@@ -462,30 +686,32 @@ func (m Model) view(focused bool) string {
 		style = shared.GraphBorderFocusedStyle
 	}
 
-	if m.detail == nil {
-		return style.Width(m.width).Height(m.height).Render(m.graphVP.View())
+	if m.detail == nil || m.showIncoming {
+		return style.Width(m.width).Height(m.height).Render(m.viewportWithScrollbar(m.graphVP))
 	}
 
 	graphH, detailH, filesH := m.sectionHeights()
 
 	// Each section is fixed-height to prevent layout shifts
-	graphView := fixedHeight(m.graphVP.View(), graphH)
-	detailView := fixedHeight(m.renderDetail(), detailH)
-	filesView := fixedHeight(m.filesVP.View(), filesH)
+	graphView := fixedHeight(m.viewportWithScrollbar(m.graphVP), graphH)
+	detailVP := m.detailVP
+	detailVP.SetContent(m.renderDetail())
+	detailView := fixedHeight(m.viewportWithScrollbar(detailVP), detailH)
+	filesView := fixedHeight(m.viewportWithScrollbar(m.filesVP), filesH)
 
 	content := graphView + "\n" + detailView + "\n" + filesView
 
 	return style.Width(m.width).Height(m.height).Render(content)
 }
 
-// --- Graph rendering ---
-
-// conventionalPrefixes are highlighted in commit messages.
-var conventionalPrefixes = []string{
-	"feat:", "fix:", "chore:", "refactor:", "docs:", "test:", "style:", "perf:", "ci:", "build:",
-	"feat(", "fix(", "chore(", "refactor(", "docs(", "test(", "style(", "perf(", "ci(", "build(",
+// viewportWithScrollbar renders a viewport with a scrollbar on its right edge.
+func (m Model) viewportWithScrollbar(vp viewport.Model) string {
+	bar := shared.Scrollbar(vp.TotalLineCount(), vp.Height, vp.YOffset, vp.Height)
+	return shared.WithScrollbar(vp.View(), m.width, bar)
 }
 
+// --- Graph rendering ---
+
 // composeGraph assembles the graph content from the cached rendered lines,
 // applying cursor highlight to the selected commit. This is fast because
 // the expensive per-character lipgloss rendering was done once in buildRenderedLines.
@@ -511,12 +737,39 @@ func (m Model) composeGraph() string {
 	return b.String()
 }
 
+// composeIncoming lists the incoming commits with author and subject.
+func (m Model) composeIncoming() string {
+	var b strings.Builder
+	header := fmt.Sprintf("Incoming from %s (%d)", m.upstream, len(m.incoming))
+	b.WriteString(shared.CommitSectionHeaderStyle.Render(header))
+	b.WriteString("  ")
+	b.WriteString(shared.HelpDescStyle.Render("esc: back to graph"))
+	b.WriteString("\n")
+	if len(m.incoming) == 0 {
+		b.WriteString(shared.HelpDescStyle.Render("  Nothing to pull (as of the last fetch)"))
+		b.WriteString("\n")
+		return b.String()
+	}
+	for _, c := range m.incoming {
+		b.WriteString(shared.GraphHashStyle.Render(c.Hash))
+		b.WriteString(" ")
+		b.WriteString(shared.CommitDetailLabelStyle.Render(c.Author))
+		b.WriteString(" ")
+		b.WriteString(shared.CommitDetailMsgStyle.Render(styleMessage(c.Message)))
+		b.WriteString(" ")
+		b.WriteString(shared.HelpDescStyle.Render(c.RelativeDate))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
 // renderLine renders a single graph line with styling. Called once per line
 // during buildRenderedLines, not on every cursor move.
-func renderLine(line git.GraphLine) string {
+func renderLine(line git.GraphLine, showAuthor bool) string {
 	var b strings.Builder
 
-	b.WriteString(colorGraphChars(line.GraphChars))
+	head := line.IsCommit && line.IsHead()
+	b.WriteString(colorGraphChars(line.GraphChars, head))
 
 	if !line.IsCommit {
 		return b.String()
@@ -532,7 +785,12 @@ func renderLine(line git.GraphLine) string {
 	}
 
 	if line.Refs != "" {
-		b.WriteString(shared.GraphRefStyle.Render(line.Refs))
+		b.WriteString(renderRefs(line.Refs, head))
+		b.WriteString(" ")
+	}
+
+	if showAuthor && line.Author != "" {
+		b.WriteString(authorTag(line.Author))
 		b.WriteString(" ")
 	}
 
@@ -541,8 +799,78 @@ func renderLine(line git.GraphLine) string {
 	return b.String()
 }
 
+// renderRefs styles a commit's decoration, picking out "HEAD -> branch"
+// (or a detached "HEAD") so the checked-out commit stands out.
+func renderRefs(refs string, head bool) string {
+	if !head {
+		return shared.GraphRefStyle.Render(refs)
+	}
+	names := strings.Split(strings.TrimSuffix(strings.TrimPrefix(refs, "("), ")"), ", ")
+	parts := make([]string, len(names))
+	for i, name := range names {
+		if name == "HEAD" || strings.HasPrefix(name, "HEAD -> ") {
+			parts[i] = shared.GraphHeadStyle.Render(name)
+		} else {
+			parts[i] = shared.GraphRefStyle.Render(name)
+		}
+	}
+	sep := shared.GraphRefStyle.Render(", ")
+	return shared.GraphRefStyle.Render("(") + strings.Join(parts, sep) + shared.GraphRefStyle.Render(")")
+}
+
+// authorColors are terminal palette colors so tags follow the user's theme.
+var authorColors = []lipgloss.Color{"1", "2", "3", "4", "5", "6", "9", "10", "11", "12", "13", "14"}
+
+// authorTag renders name's initials in a color derived from the name, so
+// each author keeps the same color across repos and refreshes.
+func authorTag(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	color := authorColors[h.Sum32()%uint32(len(authorColors))]
+	return lipgloss.NewStyle().Foreground(color).Bold(true).Render(initials(name))
+}
+
+// initials returns up to two uppercase letters: first and last word for
+// "Ada Lovelace" ("AL"), just the first letter for single-word names.
+func initials(name string) string {
+	words := strings.Fields(name)
+	if len(words) == 0 {
+		return "?"
+	}
+	first := []rune(words[0])[0]
+	if len(words) == 1 {
+		return strings.ToUpper(string(first))
+	}
+	last := []rune(words[len(words)-1])[0]
+	return strings.ToUpper(string(first) + string(last))
+}
+
 // --- Commit detail rendering ---
 
+// withEmail formats a name with its email, as git log does.
+func withEmail(name, email string) string {
+	if email == "" {
+		return name
+	}
+	return name + " <" + email + ">"
+}
+
+// signatureBadge renders a git.SignatureStatus code: green when good, red
+// when bad, dim when unsigned, and a warning colour when it's signed but
+// can't be fully trusted.
+func signatureBadge(code string) string {
+	label := git.SignatureLabel(code)
+	switch code {
+	case "G":
+		return shared.CommitStatAddStyle.Render(label)
+	case "B":
+		return shared.CommitStatDelStyle.Render(label)
+	case "N":
+		return shared.MutedFileStyle.Render(label)
+	}
+	return shared.UnstagedFileStyle.Render(label)
+}
+
 func (m Model) renderDetail() string {
 	if m.detail == nil {
 		return ""
@@ -559,36 +887,65 @@ func (m Model) renderDetail() string {
 	// Breathing room
 	b.WriteString("\n")
 
-	// Aligned labels: commit / author / date
-	b.WriteString("  ")
-	b.WriteString(label.Render("commit"))
-	b.WriteString("  ")
-	b.WriteString(shared.CommitDetailHashStyle.Render(d.Hash[:min(12, len(d.Hash))]))
-	b.WriteString("\n")
+	// Aligned labels: commit / author / date, widened for the expanded
+	// view's longer ones
+	labelW := 6
+	if m.expandedDetail {
+		labelW = 9
+	}
+	field := func(name, value string) {
+		b.WriteString("  ")
+		b.WriteString(label.Render(fmt.Sprintf("%-*s", labelW, name)))
+		b.WriteString("  ")
+		b.WriteString(value)
+		b.WriteString("\n")
+	}
 
-	b.WriteString("  ")
-	b.WriteString(label.Render("author"))
-	b.WriteString("  ")
-	b.WriteString(shared.CommitDetailAuthorStyle.Render(d.Author))
-	b.WriteString("\n")
+	if m.expandedDetail {
+		field("commit", shared.CommitDetailHashStyle.Render(d.Hash))
+		field("author", shared.CommitDetailAuthorStyle.Render(withEmail(d.Author, d.AuthorEmail)))
+		field("date", shared.CommitDetailDateStyle.Render(d.Date))
+		if d.Committer != d.Author || d.CommitterEmail != d.AuthorEmail {
+			field("committer", shared.CommitDetailAuthorStyle.Render(withEmail(d.Committer, d.CommitterEmail)))
+		}
+		field("committed", shared.CommitDetailDateStyle.Render(d.CommitDate))
+	} else {
+		field("commit", shared.CommitDetailHashStyle.Render(d.Hash[:min(12, len(d.Hash))]))
+		field("author", shared.CommitDetailAuthorStyle.Render(d.Author))
+		date := d.Date
+		if len(date) > 10 {
+			date = date[:10]
+		}
+		field("date", shared.CommitDetailDateStyle.Render(date))
+	}
 
-	b.WriteString("  ")
-	b.WriteString(label.Render("date  "))
-	b.WriteString("  ")
-	date := d.Date
-	if len(date) > 10 {
-		date = date[:10]
+	if d.Signature != "" {
+		field("sig", signatureBadge(d.Signature))
+	}
+
+	if m.expandedDetail {
+		parents := "none (root commit)"
+		if len(d.Parents) > 0 {
+			parents = shared.CommitDetailHashStyle.Render(strings.Join(d.Parents, " "))
+		}
+		field("parents", parents)
+	} else if len(d.Parents) > 1 {
+		// Files and diffs below are against the first parent
+		short := make([]string, len(d.Parents))
+		for i, p := range d.Parents {
+			short[i] = p[:min(7, len(p))]
+		}
+		field("merge", shared.CommitDetailHashStyle.Render(strings.Join(short, " ")))
 	}
-	b.WriteString(shared.CommitDetailDateStyle.Render(date))
-	b.WriteString("\n")
 
 	// Separator
 	b.WriteString("\n")
 
-	// Message (truncate to 3 lines, style with conventional prefix highlighting)
+	// Message (truncated to 3 lines unless expanded, style with
+	// conventional prefix highlighting)
 	msgLines := strings.Split(strings.TrimSpace(d.Message), "\n")
 	maxLines := 3
-	if len(msgLines) > maxLines {
+	if len(msgLines) > maxLines && !m.expandedDetail {
 		msgLines = msgLines[:maxLines]
 	}
 	for _, ml := range msgLines {
@@ -608,6 +965,22 @@ func (m Model) renderDetail() string {
 		b.WriteString("\n")
 	}
 
+	// AI summary, once asked for
+	if summary, pending := m.summaryFor(d.Hash); summary != "" {
+		b.WriteString("\n")
+		wrapped := lipgloss.NewStyle().Width(max(m.width-4, 10)).Render(summary)
+		for _, sl := range strings.Split(wrapped, "\n") {
+			b.WriteString("  ")
+			b.WriteString(shared.CommitDetailMsgStyle.Render(sl))
+			b.WriteString("\n")
+		}
+	} else if pending {
+		b.WriteString("\n")
+		b.WriteString("  ")
+		b.WriteString(shared.HelpDescStyle.Render("summarizing…"))
+		b.WriteString("\n")
+	}
+
 	// Conductor context block
 	if m.commitContext != nil {
 		b.WriteString(m.renderCommitContext())
@@ -737,7 +1110,9 @@ func (m Model) renderFiles() string {
 		}
 
 		stats := ""
-		if f.Added > 0 || f.Deleted > 0 {
+		if f.Binary {
+			stats = " " + shared.MutedFileStyle.Render("binary")
+		} else if f.Added > 0 || f.Deleted > 0 {
 			stats = " " + shared.StatAddBadge.Render(fmt.Sprintf("+%d", f.Added)) +
 				" " + shared.StatDelBadge.Render(fmt.Sprintf("-%d", f.Deleted))
 		}
@@ -747,7 +1122,11 @@ func (m Model) renderFiles() string {
 			icon = icons.ForFile(f.Path) + " "
 		}
 
-		line := fmt.Sprintf("  %s %s%s%s", chevron, icon, shared.RenderPath(f.Path), stats)
+		path := shared.RenderPath(f.Path)
+		if f.OldPath != "" {
+			path = shared.PathDirStyle.Render(f.OldPath+" → ") + path
+		}
+		line := fmt.Sprintf("  %s %s%s%s", chevron, icon, path, stats)
 
 		if i == m.fileCursor && m.activeSection == FilesSection {
 			line = shared.CursorStyle.Width(m.width).Render(line)
@@ -813,8 +1192,15 @@ func fixedHeight(s string, h int) string {
 
 func styleDiff(raw string) string {
 	var b strings.Builder
+	var hdr shared.DiffHeader
 	for _, line := range strings.Split(raw, "\n") {
 		prefix := "    "
+		if note, ok := hdr.Annotate(line); ok {
+			if note != "" {
+				b.WriteString(prefix + note + "\n")
+			}
+			continue
+		}
 		switch {
 		case strings.HasPrefix(line, "+++ ") || strings.HasPrefix(line, "--- "):
 			b.WriteString(prefix + shared.DiffMetaStyle.Render(line))
@@ -834,7 +1220,9 @@ func styleDiff(raw string) string {
 	return b.String()
 }
 
-func colorGraphChars(chars string) string {
+// colorGraphChars colours each lane; head marks the checked-out commit's
+// bullet.
+func colorGraphChars(chars string, head bool) string {
 	if len(shared.GraphLineColors) == 0 {
 		return chars
 	}
@@ -848,9 +1236,21 @@ func colorGraphChars(chars string) string {
 			col++
 		case '*':
 			style := shared.GraphLineColors[col%len(shared.GraphLineColors)]
-			b.WriteString(style.Render("●"))
+			if head {
+				b.WriteString(style.Bold(true).Render("◉"))
+			} else {
+				b.WriteString(style.Render("●"))
+			}
 			col++
-		case '|', '/', '\\':
+		case '|':
+			style := shared.GraphLineColors[col%len(shared.GraphLineColors)]
+			glyph := "|"
+			if len(shared.GraphLaneGlyphs) > 0 {
+				glyph = shared.GraphLaneGlyphs[(col/2)%len(shared.GraphLaneGlyphs)]
+			}
+			b.WriteString(style.Render(glyph))
+			col++
+		case '/', '\\':
 			style := shared.GraphLineColors[col%len(shared.GraphLineColors)]
 			b.WriteString(style.Render(string(ch)))
 			col++
@@ -863,24 +1263,14 @@ func colorGraphChars(chars string) string {
 }
 
 func styleMessage(msg string) string {
-	lower := strings.ToLower(msg)
-	for _, prefix := range conventionalPrefixes {
-		if strings.HasPrefix(lower, prefix) {
-			end := len(prefix)
-			if strings.HasSuffix(prefix, "(") {
-				closeIdx := strings.Index(msg[end:], "):")
-				if closeIdx != -1 {
-					end = end + closeIdx + 2
-				}
-			}
-			// Extract base type (e.g. "feat" from "feat:" or "feat(")
-			baseType := strings.TrimRight(prefix, ":(")
-			style, ok := shared.PrefixBadgeStyles[baseType]
-			if !ok {
-				style = shared.PrefixBadgeFallback
-			}
-			return style.Render(msg[:end]) + lipgloss.NewStyle().Render(msg[end:])
-		}
+	c, ok := commit.ParsePrefix(msg)
+	if !ok {
+		return msg
+	}
+	style, ok := shared.PrefixBadgeStyles[c.Type]
+	if !ok {
+		style = shared.PrefixBadgeFallback
 	}
-	return msg
+	end := len(c.Prefix)
+	return style.Render(msg[:end]) + lipgloss.NewStyle().Render(msg[end:])
 }
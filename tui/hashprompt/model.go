@@ -0,0 +1,85 @@
+package hashprompt
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dylan/gitdash/tui/shared"
+)
+
+type ActionKind int
+
+const (
+	ActionNone ActionKind = iota
+	ActionClose
+	ActionSubmit
+)
+
+type KeyResult struct {
+	Action ActionKind
+	Hash   string
+}
+
+// Model asks for a commit hash (or a prefix of one) to jump to in the
+// graph.
+type Model struct {
+	input textinput.Model
+}
+
+func New() Model {
+	ti := textinput.New()
+	ti.Placeholder = "commit hash or prefix"
+	ti.CharLimit = 64
+	ti.Width = 40
+
+	return Model{input: ti}
+}
+
+func (m *Model) Open() {
+	m.input.SetValue("")
+	m.input.Focus()
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) HandleKey(msg tea.KeyMsg) KeyResult {
+	switch msg.String() {
+	case "esc":
+		m.input.Blur()
+		return KeyResult{Action: ActionClose}
+	case "enter":
+		hash := strings.TrimSpace(m.input.Value())
+		if hash == "" {
+			return KeyResult{Action: ActionNone}
+		}
+		m.input.Blur()
+		return KeyResult{Action: ActionSubmit, Hash: hash}
+	}
+	return KeyResult{Action: ActionNone}
+}
+
+func (m Model) ViewOverlay(background string, w, h int) string {
+	overlay := shared.BranchPickerOverlayStyle.Render(m.renderContent())
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color("0")),
+	)
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+
+	b.WriteString(shared.TitleStyle.Render("Go to Commit"))
+	b.WriteString("\n\n")
+	b.WriteString(m.input.View())
+	b.WriteString("\n\n")
+	b.WriteString(shared.HelpDescStyle.Render("enter: jump  esc: cancel"))
+
+	return b.String()
+}
@@ -3,17 +3,50 @@ package help
 import (
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/dylan/gitdash/tui/shared"
 )
 
+type ActionKind int
+
+const (
+	ActionNone ActionKind = iota
+	ActionClose
+)
+
+type KeyResult struct {
+	Action ActionKind
+}
+
+// Context is where help was opened: a name for it and the keys that work
+// there, which are listed before everything else.
+type Context struct {
+	Name     string
+	Bindings []key.Binding
+}
+
+// chrome is the overlay's height besides the binding lines: border,
+// padding, title, filter and hint.
+const chrome = 10
+
 type Model struct {
-	width  int
-	height int
+	width   int
+	height  int
+	context Context
+	input   textinput.Model
+	showAll bool
+	offset  int
 }
 
 func New() Model {
-	return Model{}
+	ti := textinput.New()
+	ti.Placeholder = "type to search keys..."
+	ti.Prompt = "/ "
+	ti.CharLimit = 40
+	return Model{input: ti}
 }
 
 func (m *Model) SetSize(w, h int) {
@@ -21,28 +54,156 @@ func (m *Model) SetSize(w, h int) {
 	m.height = h
 }
 
-func (m Model) View() string {
-	var b strings.Builder
+// Open shows help for ctx, with an empty search and only ctx's keys. When
+// ctx has no keys of its own, every key is shown.
+func (m *Model) Open(ctx Context) {
+	m.context = ctx
+	m.showAll = len(ctx.Bindings) == 0
+	m.offset = 0
+	m.input.SetValue("")
+	m.input.Focus()
+}
 
-	b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("33")).Render("GitDash Help"))
-	b.WriteString("\n\n")
+func (m *Model) HandleKey(msg tea.KeyMsg) KeyResult {
+	switch msg.String() {
+	case "esc":
+		if m.input.Value() != "" {
+			m.input.SetValue("")
+			m.offset = 0
+			return KeyResult{Action: ActionNone}
+		}
+		m.input.Blur()
+		return KeyResult{Action: ActionClose}
+	case "tab":
+		if len(m.context.Bindings) > 0 {
+			m.showAll = !m.showAll
+			m.offset = 0
+		}
+	case "down", "ctrl+n":
+		m.offset++
+	case "up", "ctrl+p":
+		m.offset = max(m.offset-1, 0)
+	case "pgdown", "ctrl+d":
+		m.offset += m.pageSize()
+	case "pgup", "ctrl+u":
+		m.offset = max(m.offset-m.pageSize(), 0)
+	default:
+		m.input, _ = m.input.Update(msg)
+		m.offset = 0
+	}
+	m.offset = min(m.offset, max(len(m.lines())-m.pageSize(), 0))
+	return KeyResult{Action: ActionNone}
+}
 
-	groups := shared.Keys.FullHelp()
-	groupNames := []string{"Navigation", "Focus", "Staging", "Actions", "General"}
+func (m Model) pageSize() int {
+	return max(m.height-chrome, 5)
+}
+
+// section is a heading and the bindings listed under it.
+type section struct {
+	name     string
+	bindings []key.Binding
+}
+
+// sections lists the context's keys first, then (when showing all or
+// searching) every other group, leaving out keys already listed. A search
+// looks through all keys, not just the context's.
+func (m Model) sections() []section {
+	query := strings.ToLower(strings.TrimSpace(m.input.Value()))
+	seen := map[string]bool{}
+	id := func(b key.Binding) string {
+		h := b.Help()
+		return h.Key + "\x00" + h.Desc
+	}
+	keep := func(bs []key.Binding) []key.Binding {
+		var out []key.Binding
+		for _, b := range bs {
+			if !b.Enabled() || seen[id(b)] || !matches(b, query) {
+				continue
+			}
+			seen[id(b)] = true
+			out = append(out, b)
+		}
+		return out
+	}
 
-	for i, group := range groups {
+	var out []section
+	if len(m.context.Bindings) > 0 {
+		out = append(out, section{m.context.Name, keep(m.context.Bindings)})
+	}
+	if !m.showAll && query == "" {
+		return out
+	}
+	groupNames := []string{"Navigation", "Focus", "Staging", "Actions", "General"}
+	for i, group := range shared.Keys.FullHelp() {
+		name := ""
 		if i < len(groupNames) {
-			b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("255")).Render(groupNames[i]))
-			b.WriteString("\n")
+			name = groupNames[i]
+		}
+		out = append(out, section{name, keep(group)})
+	}
+	return out
+}
+
+// matches reports whether query is in b's description or is its key.
+func matches(b key.Binding, query string) bool {
+	if query == "" {
+		return true
+	}
+	h := b.Help()
+	return strings.Contains(strings.ToLower(h.Desc), query) || strings.ToLower(h.Key) == query
+}
+
+// lines renders the sections, one binding per line.
+func (m Model) lines() []string {
+	var lines []string
+	for _, s := range m.sections() {
+		if len(s.bindings) == 0 {
+			continue
+		}
+		if s.name != "" {
+			lines = append(lines, shared.TitleStyle.Render(s.name))
 		}
-		for _, k := range group {
+		for _, k := range s.bindings {
 			help := k.Help()
 			key := shared.HelpKeyStyle.Render(help.Key)
 			desc := shared.HelpDescStyle.Render(help.Desc)
-			b.WriteString("  " + key + "  " + desc + "\n")
+			lines = append(lines, "  "+key+"  "+desc)
+		}
+		lines = append(lines, "")
+	}
+	if len(lines) == 0 {
+		lines = append(lines, shared.HelpDescStyle.Render("  no matching keys"), "")
+	}
+	return lines
+}
+
+func (m Model) View() string {
+	// Scroll when the list is taller than the screen
+	lines := m.lines()
+	end := min(m.offset+m.pageSize(), len(lines))
+	visible := lines[m.offset:end]
+	if end < len(lines) {
+		visible = append(visible, shared.HelpDescStyle.Render("  … ↓ for more"))
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("33")).Render("GitDash Help"))
+	b.WriteString("\n\n")
+	b.WriteString(m.input.View())
+	b.WriteString("\n\n")
+	b.WriteString(strings.Join(visible, "\n"))
+	b.WriteString("\n")
+
+	hint := "esc: close"
+	if len(m.context.Bindings) > 0 {
+		if m.showAll {
+			hint = "tab: only " + m.context.Name + "  " + hint
+		} else {
+			hint = "tab: show all  " + hint
 		}
-		b.WriteString("\n")
 	}
+	b.WriteString(shared.HelpDescStyle.Render(hint))
 
 	content := shared.HelpOverlayStyle.Render(b.String())
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
@@ -0,0 +1,195 @@
+package palette
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dylan/gitdash/tui/shared"
+)
+
+type ActionKind int
+
+const (
+	ActionNone ActionKind = iota
+	ActionClose
+	ActionRun
+)
+
+type KeyResult struct {
+	Action  ActionKind
+	Binding key.Binding
+}
+
+// maxRows is how many commands are listed at once.
+const maxRows = 12
+
+// Model is the command palette: every action that applies where it was
+// opened, filtered as you type.
+type Model struct {
+	input    textinput.Model
+	commands []shared.Command // the ones in scope
+	filtered []shared.Command
+	cursor   int
+	offset   int
+}
+
+func New() Model {
+	ti := textinput.New()
+	ti.Placeholder = "type to filter actions..."
+	ti.Prompt = ": "
+	ti.CharLimit = 60
+	return Model{input: ti}
+}
+
+// Open lists the commands that apply in scope, with an empty filter.
+func (m *Model) Open(scope shared.Scope) {
+	m.commands = nil
+	for _, c := range shared.Keys.Commands() {
+		if c.Scope&scope != 0 && c.Binding.Enabled() {
+			m.commands = append(m.commands, c)
+		}
+	}
+	m.input.SetValue("")
+	m.input.Focus()
+	m.applyFilter()
+}
+
+func (m *Model) HandleKey(msg tea.KeyMsg) KeyResult {
+	switch msg.String() {
+	case "esc":
+		m.input.Blur()
+		return KeyResult{Action: ActionClose}
+	case "enter":
+		if len(m.filtered) == 0 {
+			return KeyResult{Action: ActionNone}
+		}
+		m.input.Blur()
+		return KeyResult{Action: ActionRun, Binding: m.filtered[m.cursor].Binding}
+	case "down", "ctrl+j", "ctrl+n":
+		if m.cursor < len(m.filtered)-1 {
+			m.cursor++
+		}
+	case "up", "ctrl+k", "ctrl+p":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	default:
+		m.input, _ = m.input.Update(msg)
+		m.applyFilter()
+		return KeyResult{Action: ActionNone}
+	}
+	m.ensureCursorVisible()
+	return KeyResult{Action: ActionNone}
+}
+
+func (m *Model) applyFilter() {
+	query := strings.ToLower(strings.TrimSpace(m.input.Value()))
+	m.cursor, m.offset = 0, 0
+	if query == "" {
+		m.filtered = m.commands
+		return
+	}
+	type scored struct {
+		cmd   shared.Command
+		score int
+	}
+	var matches []scored
+	for _, c := range m.commands {
+		h := c.Binding.Help()
+		if s, ok := fuzzyScore(query, strings.ToLower(h.Desc)); ok {
+			matches = append(matches, scored{c, s})
+		} else if query == strings.ToLower(h.Key) {
+			matches = append(matches, scored{c, 100})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	m.filtered = make([]shared.Command, len(matches))
+	for i, s := range matches {
+		m.filtered[i] = s.cmd
+	}
+}
+
+// fuzzyScore reports whether query's characters appear in order in s, and
+// scores the match: characters that start a word or follow the previous
+// match count extra, so "sa" ranks "stage all" above "show as".
+func fuzzyScore(query, s string) (int, bool) {
+	score, qi := 0, 0
+	q := []rune(query)
+	prev := -2
+	prevRune := ' '
+	for i, r := range []rune(s) {
+		if qi < len(q) && r == q[qi] {
+			score++
+			if i == prev+1 {
+				score += 2
+			}
+			if !unicode.IsLetter(prevRune) {
+				score += 3
+			}
+			prev = i
+			qi++
+		}
+		prevRune = r
+	}
+	return score, qi == len(q)
+}
+
+func (m *Model) ensureCursorVisible() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+maxRows {
+		m.offset = m.cursor - maxRows + 1
+	}
+}
+
+func (m Model) ViewOverlay(background string, w, h int) string {
+	overlay := shared.BranchPickerOverlayStyle.Render(m.renderContent())
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color("0")),
+	)
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+
+	b.WriteString(shared.TitleStyle.Render("Commands"))
+	b.WriteString("\n\n")
+	b.WriteString(m.input.View())
+	b.WriteString("\n\n")
+
+	if len(m.filtered) == 0 {
+		b.WriteString(shared.HelpDescStyle.Render("  no matching actions"))
+		b.WriteString("\n")
+	}
+	keyW := 0
+	for _, c := range m.filtered {
+		keyW = max(keyW, lipgloss.Width(c.Binding.Help().Key))
+	}
+	end := min(m.offset+maxRows, len(m.filtered))
+	for i := m.offset; i < end; i++ {
+		h := m.filtered[i].Binding.Help()
+		k := h.Key + strings.Repeat(" ", keyW-lipgloss.Width(h.Key))
+		line := shared.HelpKeyStyle.Render(k) + "  " + h.Desc
+		if i == m.cursor {
+			line = shared.CursorStyle.Render("→ " + k + "  " + h.Desc)
+		} else {
+			line = "  " + line
+		}
+		b.WriteString(line + "\n")
+	}
+	if end < len(m.filtered) {
+		b.WriteString(shared.HelpDescStyle.Render(fmt.Sprintf("  … %d more", len(m.filtered)-end)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(shared.HelpDescStyle.Render("↑/↓: navigate  enter: run  esc: close"))
+	return b.String()
+}
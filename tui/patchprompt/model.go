@@ -0,0 +1,94 @@
+package patchprompt
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dylan/gitdash/tui/shared"
+)
+
+type ActionKind int
+
+const (
+	ActionNone ActionKind = iota
+	ActionClose
+	ActionSubmit
+)
+
+type KeyResult struct {
+	Action ActionKind
+	Path   string // "" = the clipboard
+}
+
+// Model asks where the patch to apply to a repo is: a file, or (left
+// empty) the clipboard.
+type Model struct {
+	input    textinput.Model
+	repoPath string
+	repoName string
+}
+
+func New() Model {
+	ti := textinput.New()
+	ti.Placeholder = "patch file (empty: clipboard)"
+	ti.CharLimit = 300
+	ti.Width = 50
+
+	return Model{input: ti}
+}
+
+func (m *Model) Open(repoPath, repoName string) {
+	m.repoPath = repoPath
+	m.repoName = repoName
+	m.input.SetValue("")
+	m.input.Focus()
+}
+
+// RepoPath returns the repo the prompt was opened for.
+func (m Model) RepoPath() string {
+	return m.repoPath
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) HandleKey(msg tea.KeyMsg) KeyResult {
+	switch msg.String() {
+	case "esc":
+		m.input.Blur()
+		return KeyResult{Action: ActionClose}
+	case "enter":
+		m.input.Blur()
+		return KeyResult{Action: ActionSubmit, Path: strings.TrimSpace(m.input.Value())}
+	}
+	return KeyResult{Action: ActionNone}
+}
+
+func (m Model) ViewOverlay(background string, w, h int) string {
+	overlay := shared.BranchPickerOverlayStyle.Render(m.renderContent())
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color("0")),
+	)
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+
+	b.WriteString(shared.TitleStyle.Render("Apply Patch"))
+	b.WriteString(" ")
+	b.WriteString(shared.GraphHashStyle.Render(m.repoName))
+	b.WriteString("\n")
+	b.WriteString(shared.HelpDescStyle.Render("checked with a dry run first"))
+	b.WriteString("\n\n")
+	b.WriteString(m.input.View())
+	b.WriteString("\n\n")
+	b.WriteString(shared.HelpDescStyle.Render("enter: apply  esc: cancel"))
+
+	return b.String()
+}
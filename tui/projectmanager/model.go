@@ -11,6 +11,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/dylan/gitdash/config"
+	"github.com/dylan/gitdash/git"
 	"github.com/dylan/gitdash/tui/shared"
 )
 
@@ -176,8 +177,8 @@ func (m *Model) ensureCursorVisible() {
 	}
 }
 
-// walkDirs recursively collects directories up to maxDepth, skipping hidden dirs.
-func walkDirs(root string, maxDepth int) []DirEntry {
+// WalkDirs recursively collects directories up to maxDepth, skipping hidden dirs.
+func WalkDirs(root string, maxDepth int) []DirEntry {
 	var result []DirEntry
 	var walk func(dir string, relPrefix string, depth int)
 	walk = func(dir string, relPrefix string, depth int) {
@@ -194,14 +195,10 @@ func walkDirs(root string, maxDepth int) []DirEntry {
 			}
 			absPath := filepath.Join(dir, e.Name())
 			relPath := filepath.Join(relPrefix, e.Name())
-			hasGit := false
-			if info, err := os.Stat(filepath.Join(absPath, ".git")); err == nil && info.IsDir() {
-				hasGit = true
-			}
 			result = append(result, DirEntry{
 				AbsPath: absPath,
 				RelPath: relPath,
-				HasGit:  hasGit,
+				HasGit:  git.IsRepo(absPath),
 			})
 			walk(absPath, relPath, depth+1)
 		}
@@ -213,7 +210,7 @@ func walkDirs(root string, maxDepth int) []DirEntry {
 // scanDirs populates allDirs and filteredDirs from root. When preferGit is true,
 // git-containing dirs are sorted first.
 func (m *Model) scanDirs(root string, preferGit bool) {
-	m.allDirs = walkDirs(root, 3)
+	m.allDirs = WalkDirs(root, 3)
 	if preferGit {
 		sort.SliceStable(m.allDirs, func(i, j int) bool {
 			if m.allDirs[i].HasGit != m.allDirs[j].HasGit {
@@ -618,7 +615,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 func (m Model) View() string {
 	var b strings.Builder
 
-	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("255")).Render("Project Manager")
+	title := shared.TitleStyle.Render("Project Manager")
 	b.WriteString(title)
 	b.WriteString("\n\n")
 
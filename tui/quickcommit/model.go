@@ -0,0 +1,126 @@
+package quickcommit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dylan/gitdash/tui/shared"
+)
+
+type ActionKind int
+
+const (
+	ActionNone ActionKind = iota
+	ActionClose
+	ActionSubmit
+)
+
+type KeyResult struct {
+	Action  ActionKind
+	Message string
+}
+
+// Model is a one-line commit prompt for the staged changes of a single
+// repo, for when the full commit view is more than the change needs.
+type Model struct {
+	input    textinput.Model
+	repoPath string
+	repoName string
+	branch   string
+	staged   int
+	what     string // shown instead of the staged count when set
+}
+
+func New() Model {
+	ti := textinput.New()
+	ti.Placeholder = "commit message..."
+	ti.CharLimit = 200
+	ti.Width = 50
+
+	return Model{input: ti}
+}
+
+// Open resets the prompt for repoPath. staged is shown as a reminder of
+// what the commit will contain.
+func (m *Model) Open(repoPath, repoName, branch string, staged int) {
+	m.repoPath = repoPath
+	m.repoName = repoName
+	m.branch = branch
+	m.staged = staged
+	m.what = ""
+	m.input.SetValue("")
+	m.input.Focus()
+}
+
+// OpenFor is Open for a commit whose contents are described by what, such
+// as a single hunk.
+func (m *Model) OpenFor(repoPath, repoName, branch, what string) {
+	m.Open(repoPath, repoName, branch, 0)
+	m.what = what
+}
+
+// RepoPath returns the repo the prompt was opened for.
+func (m Model) RepoPath() string {
+	return m.repoPath
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) HandleKey(msg tea.KeyMsg) KeyResult {
+	switch msg.String() {
+	case "esc":
+		m.input.Blur()
+		return KeyResult{Action: ActionClose}
+	case "enter":
+		message := strings.TrimSpace(m.input.Value())
+		if message == "" {
+			return KeyResult{Action: ActionNone}
+		}
+		m.input.Blur()
+		return KeyResult{Action: ActionSubmit, Message: message}
+	}
+	return KeyResult{Action: ActionNone}
+}
+
+func (m Model) ViewOverlay(background string, w, h int) string {
+	overlay := shared.BranchPickerOverlayStyle.Render(m.renderContent())
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color("0")),
+	)
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+
+	b.WriteString(shared.TitleStyle.Render("Quick Commit"))
+	b.WriteString(" ")
+	b.WriteString(shared.GraphHashStyle.Render(m.repoName))
+	if m.branch != "" {
+		b.WriteString(" ")
+		b.WriteString(shared.BranchCurrentStyle.Render(m.branch))
+	}
+	b.WriteString("\n")
+	noun := "files"
+	if m.staged == 1 {
+		noun = "file"
+	}
+	if m.what != "" {
+		b.WriteString(shared.HelpDescStyle.Render(m.what))
+	} else {
+		b.WriteString(shared.HelpDescStyle.Render(fmt.Sprintf("%d staged %s", m.staged, noun)))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(m.input.View())
+	b.WriteString("\n\n")
+	b.WriteString(shared.HelpDescStyle.Render("enter: commit  esc: cancel"))
+
+	return b.String()
+}
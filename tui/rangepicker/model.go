@@ -0,0 +1,158 @@
+package rangepicker
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dylan/gitdash/tui/shared"
+)
+
+type ActionKind int
+
+const (
+	ActionNone ActionKind = iota
+	ActionClose
+	ActionCopy
+	ActionWrite
+)
+
+type KeyResult struct {
+	Action ActionKind
+	From   string // "" = start of history
+	To     string
+}
+
+// maxRows is how many refs are listed at once.
+const maxRows = 12
+
+// Model picks the two refs a changelog spans: HEAD or any tag.
+type Model struct {
+	repoPath string
+	refs     []string // HEAD, then tags newest first
+	cursor   int
+	offset   int
+	from     int // index into refs, -1 = start of history
+	to       int
+	outPath  string
+}
+
+func New() Model {
+	return Model{from: -1}
+}
+
+// Open lists HEAD and tags (newest first), preselecting the range between
+// the latest two tags, or from the latest tag to HEAD when there's only
+// one. outPath is where "write" puts the changelog.
+func (m *Model) Open(repoPath string, tags []string, outPath string) {
+	m.repoPath = repoPath
+	m.refs = append([]string{"HEAD"}, tags...)
+	m.outPath = outPath
+	m.offset = 0
+	switch {
+	case len(tags) >= 2:
+		m.to, m.from = 1, 2
+	case len(tags) == 1:
+		m.to, m.from = 0, 1
+	default:
+		m.to, m.from = 0, -1
+	}
+	m.cursor = m.to
+}
+
+func (m Model) RepoPath() string {
+	return m.repoPath
+}
+
+func (m Model) result(action ActionKind) KeyResult {
+	r := KeyResult{Action: action, To: m.refs[m.to]}
+	if m.from >= 0 {
+		r.From = m.refs[m.from]
+	}
+	return r
+}
+
+func (m *Model) HandleKey(msg tea.KeyMsg) KeyResult {
+	switch msg.String() {
+	case "esc", "q":
+		return KeyResult{Action: ActionClose}
+	case "j", "down":
+		if m.cursor < len(m.refs)-1 {
+			m.cursor++
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "f":
+		if m.from == m.cursor {
+			m.from = -1 // back to the start of history
+		} else {
+			m.from = m.cursor
+		}
+	case "t":
+		m.to = m.cursor
+	case "enter", "y":
+		return m.result(ActionCopy)
+	case "w":
+		return m.result(ActionWrite)
+	}
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+maxRows {
+		m.offset = m.cursor - maxRows + 1
+	}
+	return KeyResult{Action: ActionNone}
+}
+
+func (m Model) ViewOverlay(background string, w, h int) string {
+	overlay := shared.BranchPickerOverlayStyle.Render(m.renderContent())
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color("0")),
+	)
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+
+	b.WriteString(shared.TitleStyle.Render("Changelog"))
+	b.WriteString("\n\n")
+
+	from := "the first commit"
+	if m.from >= 0 {
+		from = m.refs[m.from]
+	}
+	b.WriteString(shared.HelpDescStyle.Render("from ") + from + shared.HelpDescStyle.Render(" to ") + m.refs[m.to])
+	b.WriteString("\n\n")
+
+	if len(m.refs) == 1 {
+		b.WriteString(shared.HelpDescStyle.Render("No tags yet"))
+		b.WriteString("\n")
+	}
+	end := min(m.offset+maxRows, len(m.refs))
+	for i := m.offset; i < end; i++ {
+		mark := "    "
+		switch i {
+		case m.to:
+			mark = shared.StagedFileStyle.Render("to  ")
+		case m.from:
+			mark = shared.UnstagedFileStyle.Render("from")
+		}
+		line := m.refs[i]
+		if i == m.cursor {
+			line = shared.CursorStyle.Render(line)
+		}
+		b.WriteString(mark + " " + line + "\n")
+	}
+	if end < len(m.refs) {
+		b.WriteString(shared.HelpDescStyle.Render(strings.Repeat(" ", 5) + "…"))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(shared.HelpDescStyle.Render("f: from  t: to  enter: copy  w: write to " + m.outPath))
+	b.WriteString("\n")
+	b.WriteString(shared.HelpDescStyle.Render("esc: cancel"))
+	return b.String()
+}
@@ -0,0 +1,232 @@
+package reflog
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dylan/gitdash/git"
+	"github.com/dylan/gitdash/tui/shared"
+)
+
+type Mode int
+
+const (
+	BrowseMode Mode = iota
+	BranchMode
+)
+
+type ActionKind int
+
+const (
+	ActionNone ActionKind = iota
+	ActionClose
+	ActionReset
+	ActionBranch
+)
+
+type KeyResult struct {
+	Action     ActionKind
+	Entry      git.ReflogEntry
+	BranchName string
+}
+
+// Model is a read-only reflog browser. Recovery actions are only returned
+// as results; the app confirms them before anything runs.
+type Model struct {
+	mode         Mode
+	entries      []git.ReflogEntry
+	repoPath     string
+	cursor       int
+	scrollOffset int
+
+	branchInput textinput.Model
+
+	width  int
+	height int
+}
+
+func New() Model {
+	bi := textinput.New()
+	bi.Placeholder = "branch name..."
+	bi.CharLimit = 100
+
+	return Model{branchInput: bi}
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// RepoPath returns the repo the entries were read from.
+func (m Model) RepoPath() string {
+	return m.repoPath
+}
+
+func (m *Model) SetEntries(entries []git.ReflogEntry, repoPath string) {
+	m.entries = entries
+	m.repoPath = repoPath
+	m.mode = BrowseMode
+	m.cursor = 0
+	m.scrollOffset = 0
+	m.branchInput.SetValue("")
+	m.branchInput.Blur()
+}
+
+// InInputMode returns true while a branch name is being typed.
+func (m Model) InInputMode() bool {
+	return m.mode == BranchMode
+}
+
+// listHeight returns how many entries fit in the visible area.
+func (m Model) listHeight() int {
+	h := m.height - 12 // border + title + help + padding
+	if h > 20 {
+		h = 20
+	}
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+func (m *Model) ensureCursorVisible() {
+	h := m.listHeight()
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	} else if m.cursor >= m.scrollOffset+h {
+		m.scrollOffset = m.cursor - h + 1
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if m.mode != BranchMode {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.branchInput, cmd = m.branchInput.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) HandleKey(msg tea.KeyMsg) KeyResult {
+	if m.mode == BranchMode {
+		return m.handleBranchKey(msg)
+	}
+	return m.handleBrowseKey(msg)
+}
+
+func (m *Model) handleBrowseKey(msg tea.KeyMsg) KeyResult {
+	switch msg.String() {
+	case "esc", "q":
+		return KeyResult{Action: ActionClose}
+	case "j", "down":
+		if m.cursor < len(m.entries)-1 {
+			m.cursor++
+			m.ensureCursorVisible()
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+			m.ensureCursorVisible()
+		}
+	case "r":
+		if m.cursor < len(m.entries) {
+			return KeyResult{Action: ActionReset, Entry: m.entries[m.cursor]}
+		}
+	case "n":
+		if m.cursor < len(m.entries) {
+			m.mode = BranchMode
+			m.branchInput.SetValue("")
+			m.branchInput.Focus()
+		}
+	}
+	return KeyResult{Action: ActionNone}
+}
+
+func (m *Model) handleBranchKey(msg tea.KeyMsg) KeyResult {
+	switch msg.String() {
+	case "esc":
+		m.mode = BrowseMode
+		m.branchInput.Blur()
+	case "enter":
+		name := strings.TrimSpace(m.branchInput.Value())
+		if name == "" {
+			return KeyResult{Action: ActionNone}
+		}
+		return KeyResult{Action: ActionBranch, Entry: m.entries[m.cursor], BranchName: name}
+	}
+	return KeyResult{Action: ActionNone}
+}
+
+func (m Model) ViewOverlay(background string, w, h int) string {
+	overlay := shared.BranchPickerOverlayStyle.Render(m.renderContent())
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color("0")),
+	)
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+
+	b.WriteString(shared.TitleStyle.Render("Reflog"))
+	b.WriteString(" ")
+	b.WriteString(shared.GraphHashStyle.Render(m.repoPath))
+	b.WriteString("\n\n")
+
+	if len(m.entries) == 0 {
+		b.WriteString(shared.GraphHashStyle.Render("  reflog is empty"))
+		b.WriteString("\n\n")
+		b.WriteString(shared.HelpDescStyle.Render("esc: close"))
+		return b.String()
+	}
+
+	end := m.scrollOffset + m.listHeight()
+	if end > len(m.entries) {
+		end = len(m.entries)
+	}
+	for i := m.scrollOffset; i < end; i++ {
+		e := m.entries[i]
+		line := shared.GraphHashStyle.Render(e.Hash) + " " +
+			shared.BranchItemStyle.Render(e.Selector) + " " +
+			shared.BranchPrefixStyle.Render(e.Action)
+		line += " " + truncate(e.Subject, m.subjectWidth(lipgloss.Width(line)+1))
+		if i == m.cursor {
+			line = shared.CursorStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if m.mode == BranchMode {
+		b.WriteString("New branch at " + shared.BranchCurrentStyle.Render(m.entries[m.cursor].Hash) + "\n")
+		b.WriteString(m.branchInput.View())
+		b.WriteString("\n\n")
+		b.WriteString(shared.HelpDescStyle.Render("enter: create  esc: back"))
+	} else {
+		b.WriteString(shared.HelpDescStyle.Render("j/k: navigate  r: reset branch here  n: new branch here  esc: close"))
+	}
+
+	return b.String()
+}
+
+// maxLineWidth caps entry lines on wide terminals so the overlay doesn't
+// sprawl across the screen.
+const maxLineWidth = 100
+
+// subjectWidth is the room left for a subject after used columns of a
+// line, given the overlay's border and padding.
+func (m Model) subjectWidth(used int) int {
+	return max(min(m.width-6, maxLineWidth)-used, 20)
+}
+
+func truncate(s string, maxW int) string {
+	r := []rune(s)
+	if len(r) <= maxW {
+		return s
+	}
+	return string(r[:maxW-1]) + "…"
+}
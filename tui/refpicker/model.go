@@ -0,0 +1,150 @@
+package refpicker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dylan/gitdash/git"
+	"github.com/dylan/gitdash/tui/shared"
+)
+
+type ActionKind int
+
+const (
+	ActionNone ActionKind = iota
+	ActionClose
+	ActionJump
+)
+
+type KeyResult struct {
+	Action ActionKind
+	Ref    git.Ref
+}
+
+// maxRows is how many refs are listed at once.
+const maxRows = 12
+
+// Model lists the branches and tags in the graph, filtered as you type,
+// to jump to one's commit.
+type Model struct {
+	input    textinput.Model
+	refs     []git.Ref
+	filtered []git.Ref
+	cursor   int
+	offset   int
+}
+
+func New() Model {
+	ti := textinput.New()
+	ti.Placeholder = "type to filter branches and tags..."
+	ti.Prompt = "/ "
+	ti.CharLimit = 100
+	return Model{input: ti}
+}
+
+// Open lists refs, in graph order, with an empty filter.
+func (m *Model) Open(refs []git.Ref) {
+	m.refs = refs
+	m.input.SetValue("")
+	m.input.Focus()
+	m.applyFilter()
+}
+
+func (m *Model) HandleKey(msg tea.KeyMsg) KeyResult {
+	switch msg.String() {
+	case "esc":
+		m.input.Blur()
+		return KeyResult{Action: ActionClose}
+	case "enter":
+		if len(m.filtered) == 0 {
+			return KeyResult{Action: ActionNone}
+		}
+		m.input.Blur()
+		return KeyResult{Action: ActionJump, Ref: m.filtered[m.cursor]}
+	case "down", "ctrl+j", "ctrl+n":
+		if m.cursor < len(m.filtered)-1 {
+			m.cursor++
+		}
+	case "up", "ctrl+k", "ctrl+p":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	default:
+		m.input, _ = m.input.Update(msg)
+		m.applyFilter()
+		return KeyResult{Action: ActionNone}
+	}
+	m.ensureCursorVisible()
+	return KeyResult{Action: ActionNone}
+}
+
+func (m *Model) applyFilter() {
+	query := strings.ToLower(strings.TrimSpace(m.input.Value()))
+	m.cursor, m.offset = 0, 0
+	m.filtered = nil
+	for _, r := range m.refs {
+		if strings.Contains(strings.ToLower(r.Name), query) {
+			m.filtered = append(m.filtered, r)
+		}
+	}
+}
+
+func (m *Model) ensureCursorVisible() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+maxRows {
+		m.offset = m.cursor - maxRows + 1
+	}
+}
+
+func (m Model) ViewOverlay(background string, w, h int) string {
+	overlay := shared.BranchPickerOverlayStyle.Render(m.renderContent())
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color("0")),
+	)
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+
+	b.WriteString(shared.TitleStyle.Render("Jump to Ref"))
+	b.WriteString("\n\n")
+	b.WriteString(m.input.View())
+	b.WriteString("\n\n")
+
+	if len(m.filtered) == 0 {
+		msg := "  no matching refs"
+		if len(m.refs) == 0 {
+			msg = "  no branches or tags in the graph"
+		}
+		b.WriteString(shared.HelpDescStyle.Render(msg))
+		b.WriteString("\n")
+	}
+	end := min(m.offset+maxRows, len(m.filtered))
+	for i := m.offset; i < end; i++ {
+		r := m.filtered[i]
+		kind := "branch"
+		if r.Tag {
+			kind = "tag   "
+		}
+		if i == m.cursor {
+			b.WriteString(shared.CursorStyle.Render("→ " + kind + " " + r.Name + "  " + r.Hash))
+		} else {
+			b.WriteString("  " + shared.HelpDescStyle.Render(kind) + " " +
+				shared.GraphRefStyle.Render(r.Name) + "  " + shared.GraphHashStyle.Render(r.Hash))
+		}
+		b.WriteString("\n")
+	}
+	if end < len(m.filtered) {
+		b.WriteString(shared.HelpDescStyle.Render(fmt.Sprintf("  … %d more", len(m.filtered)-end)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(shared.HelpDescStyle.Render("↑/↓: navigate  enter: jump  esc: close"))
+	return b.String()
+}
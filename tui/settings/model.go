@@ -0,0 +1,190 @@
+package settings
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dylan/gitdash/config"
+	"github.com/dylan/gitdash/tui/shared"
+)
+
+type ActionKind int
+
+const (
+	ActionNone ActionKind = iota
+	ActionCancel
+	ActionSave
+)
+
+type KeyResult struct {
+	Action  ActionKind
+	Display config.DisplayConfig
+}
+
+// option is one editable row. Booleans are flipped; numbers step between
+// min and max, starting from def when the field is unset.
+type option struct {
+	group string
+	label string
+	flag  func(d *config.DisplayConfig) *bool
+	num   func(d *config.DisplayConfig) *int
+	def   int
+	min   int
+	max   int
+	step  int
+}
+
+var options = []option{
+	{group: "Files", label: "Group by folder", flag: func(d *config.DisplayConfig) *bool { return &d.GroupFolders }},
+	{group: "Files", label: "  as a tree", flag: func(d *config.DisplayConfig) *bool { return &d.FolderTree }},
+	{group: "Files", label: "Group docs", flag: func(d *config.DisplayConfig) *bool { return &d.GroupDocs }},
+	{group: "Icons", label: "File icons", flag: func(d *config.DisplayConfig) *bool { return &d.Icons }},
+	{group: "Icons", label: "Nerd Font glyphs", flag: func(d *config.DisplayConfig) *bool { return &d.NerdFonts }},
+	{group: "Panes", label: "Graph on startup", flag: func(d *config.DisplayConfig) *bool { return boolField(&d.ShowGraph, true) }},
+	{group: "Panes", label: "Conductor on startup", flag: func(d *config.DisplayConfig) *bool { return boolField(&d.ShowConductor, false) }},
+	{group: "Panes", label: "Dashboard width %", num: func(d *config.DisplayConfig) *int { return &d.DashboardWidth }, def: 25, min: 10, max: 75, step: 5},
+	{group: "Panes", label: "  with graph only %", num: func(d *config.DisplayConfig) *int { return &d.DashboardWidth2Col }, def: 50, min: 10, max: 75, step: 5},
+	{group: "Graph", label: "Max commits", num: func(d *config.DisplayConfig) *int { return &d.GraphMaxCommits }, def: 50, min: 10, max: 1000, step: 10},
+}
+
+// boolField returns the bool behind an optional config field, setting it
+// to def first when it's unset.
+func boolField(p **bool, def bool) *bool {
+	if *p == nil {
+		*p = &def
+	}
+	return *p
+}
+
+// Model edits the display section of the config, grouped by what each
+// option affects. Nothing changes until it's saved.
+type Model struct {
+	display config.DisplayConfig
+	cursor  int
+	changed bool
+}
+
+func New() Model {
+	return Model{}
+}
+
+// Open starts editing a copy of display.
+func (m *Model) Open(display config.DisplayConfig) {
+	// The optional fields are pointers: copy them so edits don't leak
+	// into the live config before saving
+	if display.ShowGraph != nil {
+		v := *display.ShowGraph
+		display.ShowGraph = &v
+	}
+	if display.ShowConductor != nil {
+		v := *display.ShowConductor
+		display.ShowConductor = &v
+	}
+	m.display = display
+	m.cursor = 0
+	m.changed = false
+}
+
+func (m *Model) HandleKey(msg tea.KeyMsg) KeyResult {
+	switch msg.String() {
+	case "esc", "q":
+		return KeyResult{Action: ActionCancel}
+	case "j", "down":
+		if m.cursor < len(options)-1 {
+			m.cursor++
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case " ", "l", "right":
+		m.change(1)
+	case "h", "left":
+		m.change(-1)
+	case "enter":
+		if !m.changed {
+			return KeyResult{Action: ActionCancel}
+		}
+		return KeyResult{Action: ActionSave, Display: m.display}
+	}
+	return KeyResult{Action: ActionNone}
+}
+
+// change flips or steps the option under the cursor.
+func (m *Model) change(dir int) {
+	opt := options[m.cursor]
+	if opt.flag != nil {
+		p := opt.flag(&m.display)
+		*p = !*p
+		m.changed = true
+		return
+	}
+	p := opt.num(&m.display)
+	v := *p
+	if v == 0 {
+		v = opt.def
+	}
+	v = max(opt.min, min(opt.max, v+dir*opt.step))
+	if v != *p {
+		*p = v
+		m.changed = true
+	}
+}
+
+// value renders an option's current setting.
+func (m Model) value(opt option) string {
+	d := m.display
+	if opt.flag != nil {
+		if *opt.flag(&d) {
+			return "[x]"
+		}
+		return "[ ]"
+	}
+	v := *opt.num(&d)
+	if v == 0 {
+		return fmt.Sprintf("‹ %d › (default)", opt.def)
+	}
+	return fmt.Sprintf("‹ %d ›", v)
+}
+
+func (m Model) ViewOverlay(background string, w, h int) string {
+	overlay := shared.BranchPickerOverlayStyle.Render(m.renderContent())
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color("0")),
+	)
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+
+	b.WriteString(shared.TitleStyle.Render("Settings"))
+	if m.changed {
+		b.WriteString(" ")
+		b.WriteString(shared.HelpDescStyle.Render("(unsaved)"))
+	}
+	b.WriteString("\n")
+
+	group := ""
+	for i, opt := range options {
+		if opt.group != group {
+			group = opt.group
+			b.WriteString("\n")
+			b.WriteString(shared.GraphRefStyle.Render(group))
+			b.WriteString("\n")
+		}
+		row := fmt.Sprintf("%-22s %s", opt.label, m.value(opt))
+		if i == m.cursor {
+			b.WriteString(shared.CursorStyle.Render("→ " + row))
+		} else {
+			b.WriteString("  " + row)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(shared.HelpDescStyle.Render("space/←/→: change  enter: save to config  esc: cancel"))
+	return b.String()
+}
@@ -0,0 +1,268 @@
+package setup
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dylan/gitdash/config"
+	"github.com/dylan/gitdash/tui/shared"
+)
+
+type stage int
+
+const (
+	stagePick stage = iota
+	stageName
+)
+
+type ActionKind int
+
+const (
+	ActionNone ActionKind = iota
+	ActionSkip
+	ActionCreate
+)
+
+type KeyResult struct {
+	Action  ActionKind
+	Project config.ProjectConfig
+}
+
+// Model is the first-run wizard: pick repos found under the scan root and
+// name the project they go into.
+type Model struct {
+	configPath string
+	scanRoot   string
+	scanning   bool
+	repos      []string // absolute repo paths
+	selected   map[int]bool
+
+	cursor       int
+	scrollOffset int
+	stage        stage
+	nameInput    textinput.Model
+
+	width  int
+	height int
+}
+
+func New(scanRoot, configPath string) Model {
+	ni := textinput.New()
+	ni.Placeholder = "project name..."
+	ni.CharLimit = 100
+	ni.SetValue(filepath.Base(scanRoot))
+
+	return Model{
+		configPath: configPath,
+		scanRoot:   scanRoot,
+		scanning:   true,
+		selected:   make(map[int]bool),
+		nameInput:  ni,
+	}
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// ScanRoot returns the directory scanned for repos.
+func (m Model) ScanRoot() string {
+	return m.scanRoot
+}
+
+// SetRepos stores the scan results. Everything starts unselected.
+func (m *Model) SetRepos(repos []string) {
+	m.repos = repos
+	m.scanning = false
+	m.selected = make(map[int]bool)
+	m.cursor = 0
+	m.scrollOffset = 0
+}
+
+// InInputMode returns true while the project name is being typed.
+func (m Model) InInputMode() bool {
+	return m.stage == stageName
+}
+
+// listHeight returns how many repos fit in the visible area.
+func (m Model) listHeight() int {
+	h := m.height - 10 // title + intro + footer + padding
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+func (m *Model) ensureCursorVisible() {
+	h := m.listHeight()
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+	if m.cursor >= m.scrollOffset+h {
+		m.scrollOffset = m.cursor - h + 1
+	}
+}
+
+// HandleKey processes a key event and returns an action result.
+func (m *Model) HandleKey(msg tea.KeyMsg) KeyResult {
+	if m.stage == stageName {
+		return m.handleNameKey(msg)
+	}
+	return m.handlePickKey(msg)
+}
+
+func (m *Model) handlePickKey(msg tea.KeyMsg) KeyResult {
+	switch msg.String() {
+	case "esc", "q":
+		return KeyResult{Action: ActionSkip}
+	case "j", "down":
+		if m.cursor < len(m.repos)-1 {
+			m.cursor++
+			m.ensureCursorVisible()
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+			m.ensureCursorVisible()
+		}
+	case " ":
+		if m.cursor < len(m.repos) {
+			m.selected[m.cursor] = !m.selected[m.cursor]
+		}
+	case "a":
+		// Select all, or clear when everything is already selected
+		all := m.selectedCount() < len(m.repos)
+		for i := range m.repos {
+			m.selected[i] = all
+		}
+	case "enter":
+		if m.selectedCount() > 0 {
+			m.stage = stageName
+			m.nameInput.Focus()
+		}
+	}
+	return KeyResult{Action: ActionNone}
+}
+
+func (m *Model) handleNameKey(msg tea.KeyMsg) KeyResult {
+	switch msg.String() {
+	case "esc":
+		m.stage = stagePick
+		m.nameInput.Blur()
+	case "enter":
+		name := strings.TrimSpace(m.nameInput.Value())
+		if name == "" {
+			return KeyResult{Action: ActionNone}
+		}
+		proj := config.ProjectConfig{Name: name, Path: m.scanRoot}
+		for i, path := range m.repos {
+			if m.selected[i] {
+				proj.Repos = append(proj.Repos, config.RepoConfig{Path: path})
+			}
+		}
+		return KeyResult{Action: ActionCreate, Project: proj}
+	}
+	return KeyResult{Action: ActionNone}
+}
+
+func (m Model) selectedCount() int {
+	n := 0
+	for _, sel := range m.selected {
+		if sel {
+			n++
+		}
+	}
+	return n
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.InInputMode() {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.nameInput, cmd = m.nameInput.Update(msg)
+	return m, cmd
+}
+
+// View renders the wizard.
+func (m Model) View() string {
+	var b strings.Builder
+
+	title := shared.TitleStyle.Render("Welcome to GitDash")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	b.WriteString(shared.HelpDescStyle.Render("No config found. Pick the repos to track and they'll be saved as your first project in"))
+	b.WriteString("\n")
+	b.WriteString(shared.DimFileStyle.Render(m.configPath))
+	b.WriteString("\n\n")
+
+	if m.stage == stageName {
+		b.WriteString(m.renderName())
+	} else {
+		b.WriteString(m.renderPick())
+	}
+
+	b.WriteString("\n\n")
+	if m.stage == stageName {
+		b.WriteString(shared.HelpDescStyle.Render("enter: save config  esc: back"))
+	} else {
+		b.WriteString(shared.HelpDescStyle.Render("j/k: navigate  space: toggle  a: all  enter: continue  esc/q: skip setup"))
+	}
+
+	return lipgloss.NewStyle().
+		Padding(1, 2).
+		Width(m.width).
+		Height(m.height).
+		MaxHeight(m.height).
+		Render(b.String())
+}
+
+func (m Model) renderPick() string {
+	if m.scanning {
+		return shared.HelpDescStyle.Render("Scanning " + m.scanRoot + " for git repos...")
+	}
+	if len(m.repos) == 0 {
+		return shared.HelpDescStyle.Render("No git repos found under " + m.scanRoot + ". Press esc to skip and add projects later with P.")
+	}
+
+	var b strings.Builder
+	b.WriteString(shared.ProjectHeaderStyle.Render(fmt.Sprintf("%d repos under %s", len(m.repos), m.scanRoot)))
+	b.WriteString("  ")
+	b.WriteString(shared.HelpDescStyle.Render(fmt.Sprintf("(%d selected)", m.selectedCount())))
+	b.WriteString("\n\n")
+
+	end := m.scrollOffset + m.listHeight()
+	if end > len(m.repos) {
+		end = len(m.repos)
+	}
+	for i := m.scrollOffset; i < end; i++ {
+		marker := "[ ] "
+		if m.selected[i] {
+			marker = shared.StagedFileStyle.Render("[x]") + " "
+		}
+		rel, err := filepath.Rel(m.scanRoot, m.repos[i])
+		if err != nil {
+			rel = m.repos[i]
+		}
+		line := "  " + marker + shared.BranchStyle.Render(rel)
+		if i == m.cursor {
+			line = shared.CursorStyle.Width(m.width - 6).Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (m Model) renderName() string {
+	var b strings.Builder
+	b.WriteString(shared.ProjectHeaderStyle.Render(fmt.Sprintf("Project name for %d repos", m.selectedCount())))
+	b.WriteString("\n\n")
+	b.WriteString(m.nameInput.View())
+	return b.String()
+}
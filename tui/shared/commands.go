@@ -0,0 +1,75 @@
+package shared
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Scope is a set of places a command applies.
+type Scope uint8
+
+const (
+	ScopeProjects  Scope = 1 << iota // the all-projects list
+	ScopeRepos                       // the repo list inside a project
+	ScopeGraph                       // the graph pane, focused
+	ScopeConductor                   // the conductor pane, focused
+
+	ScopeAll = ScopeProjects | ScopeRepos | ScopeGraph | ScopeConductor
+)
+
+// Command is a command palette entry: an action's key binding and where
+// pressing that key does it.
+type Command struct {
+	Binding key.Binding
+	Scope   Scope
+}
+
+// Commands lists the actions the command palette offers. Movement and
+// focus keys are left out: they're only useful while watching the list.
+func (k KeyMap) Commands() []Command {
+	in := func(s Scope, bindings ...key.Binding) []Command {
+		cmds := make([]Command, len(bindings))
+		for i, b := range bindings {
+			cmds[i] = Command{Binding: b, Scope: s}
+		}
+		return cmds
+	}
+	var cmds []Command
+	cmds = append(cmds, in(ScopeRepos,
+		k.Stage, k.Unstage, k.StageAll, k.UnstageAll, k.UndoStage,
+		k.TakeOurs, k.TakeTheirs, k.AbortOp, k.ContinueOp,
+		k.Diff, k.Commit, k.QuickCommit, k.AmendNoEdit, k.Push, k.Incoming,
+		k.UndoCommit, k.Squash, k.Open, k.Terminal, k.FileManager,
+		k.Branch, k.Reflog, k.Changelog, k.ApplyPatch, k.RepoActivity, k.StagingFilter)...)
+	cmds = append(cmds, in(ScopeProjects|ScopeRepos,
+		k.CopyPath, k.Pin, k.HideClean, k.ByStatus, k.Theme, k.Settings,
+		k.ContextSummary, k.ContextRange, k.ContextToFile, k.ProjectContext,
+		k.ProjectManager)...)
+	cmds = append(cmds, in(ScopeGraph,
+		k.BranchAtCommit, k.CheckoutCommit, k.FixupCommit, k.Autosquash, k.RebaseEdit, k.ExplainCommit, k.CopyPatch, k.JumpToRef, k.GoToHash, k.ExpandDetail)...)
+	cmds = append(cmds, in(ScopeConductor, k.ConductorExport, k.MemoryTag)...)
+	cmds = append(cmds, in(ScopeAll, k.ToggleGraph, k.ToggleConductor, k.Help, k.Quit)...)
+	return cmds
+}
+
+// KeyMsgFor builds the key press that a binding's key string ("G",
+// "ctrl+x", "shift+tab", "alt+j") stands for, so an action can be run as
+// if it was typed.
+func KeyMsgFor(s string) tea.KeyMsg {
+	var msg tea.KeyMsg
+	if rest, ok := strings.CutPrefix(s, "alt+"); ok && rest != "" {
+		msg.Alt = true
+		s = rest
+	}
+	for t := tea.KeyF20; t <= tea.KeyBackspace; t++ {
+		if t != tea.KeyRunes && t.String() == s {
+			msg.Type = t
+			return msg
+		}
+	}
+	msg.Type = tea.KeyRunes
+	msg.Runes = []rune(s)
+	return msg
+}
@@ -0,0 +1,58 @@
+package shared
+
+import "strings"
+
+// DiffHeader turns git's extended header lines (mode changes, renames,
+// copies) into one highlighted line each, so permission flips and renames
+// stand out instead of blending into the meta lines. Use one per diff.
+type DiffHeader struct {
+	oldMode    string
+	similarity string
+	from       string
+}
+
+// Annotate handles line if it's an extended header line. It returns the
+// styled replacement (empty while a pair like "old mode"/"new mode" is
+// still incomplete) and whether the line was consumed.
+func (h *DiffHeader) Annotate(line string) (string, bool) {
+	switch {
+	case strings.HasPrefix(line, "diff --git "):
+		*h = DiffHeader{}
+		return "", false
+	case strings.HasPrefix(line, "old mode "):
+		h.oldMode = strings.TrimPrefix(line, "old mode ")
+		return "", true
+	case strings.HasPrefix(line, "new mode "):
+		newMode := strings.TrimPrefix(line, "new mode ")
+		note := "mode changed " + h.oldMode + " → " + newMode
+		switch {
+		case isExecutable(newMode) && !isExecutable(h.oldMode):
+			note += " (now executable)"
+		case isExecutable(h.oldMode) && !isExecutable(newMode):
+			note += " (no longer executable)"
+		}
+		h.oldMode = ""
+		return DiffNoticeStyle.Render(note), true
+	case strings.HasPrefix(line, "similarity index "):
+		h.similarity = strings.TrimPrefix(line, "similarity index ")
+		return "", true
+	case strings.HasPrefix(line, "rename from "), strings.HasPrefix(line, "copy from "):
+		_, h.from, _ = strings.Cut(line, " from ")
+		return "", true
+	case strings.HasPrefix(line, "rename to "), strings.HasPrefix(line, "copy to "):
+		verb := "renamed"
+		if strings.HasPrefix(line, "copy") {
+			verb = "copied"
+		}
+		note := verb + " from " + h.from
+		if h.similarity != "" {
+			note += " (" + h.similarity + ")"
+		}
+		return DiffNoticeStyle.Render(note), true
+	}
+	return "", false
+}
+
+func isExecutable(mode string) bool {
+	return strings.HasSuffix(mode, "755")
+}
@@ -1,23 +1,49 @@
 package shared
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 type KeyMap struct {
 	Up             key.Binding
 	Down           key.Binding
+	Left           key.Binding
+	Right          key.Binding
 	NextRepo       key.Binding
 	PrevRepo       key.Binding
+	HalfPageDown   key.Binding
+	HalfPageUp     key.Binding
+	PageDown       key.Binding
+	PageUp         key.Binding
+	Top            key.Binding
+	Bottom         key.Binding
 	Stage          key.Binding
 	Unstage        key.Binding
+	TakeOurs       key.Binding
+	TakeTheirs     key.Binding
+	AbortOp        key.Binding
+	ContinueOp     key.Binding
 	StageAll       key.Binding
 	UnstageAll     key.Binding
+	UndoStage      key.Binding
 	Diff           key.Binding
+	DiffMode       key.Binding
+	NextHunk       key.Binding
+	PrevHunk       key.Binding
+	StageHunk      key.Binding
+	CommitHunk     key.Binding
 	Commit         key.Binding
+	QuickCommit    key.Binding
+	Squash         key.Binding
 	Open           key.Binding
 	Help           key.Binding
 	Quit           key.Binding
 	Escape         key.Binding
 	Branch         key.Binding
+	Reflog         key.Binding
+	Changelog      key.Binding
+	Incoming       key.Binding
 	ToggleGraph    key.Binding
 	FocusDown      key.Binding
 	FocusUp        key.Binding
@@ -25,13 +51,53 @@ type KeyMap struct {
 	FocusRight     key.Binding
 	Push           key.Binding
 	AmendToggle    key.Binding
+	AllowEmpty     key.Binding
+	AmendNoEdit    key.Binding
+	AmendAuthor    key.Binding
+	AmendResetDate key.Binding
+	SignToggle     key.Binding
+	LoadTemplate   key.Binding
 	GenerateMsg    key.Binding
+	RegenerateMsg  key.Binding
+	PrevAIMsg      key.Binding
 	SubmitCommit   key.Binding
+	RecallMsg      key.Binding
 	ContextSummary   key.Binding
+	ContextRange     key.Binding
+	ContextToFile    key.Binding
+	ProjectContext   key.Binding
+	RepoActivity     key.Binding
 	ToggleConductor  key.Binding
 	CycleType        key.Binding
 	UndoCommit       key.Binding
 	ProjectManager   key.Binding
+	HideClean        key.Binding
+	Pin              key.Binding
+	CopyPath         key.Binding
+	Terminal         key.Binding
+	FileManager      key.Binding
+	StagingFilter    key.Binding
+	ByStatus         key.Binding
+	BranchAtCommit   key.Binding
+	CheckoutCommit   key.Binding
+	FixupCommit      key.Binding
+	Autosquash       key.Binding
+	RebaseEdit       key.Binding
+	ExplainCommit    key.Binding
+	CopyPatch        key.Binding
+	NextRef          key.Binding
+	PrevRef          key.Binding
+	JumpToRef        key.Binding
+	GoToHash         key.Binding
+	ExpandDetail     key.Binding
+	DetailDown       key.Binding
+	DetailUp         key.Binding
+	ApplyPatch       key.Binding
+	ConductorExport  key.Binding
+	MemoryTag        key.Binding
+	Theme            key.Binding
+	Settings         key.Binding
+	Palette          key.Binding
 }
 
 var Keys = KeyMap{
@@ -43,6 +109,14 @@ var Keys = KeyMap{
 		key.WithKeys("j", "down"),
 		key.WithHelp("j/↓", "down"),
 	),
+	Left: key.NewBinding(
+		key.WithKeys("h", "left"),
+		key.WithHelp("h/←", "previous file column"),
+	),
+	Right: key.NewBinding(
+		key.WithKeys("l", "right"),
+		key.WithHelp("l/→", "next file column"),
+	),
 	NextRepo: key.NewBinding(
 		key.WithKeys("tab"),
 		key.WithHelp("tab", "next repo"),
@@ -51,6 +125,31 @@ var Keys = KeyMap{
 		key.WithKeys("shift+tab"),
 		key.WithHelp("S-tab", "prev repo"),
 	),
+	HalfPageDown: key.NewBinding(
+		key.WithKeys("ctrl+d"),
+		key.WithHelp("C-d", "half page down"),
+	),
+	HalfPageUp: key.NewBinding(
+		key.WithKeys("ctrl+u"),
+		key.WithHelp("C-u", "half page up"),
+	),
+	PageDown: key.NewBinding(
+		key.WithKeys("pgdown"),
+		key.WithHelp("pgdn", "page down"),
+	),
+	PageUp: key.NewBinding(
+		key.WithKeys("pgup"),
+		key.WithHelp("pgup", "page up"),
+	),
+	// g is taken by ToggleGraph, so top is home only
+	Top: key.NewBinding(
+		key.WithKeys("home"),
+		key.WithHelp("home", "top"),
+	),
+	Bottom: key.NewBinding(
+		key.WithKeys("G", "end"),
+		key.WithHelp("G/end", "bottom"),
+	),
 	Stage: key.NewBinding(
 		key.WithKeys("s"),
 		key.WithHelp("s", "stage file"),
@@ -59,6 +158,26 @@ var Keys = KeyMap{
 		key.WithKeys("u"),
 		key.WithHelp("u", "unstage file"),
 	),
+	UndoStage: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "undo last stage/unstage"),
+	),
+	TakeOurs: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "conflict: take ours"),
+	),
+	TakeTheirs: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "conflict: take theirs"),
+	),
+	AbortOp: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "abort merge/rebase"),
+	),
+	ContinueOp: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "continue merge/rebase"),
+	),
 	StageAll: key.NewBinding(
 		key.WithKeys("S"),
 		key.WithHelp("S", "stage all"),
@@ -71,13 +190,41 @@ var Keys = KeyMap{
 		key.WithKeys("d"),
 		key.WithHelp("d", "view diff"),
 	),
+	DiffMode: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "cycle diff mode"),
+	),
+	NextHunk: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "diff: next hunk"),
+	),
+	PrevHunk: key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "diff: previous hunk"),
+	),
+	StageHunk: key.NewBinding(
+		key.WithKeys("h"),
+		key.WithHelp("h", "diff: stage/unstage hunk"),
+	),
+	CommitHunk: key.NewBinding(
+		key.WithKeys("H"),
+		key.WithHelp("H", "diff: stage hunk and quick commit it"),
+	),
 	Commit: key.NewBinding(
 		key.WithKeys("c"),
 		key.WithHelp("c", "commit"),
 	),
+	QuickCommit: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "quick commit"),
+	),
+	Squash: key.NewBinding(
+		key.WithKeys("Q"),
+		key.WithHelp("Q", "squash last N commits"),
+	),
 	Open: key.NewBinding(
 		key.WithKeys("enter"),
-		key.WithHelp("enter", "open in nvim"),
+		key.WithHelp("enter", "open in editor"),
 	),
 	Help: key.NewBinding(
 		key.WithKeys("?"),
@@ -95,6 +242,18 @@ var Keys = KeyMap{
 		key.WithKeys("b"),
 		key.WithHelp("b", "branches"),
 	),
+	Reflog: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "reflog"),
+	),
+	Changelog: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "changelog between tags"),
+	),
+	Incoming: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "incoming commits"),
+	),
 	ToggleGraph: key.NewBinding(
 		key.WithKeys("g"),
 		key.WithHelp("g", "toggle graph"),
@@ -123,18 +282,70 @@ var Keys = KeyMap{
 		key.WithKeys("ctrl+a"),
 		key.WithHelp("C-a", "amend"),
 	),
+	AllowEmpty: key.NewBinding(
+		key.WithKeys("ctrl+e"),
+		key.WithHelp("C-e", "empty commit"),
+	),
+	AmendNoEdit: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "amend (no edit)"),
+	),
+	AmendAuthor: key.NewBinding(
+		key.WithKeys("ctrl+o"),
+		key.WithHelp("C-o", "amend: edit author"),
+	),
+	AmendResetDate: key.NewBinding(
+		key.WithKeys("ctrl+g"),
+		key.WithHelp("C-g", "amend: reset date"),
+	),
+	SignToggle: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+		key.WithHelp("C-s", "sign commits (this session)"),
+	),
+	LoadTemplate: key.NewBinding(
+		key.WithKeys("ctrl+l"),
+		key.WithHelp("C-l", "load a commit template"),
+	),
 	GenerateMsg: key.NewBinding(
 		key.WithKeys("tab"),
 		key.WithHelp("tab", "AI generate"),
 	),
+	RegenerateMsg: key.NewBinding(
+		key.WithKeys("shift+tab"),
+		key.WithHelp("S-tab", "AI regenerate (new variant)"),
+	),
+	PrevAIMsg: key.NewBinding(
+		key.WithKeys("ctrl+b"),
+		key.WithHelp("C-b", "swap with previous AI message"),
+	),
 	SubmitCommit: key.NewBinding(
 		key.WithKeys("ctrl+y"),
 		key.WithHelp("C-y", "commit"),
 	),
+	RecallMsg: key.NewBinding(
+		key.WithKeys("ctrl+r"),
+		key.WithHelp("C-r", "recall recent message"),
+	),
 	ContextSummary: key.NewBinding(
 		key.WithKeys("ctrl+x"),
 		key.WithHelp("C-x", "export context"),
 	),
+	ContextRange: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "cycle export range"),
+	),
+	ContextToFile: key.NewBinding(
+		key.WithKeys("E"),
+		key.WithHelp("E", "export context to file"),
+	),
+	ProjectContext: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "export project context"),
+	),
+	RepoActivity: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "export repo activity as Markdown"),
+	),
 	ToggleConductor: key.NewBinding(
 		key.WithKeys("C"),
 		key.WithHelp("C", "toggle conductor"),
@@ -151,6 +362,114 @@ var Keys = KeyMap{
 		key.WithKeys("P"),
 		key.WithHelp("P", "projects"),
 	),
+	HideClean: key.NewBinding(
+		key.WithKeys("H"),
+		key.WithHelp("H", "hide clean repos"),
+	),
+	Pin: key.NewBinding(
+		key.WithKeys("*"),
+		key.WithHelp("*", "pin repo"),
+	),
+	CopyPath: key.NewBinding(
+		key.WithKeys("Y"),
+		key.WithHelp("Y", "copy path"),
+	),
+	Terminal: key.NewBinding(
+		key.WithKeys("!"),
+		key.WithHelp("!", "terminal in repo"),
+	),
+	FileManager: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "file manager in repo"),
+	),
+	StagingFilter: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "filter staged/unstaged"),
+	),
+	ByStatus: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "all repos by status"),
+	),
+	BranchAtCommit: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "graph: branch from commit"),
+	),
+	CheckoutCommit: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "graph: checkout commit (detached)"),
+	),
+	FixupCommit: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "graph: fixup commit from staged"),
+	),
+	Autosquash: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "graph: autosquash fixups from commit"),
+	),
+	RebaseEdit: key.NewBinding(
+		key.WithKeys("B"),
+		key.WithHelp("B", "graph: interactive rebase from commit"),
+	),
+	ExplainCommit: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "graph: summarize commit with AI"),
+	),
+	CopyPatch: key.NewBinding(
+		key.WithKeys("Y"),
+		key.WithHelp("Y", "graph: copy commit as a patch"),
+	),
+	NextRef: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "graph: next branch/tag"),
+	),
+	PrevRef: key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "graph: previous branch/tag"),
+	),
+	JumpToRef: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "graph: jump to a branch or tag"),
+	),
+	GoToHash: key.NewBinding(
+		key.WithKeys("#"),
+		key.WithHelp("#", "graph: go to commit by hash"),
+	),
+	ExpandDetail: key.NewBinding(
+		key.WithKeys("I"),
+		key.WithHelp("I", "graph: expanded commit detail"),
+	),
+	DetailDown: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "graph: scroll commit detail down"),
+	),
+	DetailUp: key.NewBinding(
+		key.WithKeys("K"),
+		key.WithHelp("K", "graph: scroll commit detail up"),
+	),
+	ApplyPatch: key.NewBinding(
+		key.WithKeys("V"),
+		key.WithHelp("V", "apply a patch (clipboard or file)"),
+	),
+	ConductorExport: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "conductor: export status as markdown"),
+	),
+	MemoryTag: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "conductor: filter memories by tag"),
+	),
+	Theme: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "theme"),
+	),
+	Settings: key.NewBinding(
+		key.WithKeys(","),
+		key.WithHelp(",", "settings"),
+	),
+	Palette: key.NewBinding(
+		key.WithKeys(":", "ctrl+p"),
+		key.WithHelp(":/C-p", "command palette"),
+	),
 }
 
 func (k KeyMap) ShortHelp() []key.Binding {
@@ -159,10 +478,39 @@ func (k KeyMap) ShortHelp() []key.Binding {
 
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.NextRepo, k.PrevRepo},
+		{k.Up, k.Down, k.Left, k.Right, k.NextRepo, k.PrevRepo, k.HalfPageDown, k.HalfPageUp, k.PageDown, k.PageUp, k.Top, k.Bottom, k.NextHunk, k.PrevHunk, k.NextRef, k.PrevRef},
 		{k.FocusLeft, k.FocusRight, k.FocusDown, k.FocusUp},
-		{k.Stage, k.Unstage, k.StageAll, k.UnstageAll},
-		{k.Diff, k.Commit, k.Push, k.UndoCommit, k.Open, k.Branch},
-		{k.ToggleGraph, k.ToggleConductor, k.ContextSummary, k.ProjectManager, k.Help, k.Quit, k.Escape},
+		{k.Stage, k.Unstage, k.StageAll, k.UnstageAll, k.UndoStage, k.TakeOurs, k.TakeTheirs, k.AbortOp, k.ContinueOp, k.StageHunk, k.CommitHunk},
+		{k.Diff, k.Commit, k.QuickCommit, k.AllowEmpty, k.AmendNoEdit, k.Push, k.Incoming, k.UndoCommit, k.Squash, k.Open, k.CopyPath, k.Terminal, k.FileManager, k.Branch, k.Reflog, k.Changelog, k.ApplyPatch, k.BranchAtCommit, k.CheckoutCommit, k.FixupCommit, k.Autosquash, k.RebaseEdit, k.ExplainCommit, k.CopyPatch, k.JumpToRef, k.GoToHash, k.ExpandDetail, k.DetailDown, k.DetailUp, k.ConductorExport, k.MemoryTag},
+		{k.ToggleGraph, k.ToggleConductor, k.HideClean, k.StagingFilter, k.ByStatus, k.Pin, k.Theme, k.Settings, k.ContextSummary, k.ContextRange, k.ContextToFile, k.ProjectContext, k.RepoActivity, k.ProjectManager, k.Palette, k.Help, k.Quit, k.Escape},
 	}
 }
+
+// PageDelta returns how far a paging key moves the cursor of a list that
+// shows page rows, and false if msg isn't a paging key. Top and bottom
+// return ±total so callers can simply clamp.
+func PageDelta(msg tea.KeyMsg, page, total int) (int, bool) {
+	half := max(page/2, 1)
+	page = max(page, 1)
+	switch {
+	case key.Matches(msg, Keys.HalfPageDown):
+		return half, true
+	case key.Matches(msg, Keys.HalfPageUp):
+		return -half, true
+	case key.Matches(msg, Keys.PageDown):
+		return page, true
+	case key.Matches(msg, Keys.PageUp):
+		return -page, true
+	case key.Matches(msg, Keys.Top):
+		return -total, true
+	case key.Matches(msg, Keys.Bottom):
+		return total, true
+	}
+	return 0, false
+}
+
+// IsPageKey reports whether msg is one of the paging keys.
+func IsPageKey(msg tea.KeyMsg) bool {
+	_, ok := PageDelta(msg, 1, 1)
+	return ok
+}
@@ -2,6 +2,7 @@ package shared
 
 import (
 	"github.com/dylan/gitdash/conductor"
+	"github.com/dylan/gitdash/forge"
 	"github.com/dylan/gitdash/git"
 )
 
@@ -9,19 +10,66 @@ type StatusRefreshedMsg struct {
 	Repos []git.RepoStatus
 }
 
-type FileStageToggledMsg struct{}
-type AllStagedMsg struct{}
-type AllUnstagedMsg struct{}
+// FileStageToggledMsg reports a single file staged or unstaged.
+type FileStageToggledMsg struct {
+	RepoPath string
+	Path     string
+	Staged   bool
+	Err      error
+}
 
-type DiffFetchedMsg struct {
-	Content string
-	File    string
+// AllStagedMsg and AllUnstagedMsg report a bulk operation; Paths are the
+// files it changed, so it can be undone.
+type AllStagedMsg struct {
+	RepoPath string
+	Paths    []string
+	Err      error
+}
+type AllUnstagedMsg struct {
+	RepoPath string
+	Paths    []string
+	Err      error
+}
+
+// StageUndoneMsg reports reverting the last staging operation.
+type StageUndoneMsg struct {
+	Summary string
 	Err     error
 }
 
+// ConflictResolvedMsg reports taking one side of a merge conflict.
+type ConflictResolvedMsg struct {
+	Path   string
+	Theirs bool
+	Err    error
+}
+
+type DiffFetchedMsg struct {
+	Content  string
+	RepoPath string
+	File     string
+	Entry    git.FileEntry
+	Mode     git.DiffMode
+	Gen      int // fetch generation; stale results are dropped
+	Err      error
+}
+
 type CommitCompleteMsg struct {
-	Hash string
-	Err  error
+	Hash    string
+	Message string
+	Quick   bool // from the quick-commit prompt rather than the commit view
+	Err     error
+}
+
+// HunkStagedMsg reports staging (or unstaging) a hunk from the diff view.
+// Commit is set when the hunk was staged on its own to be committed.
+type HunkStagedMsg struct {
+	RepoPath string
+	File     string
+	Hunk     git.Hunk
+	Unstaged bool
+	Commit   bool
+	Err      error
 }
 
 type CloseDiffMsg struct{}
@@ -30,6 +78,7 @@ type CloseCommitMsg struct{}
 type GraphFetchedMsg struct {
 	Lines    []git.GraphLine
 	RepoPath string
+	Gen      int
 	Err      error
 }
 
@@ -44,17 +93,109 @@ type BranchSwitchedMsg struct {
 	Err    error
 }
 
+type MergedBranchesMsg struct {
+	RepoPath string
+	Branches []string
+	Err      error
+}
+
+type BranchesDeletedMsg struct {
+	Deleted []string
+	Err     error // first failure; the other branches are still tried
+}
+
+type BranchNameSuggestedMsg struct {
+	RepoPath string
+	Name     string
+	Err      error
+}
+
+// CommitExplainedMsg carries an AI summary of the commit Hash.
+type CommitExplainedMsg struct {
+	Hash    string
+	Summary string
+	Err     error
+}
+
+// PatchAppliedMsg reports applying Patch, read from Source (a file or the
+// clipboard), to RepoPath. Rejected is non-nil when the dry run failed and
+// nothing was applied; it lists the hunks that failed ("file:line").
+// Conflicts lists files a three-way apply left conflicted.
+type PatchAppliedMsg struct {
+	RepoPath  string
+	Source    string
+	Patch     string
+	Files     int
+	ThreeWay  bool
+	Rejected  []string
+	Conflicts []string
+	Err       error
+}
+
+// PatchCopiedMsg reports copying the patch of Hash to the clipboard.
+type PatchCopiedMsg struct {
+	Hash  string
+	Bytes int
+	Err   error
+}
+
 type BranchCreatedMsg struct {
 	Branch string
 	Err    error
 }
 
+// OperationStepMsg reports an abort or continue of an in-progress merge or
+// rebase. Stopped is set when a continue ran into the next conflict.
+type OperationStepMsg struct {
+	Op       string // RepoStatus.InProgress, e.g. "rebasing"
+	Continue bool
+	Stopped  bool
+	Err      error
+}
+
+type FixupCommittedMsg struct {
+	Target string
+	Err    error
+}
+
+// AutosquashCompleteMsg reports an autosquash rebase. Stopped is set when
+// it halted on a conflict and is still in progress.
+type AutosquashCompleteMsg struct {
+	Stopped bool
+	Err     error
+}
+
+type CommitCheckedOutMsg struct {
+	Hash string
+	Err  error
+}
+
 type CloseBranchPickerMsg struct{}
 
+type IncomingFetchedMsg struct {
+	Commits  []git.RecentCommitInfo
+	Upstream string
+	RepoPath string
+	Err      error
+}
+
+type ReflogFetchedMsg struct {
+	Entries  []git.ReflogEntry
+	RepoPath string
+	Err      error
+}
+
+// ReflogRecoveredMsg reports a reset or branch creation from the reflog view.
+type ReflogRecoveredMsg struct {
+	Summary string // e.g. "Reset to HEAD@{3}"
+	Err     error
+}
+
 type CommitDetailFetchedMsg struct {
 	Detail   git.CommitDetail
 	RepoPath string
 	Hash     string
+	Gen      int
 	Err      error
 }
 
@@ -70,20 +211,76 @@ type AICommitMsgMsg struct {
 	Err     error
 }
 
+// AmendPreparedMsg reports whether a repo has a commit to amend, checked
+// before asking to amend it.
+type AmendPreparedMsg struct {
+	RepoPath  string
+	Name      string
+	NoCommits bool
+}
+
+type AmendCompleteMsg struct {
+	Hash string
+	Err  error
+}
+
+// SquashPreparedMsg carries the subjects of the commits about to be
+// squashed, or why they can't be.
+type SquashPreparedMsg struct {
+	RepoPath string
+	N        int
+	Subjects []string
+	Pushed   bool
+	Err      error
+}
+
+type SquashCompleteMsg struct {
+	N    int
+	Hash string
+	Err  error
+}
+
 type PushCompleteMsg struct {
 	Branch string
 	Err    error
 }
 
+// UndoCommitPreparedMsg describes HEAD before asking to undo it: its hash
+// and subject, whether it's pushed, or why it can't be undone.
+type UndoCommitPreparedMsg struct {
+	RepoPath  string
+	Name      string
+	Hash      string
+	Subject   string
+	Pushed    bool
+	NoCommits bool
+	Root      bool // the first commit, with no parent to reset to
+}
+
 type UndoCommitCompleteMsg struct {
 	Hash string
 	Err  error
 }
 
+// LaunchedMsg reports starting an external terminal or file manager.
+type LaunchedMsg struct {
+	What string // "terminal" or "file manager"
+	Dir  string
+	Err  error
+}
+
+type PathCopiedMsg struct {
+	Path string
+	Err  error
+}
+
 type ContextSummaryCopiedMsg struct {
 	Summary    string
 	NumCommits int
 	NumRepos   int
+	Days       int
+	Path       string // set when written to a file instead of the clipboard
+	Scope      string // project name when scoped to one project, empty for workspace
 	Err        error
 }
 
@@ -94,6 +291,48 @@ type ConductorRefreshedMsg struct {
 	Err      error
 }
 
+type TagsFetchedMsg struct {
+	RepoPath string
+	Tags     []string
+	Err      error
+}
+
+type ChangelogBuiltMsg struct {
+	Path  string // set when written to a file instead of the clipboard
+	Count int    // commits in the range
+	Err   error
+}
+
+type ActivityExportedMsg struct {
+	Repo       string
+	Path       string // set when written to a file instead of the clipboard
+	NumCommits int
+	Days       int
+	Err        error
+}
+
+type CommitResolvedMsg struct {
+	RepoPath string
+	Rev      string // what was typed
+	Hash     string
+	Pos      int // commits before it in the graph, -1 = not on any ref
+	Err      error
+}
+
+// ConfirmPreviewMsg carries the preview of what a destructive action will
+// drop or change, for the confirm overlay. Seq ties it to the prompt that
+// asked for it.
+type ConfirmPreviewMsg struct {
+	Seq     int
+	Preview string
+	Err     error
+}
+
+type ConductorExportedMsg struct {
+	Path string // set when written to a file instead of the clipboard
+	Err  error
+}
+
 type FeatureLinkedMsg struct {
 	FeatureID   string
 	CommitHash  string
@@ -105,6 +344,8 @@ type CommitContextFetchedMsg struct {
 	StagedStats        []git.CommitFileStat
 	RecentCommits      []git.RecentCommitInfo
 	FeatureSuggestions []conductor.FeatureMatch
+	Template           string // commit template to pre-fill, "" = none
+	TemplateErr        error
 	Err                error
 }
 
@@ -112,3 +353,18 @@ type AIFeatureSuggestMsg struct {
 	RankedIDs []string
 	Err       error
 }
+
+// Forge messages
+
+type IssuesFetchedMsg struct {
+	RepoPath string
+	Issues   []forge.Issue
+	Err      error
+}
+
+// Setup messages
+
+type SetupScannedMsg struct {
+	Root  string
+	Repos []string // absolute paths of git repos found under Root
+}
@@ -0,0 +1,26 @@
+package shared
+
+import (
+	"math"
+	"strings"
+)
+
+// ProgressBar renders fraction (0 to 1) as a bar of width cells, the done
+// part in the staged color.
+func ProgressBar(fraction float64, width int) string {
+	if width < 1 {
+		return ""
+	}
+	fraction = math.Max(0, math.Min(1, fraction))
+	filled := int(math.Round(fraction * float64(width)))
+	return StagedFileStyle.Render(strings.Repeat("█", filled)) +
+		DimFileStyle.Render(strings.Repeat("░", width-filled))
+}
+
+// Fraction is done/total, or 0 when there's nothing to do.
+func Fraction(done, total int) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(done) / float64(total)
+}
@@ -0,0 +1,66 @@
+package shared
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// scrollbarsEnabled controls whether panes draw scrollbars.
+var scrollbarsEnabled = true
+
+// SetScrollbars enables or disables pane scrollbars.
+func SetScrollbars(enabled bool) { scrollbarsEnabled = enabled }
+
+// Scrollbar returns a column of height glyphs indicating which part of total
+// items is visible, starting at offset. Returns nil when scrollbars are
+// disabled or everything fits.
+func Scrollbar(total, visible, offset, height int) []string {
+	if !scrollbarsEnabled || height < 1 || total <= visible || visible < 1 {
+		return nil
+	}
+
+	thumbH := height * visible / total
+	if thumbH < 1 {
+		thumbH = 1
+	}
+	maxOffset := total - visible
+	if offset > maxOffset {
+		offset = maxOffset
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	thumbTop := (height - thumbH) * offset / maxOffset
+
+	col := make([]string, height)
+	for i := range col {
+		if i >= thumbTop && i < thumbTop+thumbH {
+			col[i] = ScrollbarThumbStyle.Render("█")
+		} else {
+			col[i] = ScrollbarTrackStyle.Render("│")
+		}
+	}
+	return col
+}
+
+// WithScrollbar overlays a scrollbar column on the right edge of content,
+// trimming each line to width-1 so the pane's total width is unchanged.
+func WithScrollbar(content string, width int, bar []string) string {
+	if len(bar) == 0 || width < 2 {
+		return content
+	}
+	lines := strings.Split(content, "\n")
+	for len(lines) < len(bar) {
+		lines = append(lines, "")
+	}
+	clip := lipgloss.NewStyle().MaxWidth(width - 1)
+	for i := range bar {
+		line := clip.Render(lines[i])
+		if pad := width - 1 - lipgloss.Width(line); pad > 0 {
+			line += strings.Repeat(" ", pad)
+		}
+		lines[i] = line + bar[i]
+	}
+	return strings.Join(lines, "\n")
+}
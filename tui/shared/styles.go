@@ -14,9 +14,14 @@ var (
 	RepoHeaderStyle lipgloss.Style
 	BranchStyle     lipgloss.Style
 
+	// Overlay and view titles
+	TitleStyle lipgloss.Style
+
 	// Section headers
 	StagedSectionStyle   lipgloss.Style
 	UnstagedSectionStyle lipgloss.Style
+	PinnedSectionStyle   lipgloss.Style
+	PinnedStyle          lipgloss.Style
 
 	// File entries
 	StagedFileStyle   lipgloss.Style
@@ -32,6 +37,7 @@ var (
 	DiffRemoveStyle lipgloss.Style
 	DiffHunkStyle   lipgloss.Style
 	DiffMetaStyle   lipgloss.Style
+	DiffNoticeStyle lipgloss.Style // mode changes and renames
 
 	// Diff header/footer
 	DiffHeaderStyle lipgloss.Style
@@ -59,11 +65,13 @@ var (
 	// Graph pane
 	GraphHashStyle          lipgloss.Style
 	GraphRefStyle           lipgloss.Style
+	GraphHeadStyle          lipgloss.Style
 	PrefixBadgeStyles       map[string]lipgloss.Style
 	PrefixBadgeFallback     lipgloss.Style
 	GraphBorderStyle        lipgloss.Style
 	GraphBorderFocusedStyle lipgloss.Style
 	GraphLineColors         []lipgloss.Style
+	GraphLaneGlyphs         []string // per-lane vertical glyphs; nil = plain "|"
 
 	// Commit detail
 	CommitDetailHashStyle   lipgloss.Style
@@ -74,6 +82,10 @@ var (
 	CommitFileHeaderStyle   lipgloss.Style
 	SectionDividerStyle     lipgloss.Style
 
+	// Scrollbars
+	ScrollbarTrackStyle lipgloss.Style
+	ScrollbarThumbStyle lipgloss.Style
+
 	// Branch picker
 	BranchPickerOverlayStyle lipgloss.Style
 	BranchCurrentStyle       lipgloss.Style
@@ -93,6 +105,15 @@ var (
 	SyncPushBadge lipgloss.Style
 	SyncPullBadge lipgloss.Style
 
+	// Merge/rebase in progress badge
+	InProgressBadge lipgloss.Style
+
+	// Stash count badge
+	StashBadge lipgloss.Style
+
+	// Detached HEAD label in place of the branch
+	DetachedStyle lipgloss.Style
+
 	// Spinner
 	SpinnerStyle lipgloss.Style
 
@@ -104,6 +125,7 @@ var (
 	// Indicators
 	StagedIndicator   string
 	UnstagedIndicator string
+	ConflictIndicator string
 
 	// Project header
 	ProjectHeaderStyle lipgloss.Style
@@ -121,6 +143,7 @@ var (
 
 	// Commit view two-panel
 	CommitSectionHeaderStyle lipgloss.Style
+	CommitWarningStyle       lipgloss.Style
 	CommitTypeDimStyle       lipgloss.Style
 	CommitRightBorderStyle   lipgloss.Style
 )
@@ -135,12 +158,23 @@ func InitStyles(theme config.ThemeConfig, graphColors ...[]string) {
 	BranchStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color(theme.Branch))
 
+	TitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(theme.FG))
+
 	StagedSectionStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color(theme.Staged))
 
 	UnstagedSectionStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color(theme.Unstaged))
 
+	PinnedSectionStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(theme.Muted)).
+		Bold(true)
+
+	PinnedStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(theme.Accent2))
+
 	StagedFileStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color(theme.Staged))
 
@@ -168,6 +202,10 @@ func InitStyles(theme config.ThemeConfig, graphColors ...[]string) {
 	DiffMetaStyle = lipgloss.NewStyle().
 		Bold(true)
 
+	DiffNoticeStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(theme.FeedbackWarningFG))
+
 	DiffHeaderStyle = lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color(theme.FG)).
@@ -218,6 +256,11 @@ func InitStyles(theme config.ThemeConfig, graphColors ...[]string) {
 		Foreground(lipgloss.Color(theme.Accent)).
 		Bold(true)
 
+	GraphHeadStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(theme.Accent)).
+		Bold(true).
+		Reverse(true)
+
 	PrefixBadgeStyles = make(map[string]lipgloss.Style)
 	for name, pc := range theme.PrefixColors {
 		PrefixBadgeStyles[name] = lipgloss.NewStyle().
@@ -246,6 +289,14 @@ func InitStyles(theme config.ThemeConfig, graphColors ...[]string) {
 	for i, c := range gc {
 		GraphLineColors[i] = lipgloss.NewStyle().Foreground(lipgloss.Color(c))
 	}
+	GraphLaneGlyphs = nil
+	if theme.GraphPalette == "colorblind" {
+		// Vary weight and glyph too, so lanes stay distinct without hue
+		for i := range GraphLineColors {
+			GraphLineColors[i] = GraphLineColors[i].Bold(i%2 == 1)
+		}
+		GraphLaneGlyphs = []string{"│", "┃", "╎", "║"}
+	}
 
 	CommitDetailHashStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color(theme.Accent))
@@ -269,6 +320,12 @@ func InitStyles(theme config.ThemeConfig, graphColors ...[]string) {
 	SectionDividerStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color(theme.Muted))
 
+	ScrollbarTrackStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(theme.Muted))
+
+	ScrollbarThumbStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(theme.Accent))
+
 	BranchPickerOverlayStyle = lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color(theme.Accent)).
@@ -325,6 +382,19 @@ func InitStyles(theme config.ThemeConfig, graphColors ...[]string) {
 		Background(lipgloss.Color(theme.SyncPullBG)).
 		Padding(0, 1)
 
+	InProgressBadge = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(theme.FeedbackErrorFG)).
+		Background(lipgloss.Color(theme.FeedbackErrorBG)).
+		Bold(true).
+		Padding(0, 1)
+
+	StashBadge = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(theme.Accent2))
+
+	DetachedStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(theme.FeedbackWarningFG)).
+		Background(lipgloss.Color(theme.FeedbackWarningBG))
+
 	SpinnerStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color(theme.SpinnerFG))
 
@@ -345,6 +415,7 @@ func InitStyles(theme config.ThemeConfig, graphColors ...[]string) {
 
 	StagedIndicator = StagedFileStyle.Render("✓")
 	UnstagedIndicator = UnstagedFileStyle.Render("○")
+	ConflictIndicator = ErrorStyle.Render("!")
 
 	ProjectHeaderStyle = lipgloss.NewStyle().
 		Bold(true).
@@ -381,6 +452,9 @@ func InitStyles(theme config.ThemeConfig, graphColors ...[]string) {
 	ConductorWarningTextStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color(theme.FeedbackWarningFG))
 
+	CommitWarningStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(theme.FeedbackWarningFG))
+
 	CommitSectionHeaderStyle = lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color(theme.Dim))
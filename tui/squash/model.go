@@ -0,0 +1,179 @@
+package squash
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dylan/gitdash/tui/shared"
+)
+
+type Mode int
+
+const (
+	CountMode Mode = iota
+	MessageMode
+)
+
+type ActionKind int
+
+const (
+	ActionNone ActionKind = iota
+	ActionClose
+	ActionPrepare // count entered; the app checks it and loads the subjects
+	ActionSubmit
+)
+
+type KeyResult struct {
+	Action  ActionKind
+	N       int
+	Message string
+	Pushed  bool
+}
+
+// Model prompts for how many commits to squash, then for the combined
+// message. Nothing runs from here; the app confirms before rewriting.
+type Model struct {
+	mode       Mode
+	repoPath   string
+	repoName   string
+	n          int
+	pushed     bool // some of the n commits are already on the remote
+	err        string
+	countInput textinput.Model
+	msgArea    textarea.Model
+}
+
+func New() Model {
+	ci := textinput.New()
+	ci.Placeholder = "2"
+	ci.CharLimit = 4
+	ci.Width = 6
+
+	ta := textarea.New()
+	ta.Prompt = "  "
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	ta.SetWidth(64)
+	ta.SetHeight(8)
+
+	return Model{countInput: ci, msgArea: ta}
+}
+
+// Open resets the prompt for repoPath, starting at the count.
+func (m *Model) Open(repoPath, repoName string) {
+	m.mode = CountMode
+	m.repoPath = repoPath
+	m.repoName = repoName
+	m.n = 0
+	m.err = ""
+	m.countInput.SetValue("")
+	m.countInput.Focus()
+	m.msgArea.Blur()
+}
+
+// RepoPath returns the repo the prompt was opened for.
+func (m Model) RepoPath() string {
+	return m.repoPath
+}
+
+// SetError shows why the entered count can't be squashed.
+func (m *Model) SetError(err error) {
+	m.err = err.Error()
+}
+
+// SetSubjects moves on to the message, pre-filled with the subjects of
+// the n commits being squashed.
+func (m *Model) SetSubjects(n int, subjects []string, pushed bool) {
+	m.mode = MessageMode
+	m.n = n
+	m.pushed = pushed
+	m.err = ""
+	m.countInput.Blur()
+	m.msgArea.SetValue(strings.Join(subjects, "\n"))
+	m.msgArea.Focus()
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	if m.mode == MessageMode {
+		m.msgArea, cmd = m.msgArea.Update(msg)
+	} else {
+		m.countInput, cmd = m.countInput.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m *Model) HandleKey(msg tea.KeyMsg) KeyResult {
+	if m.mode == MessageMode {
+		switch msg.String() {
+		case "esc":
+			m.mode = CountMode
+			m.msgArea.Blur()
+			m.countInput.Focus()
+		case "ctrl+y":
+			message := strings.TrimSpace(m.msgArea.Value())
+			if message == "" {
+				return KeyResult{Action: ActionNone}
+			}
+			return KeyResult{Action: ActionSubmit, N: m.n, Message: message, Pushed: m.pushed}
+		}
+		return KeyResult{Action: ActionNone}
+	}
+
+	switch msg.String() {
+	case "esc":
+		return KeyResult{Action: ActionClose}
+	case "enter":
+		n, err := strconv.Atoi(strings.TrimSpace(m.countInput.Value()))
+		if err != nil || n < 2 {
+			m.err = "enter a number of commits, 2 or more"
+			return KeyResult{Action: ActionNone}
+		}
+		return KeyResult{Action: ActionPrepare, N: n}
+	}
+	return KeyResult{Action: ActionNone}
+}
+
+func (m Model) ViewOverlay(background string, w, h int) string {
+	overlay := shared.BranchPickerOverlayStyle.Render(m.renderContent())
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color("0")),
+	)
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+
+	b.WriteString(shared.TitleStyle.Render("Squash Commits"))
+	b.WriteString(" ")
+	b.WriteString(shared.GraphHashStyle.Render(m.repoName))
+	b.WriteString("\n\n")
+
+	if m.mode == MessageMode {
+		b.WriteString("Message for the " + shared.BranchCurrentStyle.Render(strconv.Itoa(m.n)) + " squashed commits\n")
+		b.WriteString(m.msgArea.View())
+		if m.pushed {
+			b.WriteString("\n")
+			b.WriteString(shared.ErrorStyle.Render("Some of these commits are already pushed; pushing the squash needs --force"))
+		}
+		b.WriteString("\n\n")
+		b.WriteString(shared.HelpDescStyle.Render("C-y: squash  esc: back"))
+		return b.String()
+	}
+
+	b.WriteString("Squash the last ")
+	b.WriteString(m.countInput.View())
+	b.WriteString(" commits")
+	if m.err != "" {
+		b.WriteString("\n")
+		b.WriteString(shared.ErrorStyle.Render(m.err))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(shared.HelpDescStyle.Render("enter: next  esc: cancel"))
+	return b.String()
+}
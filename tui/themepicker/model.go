@@ -0,0 +1,114 @@
+package themepicker
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dylan/gitdash/config"
+	"github.com/dylan/gitdash/tui/shared"
+)
+
+type ActionKind int
+
+const (
+	ActionNone ActionKind = iota
+	ActionPreview
+	ActionApply
+	ActionCancel
+)
+
+type KeyResult struct {
+	Action ActionKind
+	Preset string
+}
+
+// Model is a bottom-docked panel that cycles through the bundled theme
+// presets. The dashboard stays visible above it so previews apply live.
+type Model struct {
+	cursor   int
+	original string // preset active when the picker opened
+}
+
+func New() Model {
+	return Model{}
+}
+
+// Open starts the picker on the current preset.
+func (m *Model) Open(current string) {
+	m.original = current
+	m.cursor = 0
+	for i, name := range config.ThemePresets {
+		if name == current {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+func (m *Model) HandleKey(msg tea.KeyMsg) KeyResult {
+	n := len(config.ThemePresets)
+	switch msg.String() {
+	case "esc", "q":
+		return KeyResult{Action: ActionCancel, Preset: m.original}
+	case "l", "right", "j", "down", "tab":
+		m.cursor = (m.cursor + 1) % n
+		return KeyResult{Action: ActionPreview, Preset: config.ThemePresets[m.cursor]}
+	case "h", "left", "k", "up", "shift+tab":
+		m.cursor = (m.cursor - 1 + n) % n
+		return KeyResult{Action: ActionPreview, Preset: config.ThemePresets[m.cursor]}
+	case "enter":
+		return KeyResult{Action: ActionApply, Preset: config.ThemePresets[m.cursor]}
+	}
+	return KeyResult{Action: ActionNone}
+}
+
+// ViewOverlay replaces the bottom rows of background with the picker panel.
+func (m Model) ViewOverlay(background string, w, h int) string {
+	panel := strings.Split(m.renderPanel(w), "\n")
+	lines := strings.Split(background, "\n")
+	for len(lines) < h {
+		lines = append(lines, "")
+	}
+	lines = lines[:h]
+	start := h - len(panel)
+	if start < 0 {
+		start = 0
+	}
+	copy(lines[start:], panel)
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) renderPanel(w int) string {
+	var b strings.Builder
+
+	b.WriteString(shared.TitleStyle.Render("Theme"))
+	b.WriteString("  ")
+	for i, name := range config.ThemePresets {
+		if i == m.cursor {
+			b.WriteString(shared.BranchPrefixStyle.Render("[" + name + "]"))
+		} else {
+			b.WriteString(shared.GraphHashStyle.Render(" " + name + " "))
+		}
+		b.WriteString(" ")
+	}
+	b.WriteString("\n")
+
+	// Swatches of the highlighted preset's key colors
+	t, _ := config.PresetTheme(config.ThemePresets[m.cursor])
+	for _, c := range []string{t.Accent, t.Accent2, t.Staged, t.Unstaged, t.Branch, t.Muted, t.Dim} {
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(c)).Render("██"))
+		b.WriteString(" ")
+	}
+	b.WriteString("\n")
+	b.WriteString(shared.HelpDescStyle.Render("h/l: preview  enter: save to config  esc: cancel"))
+
+	width := w - 2 // border
+	if width < 1 {
+		width = 1
+	}
+	return shared.BranchPickerOverlayStyle.
+		Padding(0, 1).
+		Width(width).
+		Render(b.String())
+}
@@ -0,0 +1,266 @@
+// Package watch reports which repos changed on disk, so their status can be
+// refreshed as soon as something happens instead of on the next poll.
+package watch
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dylan/gitdash/git"
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// debounce is how long a repo must be quiet before it's reported, so a
+	// burst of writes (a save, a checkout) becomes one refresh.
+	debounce = 300 * time.Millisecond
+	// maxDelay bounds the wait while events keep coming, e.g. during a build.
+	maxDelay = 2 * time.Second
+	// maxDirs is the most directories watched per repo. Bigger trees are
+	// left to polling rather than eating into the OS watch limit.
+	maxDirs = 4000
+)
+
+// gitFiles are the entries directly in the git dir whose changes matter:
+// HEAD moves, the index, merges and rebases, fetches.
+var gitFiles = map[string]bool{
+	"HEAD": true, "index": true, "packed-refs": true, "FETCH_HEAD": true,
+	"ORIG_HEAD": true, "MERGE_HEAD": true, "REBASE_HEAD": true, "CHERRY_PICK_HEAD": true,
+	"rebase-merge": true, "rebase-apply": true,
+}
+
+// Watcher watches the working trees of a set of repos, plus the parts of
+// their git dirs that status depends on.
+type Watcher struct {
+	fs *fsnotify.Watcher
+
+	mu      sync.Mutex
+	dirs    map[string]string // watched dir -> repo path
+	gitDirs map[string]string // git dir -> repo path (only gitFiles count)
+	repos   map[string]bool   // repos that are fully watched
+
+	changes chan []string
+	done    chan struct{}
+}
+
+// New starts watching repoPaths. A repo that can't be watched (too many
+// directories, or the OS limit is hit) is skipped, and Watching reports
+// false for it so the caller keeps polling it. New only fails when no
+// watcher can be created at all.
+func New(repoPaths []string) (*Watcher, error) {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{
+		fs:      fw,
+		dirs:    make(map[string]string),
+		gitDirs: make(map[string]string),
+		repos:   make(map[string]bool),
+		changes: make(chan []string, 1),
+		done:    make(chan struct{}),
+	}
+	for _, p := range repoPaths {
+		if w.addRepo(p) == nil {
+			w.repos[p] = true
+		}
+	}
+	go w.run()
+	return w, nil
+}
+
+// Watching reports whether changes to repoPath are being watched.
+func (w *Watcher) Watching(repoPath string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.repos[repoPath]
+}
+
+// Changes delivers the paths of repos that changed, debounced.
+func (w *Watcher) Changes() <-chan []string {
+	return w.changes
+}
+
+func (w *Watcher) Close() error {
+	close(w.done)
+	return w.fs.Close()
+}
+
+// addRepo watches every non-ignored directory of the working tree, the git
+// dir and its refs. On failure nothing of the repo stays watched.
+func (w *Watcher) addRepo(repoPath string) error {
+	dirs, err := treeDirs(repoPath, repoPath)
+	if err != nil {
+		return err
+	}
+	gitDir := git.GitDir(repoPath)
+	refDirs, _ := treeDirs(filepath.Join(git.CommonDir(gitDir), "refs"), "")
+	dirs = append(dirs, refDirs...)
+	if len(dirs) > maxDirs {
+		return fmt.Errorf("%s: %d directories, more than %d", repoPath, len(dirs), maxDirs)
+	}
+
+	added := []string{}
+	for _, d := range append(dirs, gitDir) {
+		if err := w.fs.Add(d); err != nil {
+			for _, a := range added {
+				w.fs.Remove(a)
+			}
+			return err
+		}
+		added = append(added, d)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, d := range dirs {
+		w.dirs[d] = repoPath
+	}
+	w.gitDirs[gitDir] = repoPath
+	return nil
+}
+
+// treeDirs lists root and the directories under it, skipping .git and, when
+// repoPath is set, anything git ignores in that repo.
+func treeDirs(root, repoPath string) ([]string, error) {
+	ignored := map[string]bool{}
+	if repoPath != "" {
+		rel, err := git.IgnoredDirs(repoPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rel {
+			ignored[filepath.Join(repoPath, r)] = true
+		}
+	}
+
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil // unreadable subdirectory; skip it
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && (d.Name() == ".git" || ignored[path]) {
+			return filepath.SkipDir
+		}
+		dirs = append(dirs, path)
+		if len(dirs) > maxDirs {
+			return errors.New("too many directories")
+		}
+		return nil
+	})
+	return dirs, err
+}
+
+// repoFor maps an event to the repo it concerns, or "" if it doesn't
+// affect status.
+func (w *Watcher) repoFor(ev fsnotify.Event) string {
+	if ev.Op == fsnotify.Chmod || strings.HasSuffix(ev.Name, ".lock") {
+		return ""
+	}
+	dir, name := filepath.Split(ev.Name)
+	dir = filepath.Clean(dir)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if repo, ok := w.gitDirs[dir]; ok {
+		if gitFiles[name] {
+			return repo
+		}
+		return ""
+	}
+	return w.dirs[dir]
+}
+
+// watchNewDir starts watching a directory created inside a watched repo,
+// unless git ignores it.
+func (w *Watcher) watchNewDir(path, repoPath string) {
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return
+	}
+	if git.IsIgnored(repoPath, path) {
+		return
+	}
+	dirs, _ := treeDirs(path, repoPath)
+	for _, d := range dirs {
+		if w.fs.Add(d) == nil {
+			w.mu.Lock()
+			w.dirs[d] = repoPath
+			w.mu.Unlock()
+		}
+	}
+}
+
+func (w *Watcher) run() {
+	pending := map[string]bool{}
+	var first time.Time
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+
+		case ev, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			repo := w.repoFor(ev)
+			if repo == "" {
+				continue
+			}
+			if ev.Has(fsnotify.Create) {
+				w.watchNewDir(ev.Name, repo)
+			}
+			if len(pending) == 0 {
+				first = time.Now()
+			}
+			pending[repo] = true
+			if time.Since(first) < maxDelay {
+				timer.Reset(debounce)
+			}
+
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				// Events were dropped; anything may have changed
+				w.mu.Lock()
+				for repo := range w.repos {
+					pending[repo] = true
+				}
+				w.mu.Unlock()
+				timer.Reset(debounce)
+			}
+
+		case <-timer.C:
+			if len(pending) == 0 {
+				continue
+			}
+			paths := make([]string, 0, len(pending))
+			for p := range pending {
+				paths = append(paths, p)
+			}
+			select {
+			case w.changes <- paths:
+				pending = map[string]bool{}
+			default:
+				// The last batch hasn't been picked up yet; try again shortly
+				timer.Reset(debounce)
+			}
+		}
+	}
+}